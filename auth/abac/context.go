@@ -0,0 +1,17 @@
+package abac
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/database"
+)
+
+// CtxWithSkipPolicies creates the context for the queries that are not authorized by the policy engine. It should
+// be used only for the internal, trusted queries. It is equivalent to the database.CtxWithSkipAuthorization.
+func CtxWithSkipPolicies(ctx context.Context) context.Context {
+	return database.CtxWithSkipAuthorization(ctx)
+}
+
+func isSkipped(ctx context.Context) bool {
+	return database.CtxSkipAuthorization(ctx)
+}
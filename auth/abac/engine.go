@@ -0,0 +1,272 @@
+// Package abac implements the attribute-based access control policy engine. The policies are declared per model
+// structure and are evaluated for each database query, when the engine is set as the database query authorizer
+// i.e. using database.WithQueryAuthorizer(engine). The policy rules might allow or deny the query, or narrow it by
+// adding the filters to the query scope. The field permissions restrict the attributes and foreign keys read or
+// written by the queries to the accounts with given roles. The queries of the auth packages on their own models,
+// i.e. the roles lookups of the rbac verifier, are trusted and skip the engine, so that these models need no policies.
+package abac
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// Compile time check for the database.QueryAuthorizer interface.
+var _ database.QueryAuthorizer = &Engine{}
+
+// Engine is the attribute based access control policy engine. It evaluates the policies declared for the model
+// structures against the account stored in the context, the query method and the query scope.
+type Engine struct {
+	Options *Options
+
+	policies map[*mapping.ModelStruct][]Rule
+	fields   map[*mapping.ModelStruct]map[*mapping.StructField]*FieldPermission
+	lock     sync.RWMutex
+}
+
+// New creates new policy engine.
+func New(options ...Option) *Engine {
+	o := &Options{DefaultEffect: Allow}
+	for _, option := range options {
+		option(o)
+	}
+	return &Engine{
+		Options:  o,
+		policies: map[*mapping.ModelStruct][]Rule{},
+		fields:   map[*mapping.ModelStruct]map[*mapping.StructField]*FieldPermission{},
+	}
+}
+
+// SetPolicy sets the policy 'rules' for the model 'mStruct'. It replaces any previously declared rules.
+func (e *Engine) SetPolicy(mStruct *mapping.ModelStruct, rules ...Rule) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.policies[mStruct] = rules
+}
+
+// AuthorizeQuery implements database.QueryAuthorizer interface. All the rules of the model policy are evaluated.
+// The query is denied if any rule denies it or if none of the rules allows it. If all allowing rules narrows the
+// query, the scope is narrowed to the models matching all the filters of any of these rules. For the queries with
+// the models and without filters, the models must match the filters. The inserted models and the values written
+// by the updates must match them as well. Without a policy for the model the query has the default effect.
+// The allowed queries have the field permissions applied on their field sets.
+func (e *Engine) AuthorizeQuery(ctx context.Context, db database.DB, method query.Method, s *query.Scope) error {
+	if isSkipped(ctx) {
+		return nil
+	}
+	if err := e.authorizePolicy(ctx, db, method, s); err != nil {
+		return err
+	}
+	return e.authorizeFields(ctx, method, s)
+}
+
+func (e *Engine) authorizePolicy(ctx context.Context, db database.DB, method query.Method, s *query.Scope) error {
+	e.lock.RLock()
+	rules, ok := e.policies[s.ModelStruct]
+	e.lock.RUnlock()
+	if !ok {
+		if e.Options.DefaultEffect == Deny {
+			return errForbidden(s.ModelStruct, method)
+		}
+		return nil
+	}
+
+	account, _ := auth.CtxGetAccount(ctx)
+	var (
+		allowed, unrestricted bool
+		groups                [][]filter.Filter
+	)
+	for _, rule := range rules {
+		decision, err := rule.Evaluate(ctx, account, method, s)
+		if err != nil {
+			return err
+		}
+		switch decision.Effect {
+		case Deny:
+			return errForbidden(s.ModelStruct, method)
+		case Allow:
+			allowed = true
+			if len(decision.Filters) == 0 {
+				unrestricted = true
+			} else {
+				groups = append(groups, decision.Filters)
+			}
+		}
+	}
+	if !allowed {
+		return errForbidden(s.ModelStruct, method)
+	}
+	if unrestricted || len(groups) == 0 {
+		return nil
+	}
+	return narrow(ctx, db, method, s, groups)
+}
+
+// narrow narrows the scope 's' to the models matching all the filters of any of the rule filter 'groups'.
+func narrow(ctx context.Context, db database.DB, method query.Method, s *query.Scope, groups [][]filter.Filter) error {
+	switch method {
+	case query.Insert, query.InsertMany:
+		for _, model := range s.Models {
+			matches, err := matchAny(model, groups)
+			if err != nil {
+				return err
+			}
+			if !matches {
+				return errForbidden(s.ModelStruct, method)
+			}
+		}
+		return nil
+	case query.Update, query.UpdateMany:
+		// The updated values cannot move the models out of the allowed ones.
+		if err := matchWritten(s, method, groups); err != nil {
+			return err
+		}
+	}
+	filters, err := combineFilters(groups)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(s.Models) > 0 && len(s.Filters) == 0:
+		// The queries on the models needs to check if all of them matches the filters.
+		primaryKeys := make([]interface{}, len(s.Models))
+		for i, model := range s.Models {
+			primaryKeys[i] = model.GetPrimaryKeyValue()
+		}
+		check := query.NewScope(s.ModelStruct)
+		check.Transaction = s.Transaction
+		check.Filter(filter.New(s.ModelStruct.Primary(), filter.OpIn, primaryKeys...))
+		for _, f := range filters {
+			check.Filter(f)
+		}
+		count, err := database.Count(CtxWithSkipPolicies(ctx), db, check)
+		if err != nil {
+			return err
+		}
+		if count != int64(len(s.Models)) {
+			return errForbidden(s.ModelStruct, method)
+		}
+	default:
+		for _, f := range filters {
+			s.Filter(f)
+		}
+	}
+	return nil
+}
+
+// combineFilters combines the rule filter 'groups' into the filters that matches the models satisfying all the
+// filters of any group. The disjunction of the groups is converted into the conjunction of the filter.OrGroup
+// filters, thus the filters of multiple groups needs to be simple.
+func combineFilters(groups [][]filter.Filter) ([]filter.Filter, error) {
+	if len(groups) == 1 {
+		return groups[0], nil
+	}
+	clauses := []filter.OrGroup{{}}
+	for _, filters := range groups {
+		next := make([]filter.OrGroup, 0, len(clauses)*len(filters))
+		for _, clause := range clauses {
+			for _, f := range filters {
+				simple, ok := f.(filter.Simple)
+				if !ok {
+					return nil, errors.WrapDetf(auth.ErrInternalError, "filter: '%s' of multiple allowing rules cannot be combined", f)
+				}
+				combined := make(filter.OrGroup, len(clause), len(clause)+1)
+				copy(combined, clause)
+				next = append(next, append(combined, simple))
+			}
+		}
+		clauses = next
+	}
+	filters := make([]filter.Filter, len(clauses))
+	for i, clause := range clauses {
+		filters[i] = clause
+	}
+	return filters, nil
+}
+
+// matchWritten checks if the values written by the scope 's' models matches the simple filters of any of the
+// rule filter 'groups'. Only the filters on the written fields are matched.
+func matchWritten(s *query.Scope, method query.Method, groups [][]filter.Filter) error {
+	for i, model := range s.Models {
+		fielder, ok := model.(mapping.Fielder)
+		if !ok {
+			return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.Fielder interface", model)
+		}
+		writtenGroups := make([][]filter.Filter, len(groups))
+		for j, filters := range groups {
+			for _, f := range filters {
+				simple, ok := f.(filter.Simple)
+				if !ok {
+					continue
+				}
+				written, err := isWritten(s, i, fielder, simple.StructField)
+				if err != nil {
+					return err
+				}
+				if written {
+					writtenGroups[j] = append(writtenGroups[j], simple)
+				}
+			}
+		}
+		matches, err := matchAny(model, writtenGroups)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			return errForbidden(s.ModelStruct, method)
+		}
+	}
+	return nil
+}
+
+// matchAny checks if the 'model' field values matches all the filters of any of the 'groups'.
+func matchAny(model mapping.Model, groups [][]filter.Filter) (bool, error) {
+	for _, filters := range groups {
+		matches, err := matchModel(model, filters)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+// matchModel checks if the 'model' field values matches the equal and in operator 'filters'.
+func matchModel(model mapping.Model, filters []filter.Filter) (bool, error) {
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return false, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.Fielder interface", model)
+	}
+	for _, f := range filters {
+		simple, ok := f.(filter.Simple)
+		if !ok || (simple.Operator != filter.OpEqual && simple.Operator != filter.OpIn) {
+			return false, errors.WrapDetf(auth.ErrInternalError, "filter: '%s' cannot be matched with the model values", f)
+		}
+		value, err := fielder.GetFieldValue(simple.StructField)
+		if err != nil {
+			return false, err
+		}
+		var matches bool
+		for _, filterValue := range simple.Values {
+			if matches = reflect.DeepEqual(value, filterValue); matches {
+				break
+			}
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func errForbidden(mStruct *mapping.ModelStruct, method query.Method) error {
+	return errors.WrapDetf(auth.ErrForbidden, "query method: '%d' on the model: '%s' is forbidden by the policy", method, mStruct).
+		WithDetail("Access forbidden.")
+}
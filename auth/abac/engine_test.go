@@ -0,0 +1,219 @@
+package abac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+)
+
+func testDB(t *testing.T, options ...Option) (*Engine, database.DB, *mapping.ModelStruct) {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(Neuron_Models...))
+	e := New(options...)
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m), database.WithQueryAuthorizer(e))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+
+	posts := m.MustModelStruct(&Post{})
+	ctx := CtxWithSkipPolicies(context.Background())
+	require.NoError(t, db.Insert(ctx, posts,
+		&Post{AuthorID: 1, Title: "first"},
+		&Post{AuthorID: 2, Title: "second"},
+		&Post{AuthorID: 1, Title: "third"},
+	))
+	return e, db, posts
+}
+
+func postTitles(models []mapping.Model) []string {
+	titles := []string{}
+	for _, model := range models {
+		titles = append(titles, model.(*Post).Title)
+	}
+	return titles
+}
+
+func TestOwnerPolicy(t *testing.T) {
+	e, db, posts := testDB(t)
+	e.SetPolicy(posts,
+		AllowMethods(query.List, query.Get),
+		AllowOwner("AuthorID", query.Insert, query.Update, query.UpdateMany, query.Delete, query.DeleteMany),
+	)
+	ctx := auth.CtxWithAccount(context.Background(), &testmodels.User{ID: 1})
+
+	models, err := db.QueryCtx(ctx, posts).Find()
+	require.NoError(t, err)
+	assert.Len(t, models, 3)
+
+	t.Run("Insert", func(t *testing.T) {
+		err := db.Insert(ctx, posts, &Post{AuthorID: 2, Title: "foreign"})
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+		require.NoError(t, db.Insert(ctx, posts, &Post{AuthorID: 1, Title: "own"}))
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		_, err := db.Update(ctx, posts, &Post{ID: 2, Title: "changed"})
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+		_, err = db.Update(ctx, posts, &Post{ID: 1, Title: "changed"}, &Post{ID: 2, Title: "changed"})
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+		affected, err := db.QueryCtx(ctx, posts, &Post{ID: 1, Title: "changed"}).Select(posts.MustFieldByName("Title")).Update()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), affected)
+
+		// The filtered update is narrowed to the account posts.
+		affected, err = db.QueryCtx(ctx, posts, &Post{Title: "all"}).Where("ID IN ?", 1, 2, 3).Update()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), affected)
+
+		second, err := db.QueryCtx(ctx, posts).Where("ID = ?", 2).Get()
+		require.NoError(t, err)
+		assert.Equal(t, "second", second.(*Post).Title)
+	})
+
+	t.Run("HandOver", func(t *testing.T) {
+		// The updated owner field cannot move the post to the other account.
+		_, err := db.Update(ctx, posts, &Post{ID: 1, AuthorID: 2})
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+		_, err = db.QueryCtx(ctx, posts, &Post{AuthorID: 2}).Where("ID = ?", 3).Update()
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+		_, err = db.QueryCtx(ctx, posts, &Post{ID: 1, AuthorID: 2}).Select(posts.MustFieldByName("AuthorID")).Update()
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+		affected, err := db.QueryCtx(ctx, posts, &Post{ID: 1, AuthorID: 1, Title: "all"}).Update()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), affected)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		_, err := db.Delete(ctx, posts, &Post{ID: 2})
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+		affected, err := db.QueryCtx(ctx, posts).Where("Title = ?", "all").Delete()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), affected)
+
+		models, err := db.QueryCtx(ctx, posts).Find()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"second", "own"}, postTitles(models))
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		err := db.Insert(context.Background(), posts, &Post{AuthorID: 1, Title: "anonymous"})
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+		_, err = db.QueryCtx(context.Background(), posts).Find()
+		assert.NoError(t, err)
+	})
+}
+
+func TestNarrowedRead(t *testing.T) {
+	e, db, posts := testDB(t)
+	e.SetPolicy(posts, AllowOwner("AuthorID"))
+	ctx := auth.CtxWithAccount(context.Background(), &testmodels.User{ID: 1})
+
+	models, err := db.QueryCtx(ctx, posts).Find()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"first", "third"}, postTitles(models))
+
+	count, err := db.QueryCtx(ctx, posts).Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	_, err = db.QueryCtx(ctx, posts).Where("ID = ?", 2).Get()
+	assert.True(t, errors.Is(err, query.ErrNoResult))
+
+	err = db.Refresh(ctx, posts, &Post{ID: 2})
+	assert.True(t, errors.Is(err, query.ErrNoResult))
+
+	_, err = db.QueryCtx(context.Background(), posts).Find()
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+	// The policies are not applied for the skipped context.
+	models, err = db.QueryCtx(CtxWithSkipPolicies(ctx), posts).Find()
+	require.NoError(t, err)
+	assert.Len(t, models, 3)
+}
+
+func TestAlternativeRules(t *testing.T) {
+	e, db, posts := testDB(t)
+	title := posts.MustFieldByName("Title")
+	e.SetPolicy(posts, AllowOwner("AuthorID"), RuleFunc(func(_ context.Context, _ auth.Account, _ query.Method, _ *query.Scope) (*Decision, error) {
+		return &Decision{Effect: Allow, Filters: []filter.Filter{filter.New(title, filter.OpEqual, "second")}}, nil
+	}))
+	ctx := auth.CtxWithAccount(context.Background(), &testmodels.User{ID: 1})
+
+	// The account reads the posts allowed by any of the rules.
+	models, err := db.QueryCtx(ctx, posts).Find()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"first", "second", "third"}, postTitles(models))
+
+	require.NoError(t, db.Refresh(ctx, posts, &Post{ID: 2}))
+	require.NoError(t, db.Insert(ctx, posts, &Post{AuthorID: 2, Title: "second"}))
+	err = db.Insert(ctx, posts, &Post{AuthorID: 2, Title: "foreign"})
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+	models, err = db.QueryCtx(ctx, posts).Where("AuthorID = ?", 2).Find()
+	require.NoError(t, err)
+	assert.Len(t, models, 2)
+}
+
+func TestDecisions(t *testing.T) {
+	e, db, posts := testDB(t)
+	ctx := auth.CtxWithAccount(context.Background(), &testmodels.User{ID: 1})
+
+	// The deny overrides all the allowing rules.
+	e.SetPolicy(posts, AllowAuthenticated(), DenyMethods(query.DeleteMany))
+	_, err := db.QueryCtx(ctx, posts).Find()
+	assert.NoError(t, err)
+	_, err = db.QueryCtx(ctx, posts).Where("AuthorID = ?", 1).Delete()
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+	// An unrestricted allow makes the narrowing rules irrelevant.
+	e.SetPolicy(posts, AllowOwner("AuthorID"), AllowMethods(query.List))
+	models, err := db.QueryCtx(ctx, posts).Find()
+	require.NoError(t, err)
+	assert.Len(t, models, 3)
+
+	// None of the rules is applicable.
+	e.SetPolicy(posts, AllowMethods(query.Get))
+	_, err = db.QueryCtx(ctx, posts).Find()
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+	// The custom rule function.
+	e.SetPolicy(posts, RuleFunc(func(context.Context, auth.Account, query.Method, *query.Scope) (*Decision, error) {
+		return &Decision{Effect: Allow}, nil
+	}))
+	_, err = db.QueryCtx(ctx, posts).Find()
+	assert.NoError(t, err)
+
+	e.SetPolicy(posts, AllowOwner("Unknown"))
+	_, err = db.QueryCtx(ctx, posts).Find()
+	assert.True(t, errors.Is(err, auth.ErrInternalError))
+}
+
+func TestDefaultEffect(t *testing.T) {
+	e, db, _ := testDB(t)
+	comments := db.ModelMap().MustModelStruct(&Comment{})
+	_, err := db.QueryCtx(context.Background(), comments).Find()
+	assert.NoError(t, err)
+
+	e.Options.DefaultEffect = Deny
+	_, err = db.QueryCtx(context.Background(), comments).Find()
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+	e, db, _ = testDB(t, WithDefaultEffect(Deny))
+	assert.Equal(t, Deny, e.Options.DefaultEffect)
+	_, err = db.QueryCtx(context.Background(), db.ModelMap().MustModelStruct(&Comment{})).Find()
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+}
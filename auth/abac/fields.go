@@ -0,0 +1,257 @@
+package abac
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// FieldPermission is the field level permission. The field could be read or written only by the accounts with any
+// of the permission roles.
+type FieldPermission struct {
+	// ReadRoles are the roles allowed to read the field. If empty, the field could be read by any account.
+	ReadRoles []auth.Role
+	// WriteRoles are the roles allowed to write the field. If empty, the field could be written by any account.
+	WriteRoles []auth.Role
+	// RejectWrite rejects the insert and update queries that writes the field without the permission. By default
+	// the field is stripped from these queries.
+	RejectWrite bool
+}
+
+// SetFieldPermission sets the 'permission' for the attribute or foreign key 'field'. The account roles are checked
+// using the engine verifier, thus it must be set with the WithVerifier option.
+func (e *Engine) SetFieldPermission(field *mapping.StructField, permission FieldPermission) error {
+	if e.Options.Verifier == nil {
+		return errors.WrapDet(auth.ErrInitialization, "field permissions requires the engine verifier")
+	}
+	if kind := field.Kind(); kind != mapping.KindAttribute && kind != mapping.KindForeignKey {
+		return errors.WrapDetf(auth.ErrInitialization, "field: '%s' is not an attribute nor a foreign key", field)
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	permissions, ok := e.fields[field.Struct()]
+	if !ok {
+		permissions = map[*mapping.StructField]*FieldPermission{}
+		e.fields[field.Struct()] = permissions
+	}
+	permissions[field] = &permission
+	return nil
+}
+
+// authorizeFields applies the field permissions for the query with given 'method' and scope 's'. The read queries
+// have the forbidden fields pruned from their field sets and cannot filter nor sort by them. The write queries
+// have the forbidden fields stripped or are rejected.
+func (e *Engine) authorizeFields(ctx context.Context, method query.Method, s *query.Scope) error {
+	switch method {
+	case query.List, query.Get:
+		forbidden, err := e.forbiddenFields(ctx, s.ModelStruct, false)
+		if err != nil {
+			return err
+		}
+		if err = e.checkReadFilters(ctx, s, forbidden); err != nil {
+			return err
+		}
+		if len(forbidden) > 0 {
+			switch len(s.FieldSets) {
+			case 0:
+				s.FieldSets = append(s.FieldSets, pruneFieldSet(s.ModelStruct.Fields(), forbidden))
+			default:
+				for i := range s.FieldSets {
+					s.FieldSets[i] = pruneFieldSet(s.FieldSets[i], forbidden)
+					// An empty field set would be replaced with all the model fields.
+					if len(s.FieldSets[i]) == 0 {
+						s.FieldSets[i] = append(s.FieldSets[i], s.ModelStruct.Primary())
+					}
+				}
+			}
+		}
+		return e.pruneIncluded(ctx, s.IncludedRelations)
+	case query.Insert, query.InsertMany, query.Update, query.UpdateMany:
+		forbidden, err := e.forbiddenFields(ctx, s.ModelStruct, true)
+		if err != nil || len(forbidden) == 0 {
+			return err
+		}
+		return stripWrittenFields(s, forbidden)
+	}
+	return nil
+}
+
+// forbiddenFields gets the fields of the model 'mStruct' that the context account cannot read or 'write'.
+func (e *Engine) forbiddenFields(ctx context.Context, mStruct *mapping.ModelStruct, write bool) (map[*mapping.StructField]*FieldPermission, error) {
+	e.lock.RLock()
+	permissions := e.fields[mStruct]
+	e.lock.RUnlock()
+	if len(permissions) == 0 {
+		return nil, nil
+	}
+	account, _ := auth.CtxGetAccount(ctx)
+	forbidden := map[*mapping.StructField]*FieldPermission{}
+	for field, permission := range permissions {
+		roles := permission.ReadRoles
+		if write {
+			roles = permission.WriteRoles
+		}
+		if len(roles) == 0 {
+			continue
+		}
+		err := e.Options.Verifier.Verify(ctx, account, auth.VerifyAllowedRoles(roles...))
+		switch {
+		case err == nil:
+		case errors.Is(err, auth.ErrForbidden):
+			forbidden[field] = permission
+		default:
+			return nil, err
+		}
+	}
+	return forbidden, nil
+}
+
+// pruneIncluded prunes the forbidden fields from the 'included' relations field sets.
+func (e *Engine) pruneIncluded(ctx context.Context, included []*query.IncludedRelation) error {
+	for _, include := range included {
+		forbidden, err := e.forbiddenFields(ctx, include.StructField.Relationship().RelatedModelStruct(), false)
+		if err != nil {
+			return err
+		}
+		if len(forbidden) > 0 {
+			include.Fieldset = pruneFieldSet(include.Fieldset, forbidden)
+		}
+		if err = e.pruneIncluded(ctx, include.IncludedRelations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkReadFilters checks if the scope 's' doesn't filter nor sort by the 'forbidden' fields. The fields of the
+// related models, filtered or sorted within the relation filters and sorts, are checked with their model
+// permissions.
+func (e *Engine) checkReadFilters(ctx context.Context, s *query.Scope, forbidden map[*mapping.StructField]*FieldPermission) error {
+	fields, err := filterFields(s.Filters)
+	if err != nil {
+		return err
+	}
+	for _, sort := range s.SortingOrder {
+		fields = append(fields, sort.Field())
+		switch st := sort.(type) {
+		case query.RelationSort:
+			fields = append(fields, st.RelationFields...)
+		case *query.RelationSort:
+			fields = append(fields, st.RelationFields...)
+		}
+	}
+	modelsForbidden := map[*mapping.ModelStruct]map[*mapping.StructField]*FieldPermission{s.ModelStruct: forbidden}
+	for _, field := range fields {
+		mStruct := field.ModelStruct()
+		fieldsForbidden, ok := modelsForbidden[mStruct]
+		if !ok {
+			if fieldsForbidden, err = e.forbiddenFields(ctx, mStruct, false); err != nil {
+				return err
+			}
+			modelsForbidden[mStruct] = fieldsForbidden
+		}
+		if _, ok = fieldsForbidden[field]; ok {
+			return errors.WrapDetf(auth.ErrForbidden, "field: '%s' cannot be read", field).WithDetail("Access forbidden.")
+		}
+	}
+	return nil
+}
+
+// filterFields gets the fields used by the 'filters' and their nested relation filters. The filters of unknown
+// types cannot be checked and are rejected.
+func filterFields(filters []filter.Filter) ([]*mapping.StructField, error) {
+	var (
+		fields []*mapping.StructField
+		nested []filter.Filter
+	)
+	for _, f := range filters {
+		switch ft := f.(type) {
+		case filter.Simple:
+			fields = append(fields, ft.StructField)
+		case *filter.Simple:
+			fields = append(fields, ft.StructField)
+		case filter.OrGroup:
+			for _, simple := range ft {
+				fields = append(fields, simple.StructField)
+			}
+		case filter.Relation:
+			fields = append(fields, ft.StructField)
+			nested = append(nested, ft.Nested...)
+		case *filter.Relation:
+			fields = append(fields, ft.StructField)
+			nested = append(nested, ft.Nested...)
+		default:
+			return nil, errors.WrapDetf(auth.ErrForbidden, "unsupported filter type: '%T'", f).WithDetail("Access forbidden.")
+		}
+	}
+	if len(nested) == 0 {
+		return fields, nil
+	}
+	nestedFields, err := filterFields(nested)
+	if err != nil {
+		return nil, err
+	}
+	return append(fields, nestedFields...), nil
+}
+
+// stripWrittenFields strips the 'forbidden' fields written by the scope 's' or rejects the query if the field
+// permission requires it. The stripped fields are also set to zero values in the scope models.
+func stripWrittenFields(s *query.Scope, forbidden map[*mapping.StructField]*FieldPermission) error {
+	for i, model := range s.Models {
+		fielder, ok := model.(mapping.Fielder)
+		if !ok {
+			return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.Fielder interface", model)
+		}
+		for field, permission := range forbidden {
+			written, err := isWritten(s, i, fielder, field)
+			if err != nil {
+				return err
+			}
+			if !written {
+				continue
+			}
+			if permission.RejectWrite {
+				return errors.WrapDetf(auth.ErrForbidden, "field: '%s' cannot be written", field).WithDetail("Access forbidden.")
+			}
+			if err := fielder.SetFieldZeroValue(field); err != nil {
+				return err
+			}
+		}
+	}
+	for i := range s.FieldSets {
+		s.FieldSets[i] = pruneFieldSet(s.FieldSets[i], forbidden)
+	}
+	return nil
+}
+
+// isWritten checks if the 'field' of the scope 's' model at 'index' is written by the query. Without the field sets
+// the non zero fields are written.
+func isWritten(s *query.Scope, index int, fielder mapping.Fielder, field *mapping.StructField) (bool, error) {
+	switch len(s.FieldSets) {
+	case 0:
+		isZero, err := fielder.IsFieldZero(field)
+		if err != nil {
+			return false, err
+		}
+		return !isZero, nil
+	case 1:
+		return s.FieldSets[0].Contains(field), nil
+	default:
+		return index < len(s.FieldSets) && s.FieldSets[index].Contains(field), nil
+	}
+}
+
+// pruneFieldSet creates the copy of the 'fieldSet' without the 'forbidden' fields.
+func pruneFieldSet(fieldSet mapping.FieldSet, forbidden map[*mapping.StructField]*FieldPermission) mapping.FieldSet {
+	pruned := make(mapping.FieldSet, 0, len(fieldSet))
+	for _, field := range fieldSet {
+		if _, ok := forbidden[field]; !ok {
+			pruned = append(pruned, field)
+		}
+	}
+	return pruned
+}
@@ -0,0 +1,188 @@
+package abac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/auth/rbac"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+type testRole string
+
+func (r testRole) RoleName() string {
+	return string(r)
+}
+
+// testVerifier verifies the allowed roles of the accounts with the roles mapped by their primary keys.
+type testVerifier map[int][]string
+
+func (v testVerifier) Verify(_ context.Context, account auth.Account, options ...auth.VerifyOption) error {
+	o := &auth.VerifyOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if account == nil {
+		return auth.ErrForbidden
+	}
+	for _, role := range v[account.GetPrimaryKeyValue().(int)] {
+		for _, allowed := range o.AllowedRoles {
+			if allowed.RoleName() == role {
+				return nil
+			}
+		}
+	}
+	return auth.ErrForbidden
+}
+
+// testFilter is the filter of the type unknown to the engine.
+type testFilter struct{}
+
+func (f testFilter) Copy() filter.Filter {
+	return f
+}
+
+func (f testFilter) String() string {
+	return "test"
+}
+
+func TestFieldPermissions(t *testing.T) {
+	e, db, posts := testDB(t, WithVerifier(testVerifier{1: {"editor"}}))
+	notes := posts.MustFieldByName("Notes")
+	require.NoError(t, e.SetFieldPermission(notes, FieldPermission{
+		ReadRoles:  []auth.Role{testRole("editor")},
+		WriteRoles: []auth.Role{testRole("editor")},
+	}))
+	editor := auth.CtxWithAccount(context.Background(), &testmodels.User{ID: 1})
+	reader := auth.CtxWithAccount(context.Background(), &testmodels.User{ID: 2})
+
+	require.NoError(t, db.Insert(editor, posts, &Post{AuthorID: 1, Title: "noted", Notes: "internal"}))
+
+	t.Run("Read", func(t *testing.T) {
+		model, err := db.QueryCtx(editor, posts).Where("Title = ?", "noted").Get()
+		require.NoError(t, err)
+		assert.Equal(t, "internal", model.(*Post).Notes)
+
+		s := query.NewScope(posts)
+		s.Filter(filter.New(posts.MustFieldByName("Title"), filter.OpEqual, "noted"))
+		require.NoError(t, e.AuthorizeQuery(reader, db, query.List, s))
+		require.Len(t, s.FieldSets, 1)
+		assert.False(t, s.FieldSets[0].Contains(notes))
+		assert.True(t, s.FieldSets[0].Contains(posts.MustFieldByName("Title")))
+
+		model, err = db.QueryCtx(reader, posts).Where("Title = ?", "noted").Get()
+		require.NoError(t, err)
+		assert.Empty(t, model.(*Post).Notes)
+		assert.Equal(t, "noted", model.(*Post).Title)
+
+		model, err = db.QueryCtx(reader, posts).Select(notes).Where("Title = ?", "noted").Get()
+		require.NoError(t, err)
+		assert.Empty(t, model.(*Post).Notes)
+		assert.Empty(t, model.(*Post).Title)
+
+		_, err = db.QueryCtx(reader, posts).Where("Notes = ?", "internal").Find()
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+		sort, err := query.NewSort(posts, "Notes")
+		require.NoError(t, err)
+		_, err = db.QueryCtx(reader, posts).OrderBy(sort).Find()
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+		// The forbidden field is checked within the pointer and the relation filters. The nested relation fields
+		// are checked with the permissions of their own model.
+		simple := filter.New(notes, filter.OpEqual, "internal")
+		filters := map[string]filter.Filter{
+			"Pointer":  &simple,
+			"Relation": filter.Relation{StructField: posts.Primary(), Nested: []filter.Filter{simple}},
+			"Unknown":  testFilter{},
+		}
+		for name, f := range filters {
+			s := query.NewScope(posts)
+			s.Filter(f)
+			err = e.AuthorizeQuery(reader, db, query.List, s)
+			assert.True(t, errors.Is(err, auth.ErrForbidden), name)
+		}
+		relationSort := query.RelationSort{StructField: posts.Primary(), RelationFields: []*mapping.StructField{notes}}
+		for name, sort := range map[string]query.Sort{"RelationSort": relationSort, "RelationSortPointer": &relationSort} {
+			s := query.NewScope(posts)
+			s.SortingOrder = append(s.SortingOrder, sort)
+			err = e.AuthorizeQuery(reader, db, query.List, s)
+			assert.True(t, errors.Is(err, auth.ErrForbidden), name)
+		}
+		// The filters of unknown types are rejected even if there are no forbidden fields.
+		s = query.NewScope(posts)
+		s.Filter(testFilter{})
+		err = e.AuthorizeQuery(editor, db, query.List, s)
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		post := &Post{AuthorID: 2, Title: "stripped", Notes: "forged"}
+		require.NoError(t, db.Insert(reader, posts, post))
+		model, err := db.QueryCtx(editor, posts).Where("ID = ?", post.ID).Get()
+		require.NoError(t, err)
+		assert.Empty(t, model.(*Post).Notes)
+
+		_, err = db.QueryCtx(reader, posts, &Post{ID: post.ID, Title: "changed", Notes: "forged"}).
+			Select(posts.MustFieldByName("Title"), notes).Update()
+		require.NoError(t, err)
+		model, err = db.QueryCtx(editor, posts).Where("ID = ?", post.ID).Get()
+		require.NoError(t, err)
+		assert.Equal(t, "changed", model.(*Post).Title)
+		assert.Empty(t, model.(*Post).Notes)
+
+		require.NoError(t, e.SetFieldPermission(notes, FieldPermission{
+			WriteRoles:  []auth.Role{testRole("editor")},
+			RejectWrite: true,
+		}))
+		_, err = db.Update(reader, posts, &Post{ID: post.ID, Notes: "forged"})
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+		_, err = db.Update(editor, posts, &Post{ID: post.ID, Notes: "allowed"})
+		require.NoError(t, err)
+
+		// The read roles are not set anymore.
+		model, err = db.QueryCtx(reader, posts).Where("ID = ?", post.ID).Get()
+		require.NoError(t, err)
+		assert.Equal(t, "allowed", model.(*Post).Notes)
+	})
+}
+
+func TestSetFieldPermission(t *testing.T) {
+	e, _, posts := testDB(t)
+	err := e.SetFieldPermission(posts.MustFieldByName("Notes"), FieldPermission{})
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+
+	e.Options.Verifier = testVerifier{}
+	err = e.SetFieldPermission(posts.Primary(), FieldPermission{})
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+	assert.NoError(t, e.SetFieldPermission(posts.MustFieldByName("Notes"), FieldPermission{}))
+}
+
+func TestAuthModels(t *testing.T) {
+	// The auth packages queries on their own models are not authorized by the engine, thus the rbac verifier works
+	// without the policies for its models.
+	e, db, posts := testDB(t, WithDefaultEffect(Deny))
+	roles, err := rbac.New(db)
+	require.NoError(t, err)
+	e.Options.Verifier = roles
+	e.SetPolicy(posts, AllowMethods(query.List))
+	notes := posts.MustFieldByName("Notes")
+	require.NoError(t, e.SetFieldPermission(notes, FieldPermission{ReadRoles: []auth.Role{testRole("editor")}}))
+
+	ctx := context.Background()
+	editor, err := roles.CreateRole(ctx, "editor")
+	require.NoError(t, err)
+	require.NoError(t, roles.GrantRole(ctx, &testmodels.User{ID: 1}, editor))
+
+	_, err = db.QueryCtx(auth.CtxWithAccount(ctx, &testmodels.User{ID: 1}), posts).Where("Notes = ?", "").Find()
+	require.NoError(t, err)
+	_, err = db.QueryCtx(auth.CtxWithAccount(ctx, &testmodels.User{ID: 2}), posts).Where("Notes = ?", "").Find()
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+}
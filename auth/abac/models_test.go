@@ -0,0 +1,17 @@
+package abac
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=Post,Comment .
+
+// Post is the test model with the owner field.
+type Post struct {
+	ID       int
+	AuthorID int
+	Title    string
+	Notes    string
+}
+
+// Comment is the test model without the policy.
+type Comment struct {
+	ID   int
+	Body string
+}
@@ -0,0 +1,31 @@
+package abac
+
+import (
+	"github.com/neuronlabs/neuron/auth"
+)
+
+// Options are the policy engine options.
+type Options struct {
+	// DefaultEffect is the effect applied to the queries on the models without any policy.
+	DefaultEffect Effect
+	// Verifier is used to verify the account roles for the field permissions.
+	Verifier auth.Verifier
+}
+
+// Option is a function that sets the policy engine options.
+type Option func(o *Options)
+
+// WithDefaultEffect sets the effect applied to the queries on the models without any policy. By default these
+// queries are allowed.
+func WithDefaultEffect(effect Effect) Option {
+	return func(o *Options) {
+		o.DefaultEffect = effect
+	}
+}
+
+// WithVerifier sets the verifier used to check the account roles for the field permissions.
+func WithVerifier(verifier auth.Verifier) Option {
+	return func(o *Options) {
+		o.Verifier = verifier
+	}
+}
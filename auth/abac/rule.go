@@ -0,0 +1,125 @@
+package abac
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// Effect is the effect of the policy rule decision.
+type Effect int
+
+// Enum values for the rule effects.
+const (
+	// NotApplicable is the effect of the rule that doesn't apply to the query.
+	NotApplicable Effect = iota
+	// Allow is the effect of the rule that allows the query.
+	Allow
+	// Deny is the effect of the rule that denies the query.
+	Deny
+)
+
+// String implements fmt.Stringer interface.
+func (e Effect) String() string {
+	switch e {
+	case NotApplicable:
+		return "NotApplicable"
+	case Allow:
+		return "Allow"
+	case Deny:
+		return "Deny"
+	default:
+		return "Unknown"
+	}
+}
+
+// Decision is the result of the rule evaluation. An allowing decision with the filters narrows the query to
+// the models that matches all of them. The filters of different allowing rules are alternatives.
+type Decision struct {
+	Effect  Effect
+	Filters []filter.Filter
+}
+
+// Rule is the policy rule evaluated for the 'account' query with given 'method' and scope 's'. The account is nil
+// for the queries executed without an account in the context.
+type Rule interface {
+	Evaluate(ctx context.Context, account auth.Account, method query.Method, s *query.Scope) (*Decision, error)
+}
+
+// RuleFunc is the function that implements Rule interface.
+type RuleFunc func(ctx context.Context, account auth.Account, method query.Method, s *query.Scope) (*Decision, error)
+
+// Evaluate implements Rule interface.
+func (r RuleFunc) Evaluate(ctx context.Context, account auth.Account, method query.Method, s *query.Scope) (*Decision, error) {
+	return r(ctx, account, method, s)
+}
+
+// AllowMethods is the rule that allows the queries with given 'methods'. If no methods are provided the rule
+// applies to all of them.
+func AllowMethods(methods ...query.Method) Rule {
+	return methodsRule(Allow, methods)
+}
+
+// DenyMethods is the rule that denies the queries with given 'methods'. If no methods are provided the rule
+// applies to all of them.
+func DenyMethods(methods ...query.Method) Rule {
+	return methodsRule(Deny, methods)
+}
+
+// AllowAuthenticated is the rule that allows the queries with given 'methods' for the accounts with non zero
+// primary key. If no methods are provided the rule applies to all of them.
+func AllowAuthenticated(methods ...query.Method) Rule {
+	return RuleFunc(func(_ context.Context, account auth.Account, method query.Method, _ *query.Scope) (*Decision, error) {
+		if !hasMethod(methods, method) || !isAuthenticated(account) {
+			return &Decision{Effect: NotApplicable}, nil
+		}
+		return &Decision{Effect: Allow}, nil
+	})
+}
+
+// AllowOwner is the rule that allows the queries with given 'methods' only for the models which 'field' value
+// equals the account primary key. The query is narrowed to the models owned by the account. If no methods are
+// provided the rule applies to all of them.
+func AllowOwner(field string, methods ...query.Method) Rule {
+	return RuleFunc(func(_ context.Context, account auth.Account, method query.Method, s *query.Scope) (*Decision, error) {
+		if !hasMethod(methods, method) || !isAuthenticated(account) {
+			return &Decision{Effect: NotApplicable}, nil
+		}
+		sField, ok := s.ModelStruct.FieldByName(field)
+		if !ok {
+			return nil, errors.WrapDetf(auth.ErrInternalError, "owner field: '%s' not found in the model: '%s'", field, s.ModelStruct)
+		}
+		return &Decision{
+			Effect:  Allow,
+			Filters: []filter.Filter{filter.New(sField, filter.OpEqual, account.GetPrimaryKeyValue())},
+		}, nil
+	})
+}
+
+func methodsRule(effect Effect, methods []query.Method) Rule {
+	return RuleFunc(func(_ context.Context, _ auth.Account, method query.Method, _ *query.Scope) (*Decision, error) {
+		if !hasMethod(methods, method) {
+			return &Decision{Effect: NotApplicable}, nil
+		}
+		return &Decision{Effect: effect}, nil
+	})
+}
+
+func hasMethod(methods []query.Method, method query.Method) bool {
+	if len(methods) == 0 {
+		return true
+	}
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func isAuthenticated(account auth.Account) bool {
+	return account != nil && !account.IsPrimaryKeyZero()
+}
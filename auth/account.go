@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	"github.com/neuronlabs/neuron/errors"
 	"github.com/neuronlabs/neuron/mapping"
@@ -46,6 +47,17 @@ type SaltFielder interface {
 	SaltField() string
 }
 
+// PasswordAger is an interface for accounts that store the time of the last password change. It is used to
+// enforce the maximum password age.
+type PasswordAger interface {
+	// GetPasswordChangedAt gets the time of the last password change.
+	GetPasswordChangedAt() time.Time
+	// SetPasswordChangedAt sets the time of the last password change.
+	SetPasswordChangedAt(changedAt time.Time)
+	// PasswordChangedAtField gets the password changed at field name.
+	PasswordChangedAtField() string
+}
+
 type accountKey struct{}
 
 // CtxWithAccount stores account in the context.
@@ -59,6 +71,29 @@ func CtxGetAccount(ctx context.Context) (Account, bool) {
 	return acc, ok
 }
 
+type claimsKey struct{}
+
+// CtxWithClaims stores the token claims in the context.
+func CtxWithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// CtxGetClaims gets the token claims from the context 'ctx'.
+func CtxGetClaims(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
+
+// CtxMFASatisfied checks if the context token claims states that the multi-factor authentication is satisfied.
+func CtxMFASatisfied(ctx context.Context) bool {
+	claims, ok := CtxGetClaims(ctx)
+	if !ok {
+		return false
+	}
+	mfaClaims, ok := claims.(MFAClaims)
+	return ok && mfaClaims.MFASatisfied()
+}
+
 // UsernameValidator is a function used to validate the username for the account.
 type UsernameValidator func(username string) error
 
@@ -0,0 +1,12 @@
+package auth
+
+import (
+	"context"
+)
+
+// APIKeyAuthenticator is the interface used to authenticate the machine clients with the API keys.
+type APIKeyAuthenticator interface {
+	// AuthenticateAPIKey checks if the 'key' is valid and gets the account it belongs to. The returned claims
+	// describe the key. If they implement Scoper, the key is restricted to their scope.
+	AuthenticateAPIKey(ctx context.Context, key string) (Account, Claims, error)
+}
@@ -0,0 +1,221 @@
+// Package apikey implements the API key authentication of the machine clients. The keys belong to the accounts or
+// the service principals stored in the account model. Only the key secret hashes, created by the
+// auth.Authenticator, are stored in the database using the APIKey model. The key string has the form of
+// '<id>.<secret>'.
+package apikey
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// idLength is the number of the random bytes of the key identifier.
+const idLength = 12
+
+// Compile time check for the auth.APIKeyAuthenticator interface.
+var _ auth.APIKeyAuthenticator = &Manager{}
+
+// Manager creates, authenticates and revokes the API keys.
+type Manager struct {
+	Options *Options
+
+	db            database.DB
+	authenticator auth.Authenticator
+	keys          *mapping.ModelStruct
+	accounts      *mapping.ModelStruct
+}
+
+// New creates new API key manager for the 'db' and 'authenticator'. The APIKey model is registered in the database
+// model map if it is not yet registered. The account model option is required.
+func New(db database.DB, authenticator auth.Authenticator, options ...Option) (*Manager, error) {
+	o := defaultOptions()
+	for _, option := range options {
+		option(o)
+	}
+	if o.AccountModel == nil {
+		return nil, errors.WrapDet(auth.ErrAccountModelNotDefined, "no account model defined for the API key manager")
+	}
+	if authenticator == nil {
+		return nil, errors.WrapDet(auth.ErrInitialization, "the API key manager requires the authenticator")
+	}
+	if o.SecretLength < 16 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "API key secret length: '%d' is too short", o.SecretLength)
+	}
+	m := &Manager{Options: o, db: db, authenticator: authenticator}
+	var err error
+	if m.keys, err = db.ModelMap().ModelStruct(&APIKey{}); err != nil {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "registering API key model failed: %v", err)
+	}
+	if m.accounts, err = db.ModelMap().ModelStruct(o.AccountModel); err != nil {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "getting account model: '%T' failed: %v", o.AccountModel, err)
+	}
+	return m, nil
+}
+
+// Create creates new API key for the 'account'. The returned key string is not stored and could not be restored
+// later.
+func (m *Manager) Create(ctx context.Context, account auth.Account, options ...KeyOption) (string, *APIKey, error) {
+	o := &KeyOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return "", nil, err
+	}
+	if account.IsPrimaryKeyZero() {
+		return "", nil, errors.WrapDet(auth.ErrAccountNotValid, "the API key account has no primary key")
+	}
+	id, err := randomString(idLength)
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err := randomString(m.Options.SecretLength)
+	if err != nil {
+		return "", nil, err
+	}
+	key := &APIKey{ID: id, Name: o.Name, AccountID: accountID, Scope: o.Scope, ExpiresAt: o.ExpiresAt}
+	if err = m.authenticator.HashAndSetPassword(key, auth.NewPassword(secret)); err != nil {
+		return "", nil, err
+	}
+	if err = m.db.Insert(database.CtxWithSkipAuthorization(ctx), m.keys, key); err != nil {
+		return "", nil, err
+	}
+	return id + "." + secret, key, nil
+}
+
+// AuthenticateAPIKey implements auth.APIKeyAuthenticator interface. The key secret is compared with the stored
+// hash, and the key revocation and expiration is checked. The key last used time is updated. The returned *Claims
+// contains the key scope.
+func (m *Manager) AuthenticateAPIKey(ctx context.Context, key string) (auth.Account, auth.Claims, error) {
+	apiKey, err := m.authenticate(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	account := mapping.NewModel(m.accounts).(auth.Account)
+	if err = account.SetPrimaryKeyStringValue(apiKey.AccountID); err != nil {
+		return nil, nil, errors.WrapDetf(auth.ErrAPIKey, "API key: '%s' account id is not valid: %v", apiKey.ID, err).
+			WithDetail("The API key is not valid.")
+	}
+	model, err := m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.accounts).Filter(filter.New(m.accounts.Primary(), filter.OpEqual, account.GetPrimaryKeyValue())).Get()
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, nil, errors.WrapDetf(auth.ErrAPIKey, "API key: '%s' account not found", apiKey.ID).
+				WithDetail("The API key is not valid.")
+		}
+		return nil, nil, err
+	}
+	if err = m.updateLastUsed(ctx, apiKey); err != nil {
+		// The key authentication doesn't fail if the last used time could not be stored.
+		log.Errorf("Updating API key: '%s' last used time failed: %v", apiKey.ID, err)
+	}
+	return model.(auth.Account), &Claims{Key: apiKey, timeFunc: m.Options.TimeFunc}, nil
+}
+
+// Get gets the API key with the 'id'.
+func (m *Manager) Get(ctx context.Context, id string) (*APIKey, error) {
+	model, err := m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.keys).Filter(filter.New(m.keys.Primary(), filter.OpEqual, id)).Get()
+	if err != nil {
+		return nil, err
+	}
+	return model.(*APIKey), nil
+}
+
+// List lists all the API keys of the 'account'.
+func (m *Manager) List(ctx context.Context, account auth.Account) ([]*APIKey, error) {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return nil, err
+	}
+	models, err := m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.keys).Where("AccountID = ?", accountID).Find()
+	if err != nil && !errors.Is(err, query.ErrNoResult) {
+		return nil, err
+	}
+	keys := make([]*APIKey, len(models))
+	for i, model := range models {
+		keys[i] = model.(*APIKey)
+	}
+	return keys, nil
+}
+
+// Revoke revokes the API key with the 'id'.
+func (m *Manager) Revoke(ctx context.Context, id string) error {
+	key, err := m.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key.IsRevoked() {
+		return nil
+	}
+	key.RevokedAt = m.Options.TimeFunc()
+	return m.updateFields(ctx, key, "RevokedAt")
+}
+
+// authenticate gets the API key for the 'key' string and checks its secret, revocation and expiration.
+func (m *Manager) authenticate(ctx context.Context, key string) (*APIKey, error) {
+	dot := strings.IndexByte(key, '.')
+	if dot <= 0 || dot == len(key)-1 {
+		return nil, errors.WrapDet(auth.ErrAPIKey, "malformed API key").WithDetail("The API key is not valid.")
+	}
+	apiKey, err := m.Get(ctx, key[:dot])
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, errors.WrapDet(auth.ErrAPIKey, "API key not found").WithDetail("The API key is not valid.")
+		}
+		return nil, err
+	}
+	if err = m.authenticator.ComparePassword(apiKey, key[dot+1:]); err != nil {
+		if errors.Is(err, auth.ErrInvalidPassword) {
+			return nil, errors.WrapDetf(auth.ErrAPIKey, "API key: '%s' secret is not valid", apiKey.ID).
+				WithDetail("The API key is not valid.")
+		}
+		return nil, err
+	}
+	if apiKey.IsRevoked() {
+		return nil, errors.WrapDetf(auth.ErrAPIKeyRevoked, "API key: '%s' is revoked", apiKey.ID).
+			WithDetail("The API key is revoked.")
+	}
+	if apiKey.IsExpired(m.Options.TimeFunc()) {
+		return nil, errors.WrapDetf(auth.ErrAPIKeyExpired, "API key: '%s' is expired", apiKey.ID).
+			WithDetail("The API key is expired.")
+	}
+	return apiKey, nil
+}
+
+// updateLastUsed sets the key last used time if it is older than the options interval.
+func (m *Manager) updateLastUsed(ctx context.Context, key *APIKey) error {
+	now := m.Options.TimeFunc()
+	if !key.LastUsedAt.IsZero() && now.Sub(key.LastUsedAt) < m.Options.LastUsedInterval {
+		return nil
+	}
+	key.LastUsedAt = now
+	return m.updateFields(ctx, key, "LastUsedAt")
+}
+
+// updateFields updates the 'key' fields with provided names.
+func (m *Manager) updateFields(ctx context.Context, key *APIKey, fieldNames ...string) error {
+	fields := make([]*mapping.StructField, len(fieldNames))
+	for i, name := range fieldNames {
+		fields[i] = m.keys.MustFieldByName(name)
+	}
+	_, err := m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.keys, key).Select(fields...).Update()
+	return err
+}
+
+// randomString creates the url safe string of 'length' random bytes.
+func randomString(length int) (string, error) {
+	b, err := auth.GenerateSalt(length)
+	if err != nil {
+		return "", errors.WrapDetf(auth.ErrInternalError, "generating random bytes failed: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,130 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/auth/authenticator"
+	cjson "github.com/neuronlabs/neuron/codec/json"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+)
+
+func testManager(t *testing.T, options ...Option) (*Manager, *testmodels.User) {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(&testmodels.User{}))
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+
+	a, err := authenticator.New(auth.AuthenticatorMethod(auth.SHA256))
+	require.NoError(t, err)
+	manager, err := New(db, a, append([]Option{WithAccountModel(&testmodels.User{})}, options...)...)
+	require.NoError(t, err)
+
+	user := &testmodels.User{Username: "service"}
+	require.NoError(t, db.Insert(context.Background(), manager.accounts, user))
+	return manager, user
+}
+
+func TestAPIKey(t *testing.T) {
+	now := time.Date(2020, 10, 21, 10, 0, 0, 0, time.UTC)
+	manager, user := testManager(t, WithTimeFunc(func() time.Time { return now }))
+	ctx := context.Background()
+
+	key, apiKey, err := manager.Create(ctx, user, KeyName("ci"), KeyScope("read write"))
+	require.NoError(t, err)
+	assert.Equal(t, "1", apiKey.AccountID)
+	assert.Equal(t, "ci", apiKey.Name)
+	assert.NotContains(t, string(apiKey.Hash), key[len(apiKey.ID)+1:])
+	assert.True(t, apiKey.HasScope("read"))
+	assert.False(t, apiKey.HasScope("admin"))
+
+	marshaled, err := cjson.New(manager.db.ModelMap()).MarshalModel(apiKey)
+	require.NoError(t, err)
+	assert.NotContains(t, string(marshaled), "hash")
+
+	account, claims, err := manager.AuthenticateAPIKey(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, account.(*testmodels.User).ID)
+	assert.Equal(t, "1", claims.Subject())
+	assert.Equal(t, "read write", claims.(auth.Scoper).Scope())
+	assert.Equal(t, int64(-1), claims.ExpiresIn())
+
+	stored, err := manager.Get(ctx, apiKey.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.LastUsedAt.Equal(now))
+
+	t.Run("LastUsedInterval", func(t *testing.T) {
+		used := now
+		now = now.Add(30 * time.Second)
+		_, _, err := manager.AuthenticateAPIKey(ctx, key)
+		require.NoError(t, err)
+		stored, err := manager.Get(ctx, apiKey.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.LastUsedAt.Equal(used))
+
+		now = now.Add(time.Minute)
+		_, _, err = manager.AuthenticateAPIKey(ctx, key)
+		require.NoError(t, err)
+		stored, err = manager.Get(ctx, apiKey.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.LastUsedAt.Equal(now))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, invalid := range []string{"", "invalid", apiKey.ID + ".", "." + key, apiKey.ID + ".invalid", "unknown.secret"} {
+			_, _, err := manager.AuthenticateAPIKey(ctx, invalid)
+			assert.True(t, errors.Is(err, auth.ErrAPIKey), invalid)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		key, _, err := manager.Create(ctx, user, KeyExpiresAt(now.Add(time.Hour)))
+		require.NoError(t, err)
+		_, claims, err := manager.AuthenticateAPIKey(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3600), claims.ExpiresIn())
+
+		now = now.Add(time.Hour)
+		_, _, err = manager.AuthenticateAPIKey(ctx, key)
+		assert.True(t, errors.Is(err, auth.ErrAPIKeyExpired))
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		keys, err := manager.List(ctx, user)
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+
+		require.NoError(t, manager.Revoke(ctx, apiKey.ID))
+		_, _, err = manager.AuthenticateAPIKey(ctx, key)
+		assert.True(t, errors.Is(err, auth.ErrAPIKeyRevoked))
+
+		err = manager.Revoke(ctx, "unknown")
+		assert.True(t, errors.Is(err, query.ErrNoResult))
+	})
+}
+
+func TestNew(t *testing.T) {
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()))
+	require.NoError(t, err)
+	a, err := authenticator.New()
+	require.NoError(t, err)
+
+	_, err = New(db, a)
+	assert.True(t, errors.Is(err, auth.ErrAccountModelNotDefined))
+	_, err = New(db, nil, WithAccountModel(&testmodels.User{}))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+	_, err = New(db, a, WithAccountModel(&testmodels.User{}), WithSecretLength(8))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+}
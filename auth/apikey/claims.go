@@ -0,0 +1,64 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// Compile time check for the claims interfaces.
+var (
+	_ auth.Claims = &Claims{}
+	_ auth.Scoper = &Claims{}
+)
+
+// Claims are the claims of the authenticated API key. The key scope restricts the authority of the key account.
+type Claims struct {
+	Key *APIKey
+
+	timeFunc func() time.Time
+}
+
+// Subject implements auth.Claims interface. The subject is the key account primary key.
+func (c *Claims) Subject() string {
+	return c.Key.AccountID
+}
+
+// ExpiresIn implements auth.Claims interface. Returns the number of seconds left to the key expiration.
+// If the key doesn't expire it returns -1.
+func (c *Claims) ExpiresIn() int64 {
+	if c.Key.ExpiresAt.IsZero() {
+		return -1
+	}
+	expiresIn := int64(c.Key.ExpiresAt.Sub(c.now()) / time.Second)
+	if expiresIn < 0 {
+		return 0
+	}
+	return expiresIn
+}
+
+// Valid implements auth.Claims interface. Checks if the key is not revoked and not expired.
+func (c *Claims) Valid() error {
+	if c.Key.IsRevoked() {
+		return errors.WrapDetf(auth.ErrAPIKeyRevoked, "API key: '%s' is revoked", c.Key.ID).
+			WithDetail("The API key is revoked.")
+	}
+	if c.Key.IsExpired(c.now()) {
+		return errors.WrapDetf(auth.ErrAPIKeyExpired, "API key: '%s' is expired", c.Key.ID).
+			WithDetail("The API key is expired.")
+	}
+	return nil
+}
+
+// Scope implements auth.Scoper interface. An empty scope means that the key has no scope restrictions.
+func (c *Claims) Scope() string {
+	return c.Key.Scope
+}
+
+func (c *Claims) now() time.Time {
+	if c.timeFunc == nil {
+		return time.Now()
+	}
+	return c.timeFunc()
+}
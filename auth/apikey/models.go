@@ -0,0 +1,89 @@
+package apikey
+
+import (
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+)
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=APIKey .
+
+// Compile time check for the auth.Account interface.
+var _ auth.Account = &APIKey{}
+
+// APIKey is the model of the API key that belongs to the account or the service principal. Only the hash of the key
+// secret is stored. The APIKey implements auth.Account interface, so that its secret could be hashed and compared
+// by the auth.Authenticator.
+type APIKey struct {
+	// ID is the public identifier of the key. It is the first part of the key string.
+	ID string
+	// Hash is the hash of the key secret. It is never marshaled by the codecs.
+	Hash []byte `codec:"-"`
+	// Name is the optional key description.
+	Name string
+	// AccountID is the primary key string value of the account the key belongs to.
+	AccountID string
+	// Scope are the space separated scopes the key is allowed for. If empty, the key has no scope restrictions.
+	Scope     string
+	CreatedAt time.Time
+	// ExpiresAt is the key expiration time. The zero value means that the key never expires.
+	ExpiresAt time.Time
+	// LastUsedAt is the time of the last key authentication.
+	LastUsedAt time.Time
+	// RevokedAt is the time of the key revocation. The zero value means that the key is not revoked.
+	RevokedAt time.Time
+}
+
+// IsRevoked checks if the key is revoked.
+func (a *APIKey) IsRevoked() bool {
+	return !a.RevokedAt.IsZero()
+}
+
+// IsExpired checks if the key is expired at 'now'.
+func (a *APIKey) IsExpired(now time.Time) bool {
+	return !a.ExpiresAt.IsZero() && !now.Before(a.ExpiresAt)
+}
+
+// HasScope checks if the key is allowed for the 'scope'.
+func (a *APIKey) HasScope(scope string) bool {
+	if a.Scope == "" {
+		return true
+	}
+	for _, s := range strings.Fields(a.Scope) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// GetUsername implements auth.Account interface. The key username is its identifier.
+func (a *APIKey) GetUsername() string {
+	return a.ID
+}
+
+// SetUsername implements auth.Account interface.
+func (a *APIKey) SetUsername(username string) {
+	a.ID = username
+}
+
+// GetPasswordHash implements auth.Account interface. The key password hash is its secret hash.
+func (a *APIKey) GetPasswordHash() []byte {
+	return a.Hash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (a *APIKey) SetPasswordHash(hash []byte) {
+	a.Hash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (a *APIKey) UsernameField() string {
+	return "ID"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (a *APIKey) PasswordHashField() string {
+	return "Hash"
+}
@@ -0,0 +1,91 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+)
+
+// Options are the API key manager options.
+type Options struct {
+	// AccountModel is the model of the accounts and the service principals the keys belong to.
+	AccountModel auth.Account
+	// SecretLength is the number of the random bytes of the key secret.
+	SecretLength int
+	// LastUsedInterval is the minimal interval between the key last used time updates.
+	LastUsedInterval time.Duration
+	// TimeFunc is the time function used to check the key expiration and to set its last used time.
+	TimeFunc func() time.Time
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		SecretLength:     32,
+		LastUsedInterval: time.Minute,
+		TimeFunc:         time.Now,
+	}
+}
+
+// Option is a function that sets the API key manager options.
+type Option func(o *Options)
+
+// WithAccountModel sets the account model the keys belong to.
+func WithAccountModel(model auth.Account) Option {
+	return func(o *Options) {
+		o.AccountModel = model
+	}
+}
+
+// WithSecretLength sets the number of the random bytes of the key secret.
+func WithSecretLength(length int) Option {
+	return func(o *Options) {
+		o.SecretLength = length
+	}
+}
+
+// WithLastUsedInterval sets the minimal interval between the key last used time updates. If zero, the last used
+// time is updated on each key authentication.
+func WithLastUsedInterval(interval time.Duration) Option {
+	return func(o *Options) {
+		o.LastUsedInterval = interval
+	}
+}
+
+// WithTimeFunc sets the time function of the manager.
+func WithTimeFunc(tf func() time.Time) Option {
+	return func(o *Options) {
+		o.TimeFunc = tf
+	}
+}
+
+// KeyOptions are the options of the created key.
+type KeyOptions struct {
+	Name      string
+	Scope     string
+	ExpiresAt time.Time
+}
+
+// KeyOption is a function that sets the created key options.
+type KeyOption func(o *KeyOptions)
+
+// KeyName sets the key name.
+func KeyName(name string) KeyOption {
+	return func(o *KeyOptions) {
+		o.Name = name
+	}
+}
+
+// KeyScope sets the space separated scopes the key is allowed for. The key created without the scope has no scope
+// restrictions.
+func KeyScope(scope string) KeyOption {
+	return func(o *KeyOptions) {
+		o.Scope = scope
+	}
+}
+
+// KeyExpiresAt sets the key expiration time.
+func KeyExpiresAt(expiresAt time.Time) KeyOption {
+	return func(o *KeyOptions) {
+		o.ExpiresAt = expiresAt
+	}
+}
@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"context"
+
+	"github.com/neuronlabs/neuron/database"
 	"github.com/neuronlabs/neuron/store"
 )
 
@@ -13,16 +16,43 @@ type Authenticator interface {
 	ComparePassword(account Account, password string) error
 }
 
+// Rehasher is an optional Authenticator interface that transparently upgrades the account password hashes.
+// After successful password comparison the hash created with weaker method or cost than the current options
+// is replaced with the new one.
+type Rehasher interface {
+	// NeedsRehash checks if the account password hash is weaker than the one created with current options.
+	NeedsRehash(account Account) bool
+	// ComparePasswordAndRehash compares the 'password' just like the ComparePassword. If the password matches and
+	// the account password hash needs rehashing, the new hash is set and the account is updated in the database.
+	ComparePasswordAndRehash(ctx context.Context, account Account, password string) error
+}
+
 // AuthenticatorOptions are the authentication service options.
 type AuthenticatorOptions struct {
 	// Store is a store used for some authenticator implementations.
 	Store store.Store
+	// DB is the database used to store rehashed account passwords.
+	DB database.DB
 	// BCryptCost is an option that defines the cost of given password.
 	BCryptCost int
 	// AuthenticateMethod is a method used for authentication.
 	AuthenticateMethod AuthenticateMethod
 	// SaltLength is the length of the salt.
 	SaltLength int
+	// KeyLength is the length of the derived Argon2id and Scrypt password keys.
+	KeyLength int
+	// Argon2Time is the number of the Argon2id passes over the memory.
+	Argon2Time uint32
+	// Argon2Memory is the size of the Argon2id memory in KiB.
+	Argon2Memory uint32
+	// Argon2Threads is the number of the Argon2id threads.
+	Argon2Threads uint8
+	// ScryptN is the Scrypt CPU/memory cost parameter. It must be a power of two greater than one.
+	ScryptN int
+	// ScryptR is the Scrypt block size parameter.
+	ScryptR int
+	// ScryptP is the Scrypt parallelization parameter.
+	ScryptP int
 }
 
 // AuthenticatorOption is a function used to set authentication options.
@@ -40,6 +70,10 @@ const (
 	SHA256
 	// SHA512 is a sha512 password hashing method.
 	SHA512
+	// Argon2id is the argon2id password hashing method.
+	Argon2id
+	// Scrypt is the scrypt password hashing method.
+	Scrypt
 )
 
 // AuthenticatorStore is an option that sets Store in the option.
@@ -49,6 +83,13 @@ func AuthenticatorStore(op store.Store) AuthenticatorOption {
 	}
 }
 
+// AuthenticatorDB is an option that sets DB in the auth options.
+func AuthenticatorDB(db database.DB) AuthenticatorOption {
+	return func(o *AuthenticatorOptions) {
+		o.DB = db
+	}
+}
+
 // AuthenticatorBCryptCost is an option that sets BCryptCost in the auth options.
 func AuthenticatorBCryptCost(op int) AuthenticatorOption {
 	return func(o *AuthenticatorOptions) {
@@ -69,3 +110,28 @@ func AuthenticatorSaltLength(op int) AuthenticatorOption {
 		o.SaltLength = op
 	}
 }
+
+// AuthenticatorKeyLength is an option that sets KeyLength in the auth options.
+func AuthenticatorKeyLength(op int) AuthenticatorOption {
+	return func(o *AuthenticatorOptions) {
+		o.KeyLength = op
+	}
+}
+
+// AuthenticatorArgon2 is an option that sets the Argon2id 'time', 'memory' (KiB) and 'threads' parameters.
+func AuthenticatorArgon2(time, memory uint32, threads uint8) AuthenticatorOption {
+	return func(o *AuthenticatorOptions) {
+		o.Argon2Time = time
+		o.Argon2Memory = memory
+		o.Argon2Threads = threads
+	}
+}
+
+// AuthenticatorScrypt is an option that sets the Scrypt 'n', 'r' and 'p' parameters.
+func AuthenticatorScrypt(n, r, p int) AuthenticatorOption {
+	return func(o *AuthenticatorOptions) {
+		o.ScryptN = n
+		o.ScryptR = r
+		o.ScryptP = p
+	}
+}
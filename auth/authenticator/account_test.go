@@ -0,0 +1,26 @@
+package authenticator
+
+import (
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+)
+
+var _ auth.Salter = &saltedAccount{}
+
+// saltedAccount is the test account that stores the password salt.
+type saltedAccount struct {
+	testmodels.User
+	Salt []byte
+}
+
+func (a *saltedAccount) SaltField() string {
+	return "Salt"
+}
+
+func (a *saltedAccount) SetSalt(salt []byte) {
+	a.Salt = salt
+}
+
+func (a *saltedAccount) GetSalt() []byte {
+	return a.Salt
+}
@@ -0,0 +1,242 @@
+// Package authenticator implements the default auth.Authenticator.
+package authenticator
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// Default authenticator options.
+const (
+	// DefaultSaltLength is the default length of the generated salt.
+	DefaultSaltLength = 32
+	// DefaultKeyLength is the default length of the Argon2id and Scrypt derived keys.
+	DefaultKeyLength = 32
+	// DefaultArgon2Time is the default number of the Argon2id passes.
+	DefaultArgon2Time = 1
+	// DefaultArgon2Memory is the default Argon2id memory size in KiB.
+	DefaultArgon2Memory = 64 * 1024
+	// DefaultArgon2Threads is the default number of Argon2id threads.
+	DefaultArgon2Threads = 4
+	// DefaultScryptN is the default Scrypt CPU/memory cost parameter.
+	DefaultScryptN = 1 << 15
+	// DefaultScryptR is the default Scrypt block size parameter.
+	DefaultScryptR = 8
+	// DefaultScryptP is the default Scrypt parallelization parameter.
+	DefaultScryptP = 1
+)
+
+// Compile time check for the auth.Authenticator interface.
+var _ auth.Authenticator = &Authenticator{}
+
+// Authenticator is the default auth.Authenticator implementation. It hashes the passwords using the options
+// AuthenticateMethod. The BCrypt, Argon2id and Scrypt hashes are self-describing - they contain the algorithm,
+// its parameters and the salt, so that they could be verified regardless of the current method. For the MD5,
+// SHA256 and SHA512 methods the accounts that implements auth.Salter gets new random salt on each password change.
+type Authenticator struct {
+	Options *auth.AuthenticatorOptions
+}
+
+// New creates new authenticator with given 'options'.
+func New(options ...auth.AuthenticatorOption) (*Authenticator, error) {
+	o := &auth.AuthenticatorOptions{
+		BCryptCost:    bcrypt.DefaultCost,
+		SaltLength:    DefaultSaltLength,
+		KeyLength:     DefaultKeyLength,
+		Argon2Time:    DefaultArgon2Time,
+		Argon2Memory:  DefaultArgon2Memory,
+		Argon2Threads: DefaultArgon2Threads,
+		ScryptN:       DefaultScryptN,
+		ScryptR:       DefaultScryptR,
+		ScryptP:       DefaultScryptP,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	switch o.AuthenticateMethod {
+	case auth.BCrypt:
+		if o.BCryptCost < bcrypt.MinCost || o.BCryptCost > bcrypt.MaxCost {
+			return nil, errors.WrapDetf(auth.ErrInitialization, "invalid bcrypt cost: %d, should be in range [%d, %d]",
+				o.BCryptCost, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		return &Authenticator{Options: o}, nil
+	case auth.MD5, auth.SHA256, auth.SHA512:
+	case auth.Argon2id:
+		if o.Argon2Time == 0 || o.Argon2Memory == 0 || o.Argon2Threads == 0 {
+			return nil, errors.WrapDet(auth.ErrInitialization, "argon2id time, memory and threads parameters must be positive")
+		}
+	case auth.Scrypt:
+		if o.ScryptN <= 1 || o.ScryptN&(o.ScryptN-1) != 0 {
+			return nil, errors.WrapDetf(auth.ErrInitialization, "scrypt N parameter: %d must be a power of two greater than one", o.ScryptN)
+		}
+		if o.ScryptR <= 0 || o.ScryptP <= 0 || uint64(o.ScryptR)*uint64(o.ScryptP) >= 1<<30 {
+			return nil, errors.WrapDetf(auth.ErrInitialization, "invalid scrypt parameters r: %d, p: %d", o.ScryptR, o.ScryptP)
+		}
+	default:
+		return nil, errors.WrapDetf(auth.ErrInitialization, "unsupported authenticate method: %d", o.AuthenticateMethod)
+	}
+	if o.SaltLength <= 0 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "invalid salt length: %d", o.SaltLength)
+	}
+	if o.KeyLength <= 0 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "invalid key length: %d", o.KeyLength)
+	}
+	return &Authenticator{Options: o}, nil
+}
+
+// HashAndSetPassword implements auth.Authenticator interface. Hashes the 'password' and sets it within the
+// 'account'. If the method is MD5, SHA256 or SHA512 and the account implements auth.Salter the salt is generated
+// and set, otherwise the salt is cleared.
+func (a *Authenticator) HashAndSetPassword(account auth.Account, password *auth.Password) error {
+	if password == nil || password.Password == "" {
+		return errors.WrapDet(auth.ErrInvalidPassword, "no password provided").WithDetail("No password provided.")
+	}
+	switch a.Options.AuthenticateMethod {
+	case auth.BCrypt, auth.Argon2id, auth.Scrypt:
+		// The self-describing hashes contains their own salt, clear the salt that might be used previously.
+		if salter, ok := account.(auth.Salter); ok {
+			salter.SetSalt(nil)
+		}
+	}
+	switch a.Options.AuthenticateMethod {
+	case auth.BCrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password.Password), a.Options.BCryptCost)
+		if err != nil {
+			return errors.WrapDetf(auth.ErrInternalError, "generating bcrypt password hash failed: %v", err)
+		}
+		account.SetPasswordHash(hash)
+		return nil
+	case auth.Argon2id, auth.Scrypt:
+		salt, err := auth.GenerateSalt(a.Options.SaltLength)
+		if err != nil {
+			return err
+		}
+		var hash []byte
+		if a.Options.AuthenticateMethod == auth.Argon2id {
+			hash = a.argon2idHash(password.Password, salt)
+		} else if hash, err = a.scryptHash(password.Password, salt); err != nil {
+			return err
+		}
+		account.SetPasswordHash(hash)
+		return nil
+	}
+
+	var salt []byte
+	salter, isSalter := account.(auth.Salter)
+	if isSalter {
+		var err error
+		if salt, err = auth.GenerateSalt(a.Options.SaltLength); err != nil {
+			return err
+		}
+	}
+	hash, err := digest(a.Options.AuthenticateMethod, password, salt)
+	if err != nil {
+		return err
+	}
+	if isSalter {
+		salter.SetSalt(salt)
+	}
+	account.SetPasswordHash(hash)
+	return nil
+}
+
+// ComparePassword implements auth.Authenticator interface. Compares the 'password' with the account password hash
+// in constant time. The BCrypt, Argon2id and Scrypt hashes are verified with their own parameters, all the other
+// hashes are recognized by their length as MD5, SHA256 or SHA512 digests. If the password doesn't match the function returns
+// auth.ErrInvalidPassword error.
+func (a *Authenticator) ComparePassword(account auth.Account, password string) error {
+	hash := account.GetPasswordHash()
+	if len(hash) == 0 {
+		return errInvalidPassword()
+	}
+	method, ok := storedMethod(hash)
+	if !ok {
+		return errors.WrapDet(auth.ErrInternalError, "unknown password hash format")
+	}
+	switch method {
+	case auth.BCrypt:
+		switch err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err {
+		case nil:
+			return nil
+		case bcrypt.ErrMismatchedHashAndPassword:
+			return errInvalidPassword()
+		default:
+			return errors.WrapDetf(auth.ErrInternalError, "comparing bcrypt password hash failed: %v", err)
+		}
+	case auth.Argon2id, auth.Scrypt:
+		p, err := decodePHC(hash)
+		if err != nil {
+			return err
+		}
+		key, err := p.derive(password)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare(key, p.Hash) != 1 {
+			return errInvalidPassword()
+		}
+		return nil
+	}
+
+	var salt []byte
+	if salter, ok := account.(auth.SaltGetter); ok {
+		salt = salter.GetSalt()
+	}
+	passwordHash, err := digest(method, &auth.Password{Password: password}, salt)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(passwordHash, hash) != 1 {
+		return errInvalidPassword()
+	}
+	return nil
+}
+
+// storedMethod gets the method used to create the password 'hash'. The BCrypt, Argon2id and Scrypt hashes are
+// recognized by their identifier, and the MD5, SHA256 and SHA512 digests by their length.
+func storedMethod(hash []byte) (auth.AuthenticateMethod, bool) {
+	if isPHC(hash) {
+		switch phcID(hash) {
+		case "2a", "2b", "2y":
+			return auth.BCrypt, true
+		case argon2idID:
+			return auth.Argon2id, true
+		case scryptID:
+			return auth.Scrypt, true
+		}
+	}
+	switch len(hash) {
+	case md5.Size:
+		return auth.MD5, true
+	case sha256.Size:
+		return auth.SHA256, true
+	case sha512.Size:
+		return auth.SHA512, true
+	}
+	return 0, false
+}
+
+// digest creates the salted 'password' digest using MD5, SHA256 or SHA512 'method'.
+func digest(method auth.AuthenticateMethod, password *auth.Password, salt []byte) ([]byte, error) {
+	switch method {
+	case auth.MD5:
+		return password.MD5(salt)
+	case auth.SHA256:
+		return password.SHA256(salt)
+	case auth.SHA512:
+		return password.SHA512(salt)
+	default:
+		return nil, errors.WrapDetf(auth.ErrInternalError, "authenticate method: %d is not a digest method", method)
+	}
+}
+
+func errInvalidPassword() error {
+	return errors.WrapDet(auth.ErrInvalidPassword, "password doesn't match").WithDetail("Invalid username or password.")
+}
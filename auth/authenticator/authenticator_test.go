@@ -0,0 +1,141 @@
+package authenticator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+)
+
+// testOptions are the authenticator options with low hashing costs.
+var testOptions = []auth.AuthenticatorOption{
+	auth.AuthenticatorBCryptCost(bcrypt.MinCost),
+	auth.AuthenticatorArgon2(1, 1024, 1),
+	auth.AuthenticatorScrypt(1<<10, 8, 1),
+}
+
+func TestAuthenticator(t *testing.T) {
+	methods := map[string]auth.AuthenticateMethod{
+		"BCrypt":   auth.BCrypt,
+		"MD5":      auth.MD5,
+		"SHA256":   auth.SHA256,
+		"SHA512":   auth.SHA512,
+		"Argon2id": auth.Argon2id,
+		"Scrypt":   auth.Scrypt,
+	}
+	for name, method := range methods {
+		t.Run(name, func(t *testing.T) {
+			a, err := New(append(testOptions, auth.AuthenticatorMethod(method))...)
+			require.NoError(t, err)
+
+			accounts := []auth.Account{&testmodels.User{}, &saltedAccount{}}
+			for _, account := range accounts {
+				require.NoError(t, a.HashAndSetPassword(account, auth.NewPassword("Secret1!")))
+				assert.NotEmpty(t, account.GetPasswordHash())
+				assert.NotEqual(t, []byte("Secret1!"), account.GetPasswordHash())
+
+				assert.NoError(t, a.ComparePassword(account, "Secret1!"))
+				err = a.ComparePassword(account, "Secret2!")
+				assert.True(t, errors.Is(err, auth.ErrInvalidPassword))
+			}
+
+			salted := accounts[1].(*saltedAccount)
+			if method == auth.BCrypt || method == auth.Argon2id || method == auth.Scrypt {
+				// The self-describing hashes contains their own salt.
+				assert.Empty(t, salted.Salt)
+				return
+			}
+			assert.Len(t, salted.Salt, DefaultSaltLength)
+
+			// Each password change generates new salt.
+			hash, salt := salted.PasswordHash, salted.Salt
+			require.NoError(t, a.HashAndSetPassword(salted, auth.NewPassword("Secret1!")))
+			assert.NotEqual(t, salt, salted.Salt)
+			assert.NotEqual(t, hash, salted.PasswordHash)
+
+			// Changed salt invalidates the password.
+			salted.Salt = salt
+			err = a.ComparePassword(salted, "Secret1!")
+			assert.True(t, errors.Is(err, auth.ErrInvalidPassword))
+		})
+	}
+}
+
+func TestAuthenticatorErrors(t *testing.T) {
+	_, err := New(auth.AuthenticatorBCryptCost(bcrypt.MaxCost + 1))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+
+	_, err = New(auth.AuthenticatorMethod(auth.SHA256), auth.AuthenticatorSaltLength(0))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+
+	_, err = New(auth.AuthenticatorMethod(auth.Scrypt), auth.AuthenticatorScrypt(1000, 8, 1))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+
+	_, err = New(auth.AuthenticatorMethod(auth.Argon2id), auth.AuthenticatorArgon2(0, 1024, 1))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+
+	_, err = New(auth.AuthenticatorMethod(auth.AuthenticateMethod(100)))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+
+	a, err := New(auth.AuthenticatorBCryptCost(bcrypt.MinCost))
+	require.NoError(t, err)
+	err = a.HashAndSetPassword(&testmodels.User{}, auth.NewPassword(""))
+	assert.True(t, errors.Is(err, auth.ErrInvalidPassword))
+
+	// The account without the password hash never matches.
+	err = a.ComparePassword(&testmodels.User{}, "")
+	assert.True(t, errors.Is(err, auth.ErrInvalidPassword))
+}
+
+func TestPHCHash(t *testing.T) {
+	a, err := New(append(testOptions, auth.AuthenticatorMethod(auth.Argon2id))...)
+	require.NoError(t, err)
+	account := &testmodels.User{}
+	require.NoError(t, a.HashAndSetPassword(account, auth.NewPassword("Secret1!")))
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=1024,t=1,p=1\$[A-Za-z0-9+/]{43}\$[A-Za-z0-9+/]{43}$`, string(account.PasswordHash))
+
+	a, err = New(append(testOptions, auth.AuthenticatorMethod(auth.Scrypt))...)
+	require.NoError(t, err)
+	require.NoError(t, a.HashAndSetPassword(account, auth.NewPassword("Secret1!")))
+	assert.Regexp(t, `^\$scrypt\$ln=10,r=8,p=1\$[A-Za-z0-9+/]{43}\$[A-Za-z0-9+/]{43}$`, string(account.PasswordHash))
+
+	// Decoded hash has the parameters used for the hashing.
+	p, err := decodePHC(account.PasswordHash)
+	require.NoError(t, err)
+	assert.Equal(t, scryptID, p.ID)
+	assert.Equal(t, map[string]int{"ln": 10, "r": 8, "p": 1}, p.Params)
+	assert.Len(t, p.Salt, DefaultSaltLength)
+	assert.Len(t, p.Hash, DefaultKeyLength)
+
+	for _, malformed := range []string{"$scrypt", "$scrypt$ln=10,r=8,p=1$salt", "$scrypt$ln=x$c2FsdA$aGFzaA", "$scrypt$ln=10$!$aGFzaA"} {
+		_, err = decodePHC([]byte(malformed))
+		assert.Error(t, err, malformed)
+	}
+}
+
+func TestCompareAnyHash(t *testing.T) {
+	// Hash the passwords with all the methods.
+	var accounts []auth.Account
+	for _, method := range []auth.AuthenticateMethod{auth.BCrypt, auth.MD5, auth.SHA256, auth.SHA512, auth.Argon2id, auth.Scrypt} {
+		a, err := New(append(testOptions, auth.AuthenticatorMethod(method))...)
+		require.NoError(t, err)
+		account := &saltedAccount{}
+		require.NoError(t, a.HashAndSetPassword(account, auth.NewPassword("Secret1!")))
+		accounts = append(accounts, account)
+	}
+
+	// Any authenticator verifies the hashes created with other methods.
+	for _, method := range []auth.AuthenticateMethod{auth.BCrypt, auth.SHA256, auth.Argon2id} {
+		a, err := New(append(testOptions, auth.AuthenticatorMethod(method))...)
+		require.NoError(t, err)
+		for i, account := range accounts {
+			assert.NoError(t, a.ComparePassword(account, "Secret1!"), i)
+			assert.True(t, errors.Is(a.ComparePassword(account, "Secret2!"), auth.ErrInvalidPassword), i)
+		}
+	}
+}
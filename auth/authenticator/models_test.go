@@ -0,0 +1,56 @@
+package authenticator
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=User .
+
+// User is the account model stored in the database.
+type User struct {
+	ID           int
+	Username     string
+	PasswordHash []byte
+	PasswordSalt []byte
+}
+
+// GetUsername implements auth.Account interface.
+func (u *User) GetUsername() string {
+	return u.Username
+}
+
+// SetUsername implements auth.Account interface.
+func (u *User) SetUsername(username string) {
+	u.Username = username
+}
+
+// GetPasswordHash implements auth.Account interface.
+func (u *User) GetPasswordHash() []byte {
+	return u.PasswordHash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (u *User) SetPasswordHash(hash []byte) {
+	u.PasswordHash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (u *User) UsernameField() string {
+	return "Username"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (u *User) PasswordHashField() string {
+	return "PasswordHash"
+}
+
+// SaltField implements auth.Salter interface.
+func (u *User) SaltField() string {
+	return "PasswordSalt"
+}
+
+// SetSalt implements auth.Salter interface.
+func (u *User) SetSalt(salt []byte) {
+	u.PasswordSalt = salt
+}
+
+// GetSalt implements auth.Salter interface.
+func (u *User) GetSalt() []byte {
+	return u.PasswordSalt
+}
@@ -0,0 +1,183 @@
+package authenticator
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// The PHC string format algorithm identifiers.
+const (
+	argon2idID = "argon2id"
+	scryptID   = "scrypt"
+)
+
+// phcHash is the password hash in the PHC string format:
+//
+//	$<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*][$<salt>[$<hash>]]
+//
+// The salt and the hash are encoded with standard base64 encoding without padding.
+type phcHash struct {
+	ID      string
+	Version int
+	Params  map[string]int
+	Salt    []byte
+	Hash    []byte
+}
+
+// encode encodes the hash into the PHC string format. The parameters are written in the order of 'keys'.
+func (p *phcHash) encode(keys ...string) []byte {
+	sb := strings.Builder{}
+	sb.WriteString("$")
+	sb.WriteString(p.ID)
+	if p.Version != 0 {
+		fmt.Fprintf(&sb, "$v=%d", p.Version)
+	}
+	for i, key := range keys {
+		if i == 0 {
+			sb.WriteString("$")
+		} else {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, "%s=%d", key, p.Params[key])
+	}
+	sb.WriteString("$")
+	sb.WriteString(base64.RawStdEncoding.EncodeToString(p.Salt))
+	sb.WriteString("$")
+	sb.WriteString(base64.RawStdEncoding.EncodeToString(p.Hash))
+	return []byte(sb.String())
+}
+
+// isPHC checks if the 'hash' is in the PHC string format.
+func isPHC(hash []byte) bool {
+	return len(hash) > 1 && hash[0] == '$'
+}
+
+// phcID gets the algorithm identifier of the PHC formatted 'hash'.
+func phcID(hash []byte) string {
+	id := string(hash[1:])
+	if i := strings.IndexByte(id, '$'); i != -1 {
+		id = id[:i]
+	}
+	return id
+}
+
+// decodePHC decodes the PHC formatted 'hash' with the salt and the hash value.
+func decodePHC(hash []byte) (*phcHash, error) {
+	parts := strings.Split(string(hash), "$")
+	// The first part is empty as the hash starts with the '$'.
+	if len(parts) < 4 || parts[0] != "" {
+		return nil, errMalformedHash()
+	}
+	p := &phcHash{ID: parts[1], Params: map[string]int{}}
+	parts = parts[2:]
+	if strings.HasPrefix(parts[0], "v=") {
+		version, err := strconv.Atoi(strings.TrimPrefix(parts[0], "v="))
+		if err != nil {
+			return nil, errMalformedHash()
+		}
+		p.Version = version
+		parts = parts[1:]
+	}
+	if len(parts) == 3 {
+		for _, param := range strings.Split(parts[0], ",") {
+			i := strings.IndexByte(param, '=')
+			if i == -1 {
+				return nil, errMalformedHash()
+			}
+			value, err := strconv.Atoi(param[i+1:])
+			if err != nil || value < 0 {
+				return nil, errMalformedHash()
+			}
+			p.Params[param[:i]] = value
+		}
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return nil, errMalformedHash()
+	}
+	var err error
+	if p.Salt, err = base64.RawStdEncoding.DecodeString(parts[0]); err != nil {
+		return nil, errMalformedHash()
+	}
+	if p.Hash, err = base64.RawStdEncoding.DecodeString(parts[1]); err != nil || len(p.Hash) == 0 {
+		return nil, errMalformedHash()
+	}
+	return p, nil
+}
+
+// argon2idHash derives the argon2id 'password' hash.
+func (a *Authenticator) argon2idHash(password string, salt []byte) []byte {
+	o := a.Options
+	p := &phcHash{
+		ID:      argon2idID,
+		Version: argon2.Version,
+		Params:  map[string]int{"m": int(o.Argon2Memory), "t": int(o.Argon2Time), "p": int(o.Argon2Threads)},
+		Salt:    salt,
+		Hash:    argon2.IDKey([]byte(password), salt, o.Argon2Time, o.Argon2Memory, o.Argon2Threads, uint32(o.KeyLength)),
+	}
+	return p.encode("m", "t", "p")
+}
+
+// scryptHash derives the scrypt 'password' hash. The 'N' parameter is stored as its binary logarithm 'ln'.
+func (a *Authenticator) scryptHash(password string, salt []byte) ([]byte, error) {
+	o := a.Options
+	key, err := scrypt.Key([]byte(password), salt, o.ScryptN, o.ScryptR, o.ScryptP, o.KeyLength)
+	if err != nil {
+		return nil, errors.WrapDetf(auth.ErrInternalError, "deriving scrypt password key failed: %v", err)
+	}
+	p := &phcHash{
+		ID:     scryptID,
+		Params: map[string]int{"ln": log2(o.ScryptN), "r": o.ScryptR, "p": o.ScryptP},
+		Salt:   salt,
+		Hash:   key,
+	}
+	return p.encode("ln", "r", "p"), nil
+}
+
+// derive derives the 'password' key with the algorithm and parameters of the decoded hash 'p'.
+func (p *phcHash) derive(password string) ([]byte, error) {
+	switch p.ID {
+	case argon2idID:
+		if p.Version != argon2.Version {
+			return nil, errors.WrapDetf(auth.ErrInternalError, "unsupported argon2id version: %d", p.Version)
+		}
+		m, t, threads := p.Params["m"], p.Params["t"], p.Params["p"]
+		if m == 0 || t == 0 || threads == 0 || threads > 255 {
+			return nil, errMalformedHash()
+		}
+		return argon2.IDKey([]byte(password), p.Salt, uint32(t), uint32(m), uint8(threads), uint32(len(p.Hash))), nil
+	case scryptID:
+		ln, r, parallel := p.Params["ln"], p.Params["r"], p.Params["p"]
+		if ln == 0 || ln > 62 {
+			return nil, errMalformedHash()
+		}
+		key, err := scrypt.Key([]byte(password), p.Salt, 1<<uint(ln), r, parallel, len(p.Hash))
+		if err != nil {
+			return nil, errors.WrapDetf(auth.ErrInternalError, "deriving scrypt password key failed: %v", err)
+		}
+		return key, nil
+	default:
+		return nil, errors.WrapDetf(auth.ErrInternalError, "unsupported password hash algorithm: '%s'", p.ID)
+	}
+}
+
+func log2(n int) int {
+	var l int
+	for n > 1 {
+		n >>= 1
+		l++
+	}
+	return l
+}
+
+func errMalformedHash() error {
+	return errors.WrapDet(auth.ErrInternalError, "malformed password hash")
+}
@@ -0,0 +1,116 @@
+package authenticator
+
+import (
+	"context"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// Compile time check for the auth.Rehasher interface.
+var _ auth.Rehasher = &Authenticator{}
+
+// NeedsRehash implements auth.Rehasher interface. The hash needs rehashing if it was created with a method other
+// than the current one, unless the current method is a weaker digest, or if it was created with the current method
+// but with lower cost parameters, shorter salt or key.
+func (a *Authenticator) NeedsRehash(account auth.Account) bool {
+	hash := account.GetPasswordHash()
+	method, ok := storedMethod(hash)
+	if !ok {
+		return false
+	}
+	current := a.Options.AuthenticateMethod
+	if method != current {
+		return methodStrength(method) <= methodStrength(current)
+	}
+	o := a.Options
+	switch method {
+	case auth.BCrypt:
+		cost, err := bcrypt.Cost(hash)
+		return err == nil && cost < o.BCryptCost
+	case auth.Argon2id:
+		p, err := decodePHC(hash)
+		if err != nil {
+			return false
+		}
+		return p.Version != argon2.Version || p.Params["m"] < int(o.Argon2Memory) || p.Params["t"] < int(o.Argon2Time) ||
+			p.Params["p"] < int(o.Argon2Threads) || len(p.Hash) < o.KeyLength || len(p.Salt) < o.SaltLength
+	case auth.Scrypt:
+		p, err := decodePHC(hash)
+		if err != nil {
+			return false
+		}
+		return p.Params["ln"] < log2(o.ScryptN) || p.Params["r"] < o.ScryptR || p.Params["p"] < o.ScryptP ||
+			len(p.Hash) < o.KeyLength || len(p.Salt) < o.SaltLength
+	default:
+		// The digests of the salter accounts needs to have the salt of the options length.
+		salter, ok := account.(auth.Salter)
+		return ok && len(salter.GetSalt()) < o.SaltLength
+	}
+}
+
+// ComparePasswordAndRehash implements auth.Rehasher interface. If the 'password' matches and the account password
+// hash needs rehashing, the new password hash (and salt) is set and the account is updated in the options DB.
+// If no DB is defined, only the account value is changed. The comparison doesn't fail if the rehashed password
+// could not be stored.
+func (a *Authenticator) ComparePasswordAndRehash(ctx context.Context, account auth.Account, password string) error {
+	if err := a.ComparePassword(account, password); err != nil {
+		return err
+	}
+	if !a.NeedsRehash(account) {
+		return nil
+	}
+	if err := a.HashAndSetPassword(account, &auth.Password{Password: password}); err != nil {
+		return err
+	}
+	if a.Options.DB == nil {
+		log.Debug("No authenticator DB defined. The rehashed account password is not stored.")
+		return nil
+	}
+	if err := a.updatePassword(ctx, account); err != nil {
+		log.Errorf("Updating account: '%s' rehashed password failed: %v", account.GetUsername(), err)
+	}
+	return nil
+}
+
+// updatePassword updates the account password hash and salt fields in the options DB.
+func (a *Authenticator) updatePassword(ctx context.Context, account auth.Account) error {
+	db := a.Options.DB
+	mStruct, err := db.ModelMap().ModelStruct(account)
+	if err != nil {
+		return err
+	}
+	fieldNames := []string{account.PasswordHashField()}
+	if salter, ok := account.(auth.Salter); ok {
+		fieldNames = append(fieldNames, salter.SaltField())
+	}
+	fields := make([]*mapping.StructField, len(fieldNames))
+	for i, name := range fieldNames {
+		field, ok := mStruct.FieldByName(name)
+		if !ok {
+			return errors.WrapDetf(auth.ErrAccountNotValid, "field: '%s' not found in the account model: '%s'", name, mStruct)
+		}
+		fields[i] = field
+	}
+	_, err = db.QueryCtx(ctx, mStruct, account).Select(fields...).Update()
+	return err
+}
+
+// methodStrength gets the strength rank of the hashing 'method'. The adaptive methods have the same strength.
+func methodStrength(method auth.AuthenticateMethod) int {
+	switch method {
+	case auth.MD5:
+		return 0
+	case auth.SHA256:
+		return 1
+	case auth.SHA512:
+		return 2
+	default:
+		return 3
+	}
+}
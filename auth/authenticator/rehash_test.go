@@ -0,0 +1,121 @@
+package authenticator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+)
+
+func testDB(t *testing.T) database.DB {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(Neuron_Models...))
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+	return db
+}
+
+func TestComparePasswordAndRehash(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+	mStruct := db.ModelMap().MustModelStruct(&User{})
+
+	// Store the user with the legacy salted SHA256 password hash.
+	legacySalt := []byte("salt")
+	legacyHash, err := auth.NewPassword("Secret1!").SHA256(legacySalt)
+	require.NoError(t, err)
+	require.NoError(t, db.Insert(ctx, mStruct, &User{ID: 1, Username: "user", PasswordHash: legacyHash, PasswordSalt: legacySalt}))
+
+	getUser := func() *User {
+		model, err := db.QueryCtx(ctx, mStruct).Where("ID = ?", 1).Get()
+		require.NoError(t, err)
+		return model.(*User)
+	}
+
+	a, err := New(append(testOptions, auth.AuthenticatorMethod(auth.Argon2id), auth.AuthenticatorDB(db))...)
+	require.NoError(t, err)
+
+	user := getUser()
+	require.True(t, a.NeedsRehash(user))
+
+	// Invalid password doesn't change the hash.
+	err = a.ComparePasswordAndRehash(ctx, user, "Secret2!")
+	assert.True(t, errors.Is(err, auth.ErrInvalidPassword))
+	assert.Equal(t, legacyHash, getUser().PasswordHash)
+
+	require.NoError(t, a.ComparePasswordAndRehash(ctx, user, "Secret1!"))
+	stored := getUser()
+	method, ok := storedMethod(stored.PasswordHash)
+	require.True(t, ok)
+	assert.Equal(t, auth.Argon2id, method)
+	assert.Equal(t, user.PasswordHash, stored.PasswordHash)
+	assert.Empty(t, stored.PasswordSalt)
+	assert.False(t, a.NeedsRehash(stored))
+	assert.NoError(t, a.ComparePasswordAndRehash(ctx, stored, "Secret1!"))
+	assert.Equal(t, stored.PasswordHash, getUser().PasswordHash)
+
+	// Stronger argon2id parameters require another rehash.
+	a, err = New(append(testOptions, auth.AuthenticatorMethod(auth.Argon2id), auth.AuthenticatorArgon2(2, 1024, 1), auth.AuthenticatorDB(db))...)
+	require.NoError(t, err)
+	require.True(t, a.NeedsRehash(stored))
+	require.NoError(t, a.ComparePasswordAndRehash(ctx, stored, "Secret1!"))
+	p, err := decodePHC(getUser().PasswordHash)
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.Params["t"])
+
+	// The valid password is accepted even if the rehashed password of the account without primary key could not
+	// be stored.
+	user = &User{Username: "user", PasswordHash: legacyHash, PasswordSalt: legacySalt}
+	require.NoError(t, a.ComparePasswordAndRehash(ctx, user, "Secret1!"))
+	assert.False(t, a.NeedsRehash(user))
+}
+
+func TestNeedsRehash(t *testing.T) {
+	newAuthenticator := func(options ...auth.AuthenticatorOption) *Authenticator {
+		a, err := New(append(testOptions, options...)...)
+		require.NoError(t, err)
+		return a
+	}
+	hashed := func(a *Authenticator) *saltedAccount {
+		account := &saltedAccount{}
+		require.NoError(t, a.HashAndSetPassword(account, auth.NewPassword("Secret1!")))
+		return account
+	}
+	md5 := newAuthenticator(auth.AuthenticatorMethod(auth.MD5))
+	sha512 := newAuthenticator(auth.AuthenticatorMethod(auth.SHA512))
+	bcryptMin := newAuthenticator()
+	bcryptHigher := newAuthenticator(auth.AuthenticatorBCryptCost(bcrypt.MinCost + 1))
+	scryptMin := newAuthenticator(auth.AuthenticatorMethod(auth.Scrypt))
+	scryptHigher := newAuthenticator(auth.AuthenticatorMethod(auth.Scrypt), auth.AuthenticatorScrypt(1<<11, 8, 1))
+
+	// Weaker digests are upgraded, but the stronger are never downgraded.
+	assert.True(t, sha512.NeedsRehash(hashed(md5)))
+	assert.False(t, md5.NeedsRehash(hashed(sha512)))
+	assert.False(t, md5.NeedsRehash(hashed(bcryptMin)))
+	assert.True(t, bcryptMin.NeedsRehash(hashed(sha512)))
+
+	// Unsalted or shortly salted digests are rehashed.
+	account := hashed(sha512)
+	assert.False(t, sha512.NeedsRehash(account))
+	account.Salt = account.Salt[:8]
+	assert.True(t, sha512.NeedsRehash(account))
+
+	// The cost parameters.
+	assert.True(t, bcryptHigher.NeedsRehash(hashed(bcryptMin)))
+	assert.False(t, bcryptMin.NeedsRehash(hashed(bcryptHigher)))
+	assert.True(t, scryptHigher.NeedsRehash(hashed(scryptMin)))
+	assert.False(t, scryptMin.NeedsRehash(hashed(scryptHigher)))
+
+	// Changing the adaptive method requires rehashing.
+	assert.True(t, scryptMin.NeedsRehash(hashed(bcryptMin)))
+}
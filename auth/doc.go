@@ -1,3 +1,4 @@
 // Package auth contains interfaces that defines basic authentication and authorization structures.
-// It also provides basic authentication functions, and PEM file reading helpers.
+// It also provides basic authentication functions, and PEM encoded private, public keys and certificates reading
+// helpers.
 package auth
@@ -23,6 +23,10 @@ var (
 	ErrInvalidUsername = errors.Wrap(ErrAuthentication, "invalid username")
 	// ErrInvalidPassword is the error classification when provided secret is not valid.
 	ErrInvalidPassword = errors.Wrap(ErrAuthentication, "provided invalid secret")
+	// ErrAccountLocked is the error when the authentication is temporarily locked after too many failed attempts.
+	ErrAccountLocked = errors.Wrap(ErrAuthentication, "locked")
+	// ErrPasswordExpired is the error when the account password is older than the maximum password age.
+	ErrPasswordExpired = errors.Wrap(ErrAuthentication, "password expired")
 	// ErrNoRequiredOption is the error classification while there is no required option.
 	ErrNoRequiredOption = errors.Wrap(ErrAuthentication, "provided no required option")
 	// ErrInitialization is the error classification while initializing the structures.
@@ -33,6 +37,22 @@ var (
 	ErrInvalidRSAKey = errors.Wrap(ErrInitialization, "invalid RSA key")
 	// ErrInvalidECDSAKey is an error for initialization with an invalid ECDSA key.
 	ErrInvalidECDSAKey = errors.Wrap(ErrInitialization, "invalid ECDSA key")
+	// ErrInvalidEd25519Key is an error for initialization with an invalid Ed25519 key.
+	ErrInvalidEd25519Key = errors.Wrap(ErrInitialization, "invalid Ed25519 key")
+	// ErrInvalidKey is an error for initialization with an invalid or unsupported key.
+	ErrInvalidKey = errors.Wrap(ErrInitialization, "invalid key")
+	// ErrPasswordPolicy is the error classification of the password policy violations.
+	ErrPasswordPolicy = errors.Wrap(ErrAuth, "password policy")
+	// ErrPasswordTooShort is the error when the password is shorter than the policy minimum length.
+	ErrPasswordTooShort = errors.Wrap(ErrPasswordPolicy, "too short")
+	// ErrPasswordTooWeak is the error when the password score is lower than the policy minimum score.
+	ErrPasswordTooWeak = errors.Wrap(ErrPasswordPolicy, "too weak")
+	// ErrPasswordCommon is the error when the password is found in the breached or common passwords dictionary.
+	ErrPasswordCommon = errors.Wrap(ErrPasswordPolicy, "common password")
+	// ErrPasswordContainsUsername is the error when the password contains the account username.
+	ErrPasswordContainsUsername = errors.Wrap(ErrPasswordPolicy, "contains username")
+	// ErrPasswordReused is the error when the password was recently used by the account.
+	ErrPasswordReused = errors.Wrap(ErrPasswordPolicy, "reused")
 	// ErrToken is the error for invalid token.
 	ErrToken = errors.Wrap(ErrAuthentication, "invalid token")
 	// ErrTokenRevoked is the error for invalid token.
@@ -41,6 +61,22 @@ var (
 	ErrTokenExpired = errors.Wrap(ErrToken, "expired")
 	// ErrTokenNotValidYet is an error related to the token that is not valid yet.
 	ErrTokenNotValidYet = errors.Wrap(ErrToken, "not valid yet")
+	// ErrAPIKey is the error for invalid API key.
+	ErrAPIKey = errors.Wrap(ErrAuthentication, "invalid api key")
+	// ErrAPIKeyRevoked is the error for revoked API key.
+	ErrAPIKeyRevoked = errors.Wrap(ErrAPIKey, "revoked")
+	// ErrAPIKeyExpired is the error for expired API key.
+	ErrAPIKeyExpired = errors.Wrap(ErrAPIKey, "expired")
+	// ErrMFA is the error related with the multi-factor authentication.
+	ErrMFA = errors.Wrap(ErrAuthentication, "multi-factor")
+	// ErrMFANotEnrolled is the error when the account has no second factor enrolled.
+	ErrMFANotEnrolled = errors.Wrap(ErrMFA, "not enrolled")
+	// ErrMFAAlreadyEnrolled is the error when the account has already confirmed second factor.
+	ErrMFAAlreadyEnrolled = errors.Wrap(ErrMFA, "already enrolled")
+	// ErrInvalidOTP is the error for invalid one-time password or recovery code.
+	ErrInvalidOTP = errors.Wrap(ErrMFA, "invalid one-time password")
+	// ErrOTPReused is the error for the one-time password that was already used.
+	ErrOTPReused = errors.Wrap(ErrInvalidOTP, "already used")
 )
 
 var (
@@ -52,6 +88,8 @@ var (
 	ErrAuthorizationHeader = errors.Wrap(ErrAuthorization, "header")
 	// ErrForbidden is the error classification when authorization fails.
 	ErrForbidden = errors.Wrap(ErrAuthorization, "forbidden")
+	// ErrMFARequired is the error when the multi-factor authentication is required but not satisfied.
+	ErrMFARequired = errors.Wrap(ErrForbidden, "multi-factor authentication required")
 	// ErrInvalidRole is the error classification when the role is not valid.
 	ErrInvalidRole = errors.Wrap(ErrAuthorization, "invalid role")
 	// ErrRoleAlreadyGranted is the error when the role is already granted.
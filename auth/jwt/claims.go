@@ -0,0 +1,130 @@
+package jwt
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+const (
+	// TypeAccess is the token type of the access tokens.
+	TypeAccess = "access"
+	// TypeRefresh is the token type of the refresh tokens.
+	TypeRefresh = "refresh"
+)
+
+// Compile time check for the claims interfaces.
+var (
+	_ auth.Claims       = &Claims{}
+	_ auth.NotBeforer   = &Claims{}
+	_ auth.Audiencer    = &Claims{}
+	_ auth.Issuer       = &Claims{}
+	_ auth.Scoper       = &Claims{}
+	_ auth.MFAClaims    = &Claims{}
+	_ auth.ClientClaims = &Claims{}
+	_ auth.AccessClaims = &AccessClaims{}
+)
+
+// Claims are the JSON Web Token registered claims with the token type and authorization scope.
+type Claims struct {
+	ID             string `json:"jti,omitempty"`
+	Type           string `json:"typ,omitempty"`
+	SubjectValue   string `json:"sub,omitempty"`
+	AudienceValue  string `json:"aud,omitempty"`
+	IssuerValue    string `json:"iss,omitempty"`
+	ExpiresAt      int64  `json:"exp,omitempty"`
+	IssuedAt       int64  `json:"iat,omitempty"`
+	NotBeforeValue int64  `json:"nbf,omitempty"`
+	ScopeValue     string `json:"scope,omitempty"`
+	MFA            bool   `json:"mfa,omitempty"`
+	ClientIDValue  string `json:"client_id,omitempty"`
+
+	timeFunc func() time.Time
+}
+
+// Subject implements auth.Claims interface.
+func (c *Claims) Subject() string {
+	return c.SubjectValue
+}
+
+// ExpiresIn implements auth.Claims interface. Returns the number of seconds left to the expiration.
+// If the token doesn't expire it returns -1.
+func (c *Claims) ExpiresIn() int64 {
+	if c.ExpiresAt == 0 {
+		return -1
+	}
+	expiresIn := c.ExpiresAt - c.now().Unix()
+	if expiresIn < 0 {
+		return 0
+	}
+	return expiresIn
+}
+
+// Valid implements auth.Claims interface. Checks if the claims are not expired and already valid.
+func (c *Claims) Valid() error {
+	now := c.now().Unix()
+	if c.ExpiresAt != 0 && now >= c.ExpiresAt {
+		return errors.WrapDet(auth.ErrTokenExpired, "token is expired").WithDetail("The token is expired.")
+	}
+	if c.NotBeforeValue != 0 && now < c.NotBeforeValue {
+		return errors.WrapDet(auth.ErrTokenNotValidYet, "token is not valid yet").WithDetail("The token is not valid yet.")
+	}
+	return nil
+}
+
+// NotBefore implements auth.NotBeforer interface.
+func (c *Claims) NotBefore() int64 {
+	return c.NotBeforeValue
+}
+
+// Audience implements auth.Audiencer interface.
+func (c *Claims) Audience() string {
+	return c.AudienceValue
+}
+
+// Issuer implements auth.Issuer interface.
+func (c *Claims) Issuer() string {
+	return c.IssuerValue
+}
+
+// Scope implements auth.Scoper interface.
+func (c *Claims) Scope() string {
+	return c.ScopeValue
+}
+
+// MFASatisfied implements auth.MFAClaims interface.
+func (c *Claims) MFASatisfied() bool {
+	return c.MFA
+}
+
+// ClientID implements auth.ClientClaims interface.
+func (c *Claims) ClientID() string {
+	return c.ClientIDValue
+}
+
+func (c *Claims) now() time.Time {
+	if c.timeFunc == nil {
+		return time.Now()
+	}
+	return c.timeFunc()
+}
+
+// AccessClaims are the access token claims. The token stores only the account primary key in the subject and its
+// username, so that no sensitive account data is exposed.
+type AccessClaims struct {
+	Claims
+	Username string `json:"username,omitempty"`
+
+	account auth.Account
+}
+
+// GetAccount implements auth.AccessClaims interface. The account has only the primary key and username set.
+func (c *AccessClaims) GetAccount() auth.Account {
+	return c.account
+}
+
+// RefreshClaims are the refresh token claims.
+type RefreshClaims struct {
+	Claims
+}
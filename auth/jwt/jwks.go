@@ -0,0 +1,106 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// JSONWebKey is the public JSON Web Key (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	// N and E are the RSA public key modulus and exponent.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve, X and Y are the elliptic curve public key parameters. The octet key pairs (Ed25519) has only the X.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet is the JSON Web Key Set document.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeySet gets the public keys of the tokener that are not retired. The primary key is the first one. The HMAC
+// secrets are never published.
+func (t *Tokener) KeySet() *JSONWebKeySet {
+	now := t.Options.TimeFunc()
+	t.lock.RLock()
+	keys := make([]*key, 0, len(t.keys))
+	for _, k := range t.keys {
+		if k == t.primary || !k.isRetired(now) {
+			keys = append(keys, k)
+		}
+	}
+	primary := t.primary
+	t.lock.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i] == primary || keys[j] == primary {
+			return keys[i] == primary
+		}
+		return keys[i].id < keys[j].id
+	})
+	set := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, k := range keys {
+		if jwk, ok := publicJSONWebKey(k); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// JWKS renders the tokener public keys as the JSON Web Key Set document.
+func (t *Tokener) JWKS() ([]byte, error) {
+	data, err := json.Marshal(t.KeySet())
+	if err != nil {
+		return nil, errors.WrapDetf(auth.ErrInternalError, "marshaling JSON Web Key Set failed: %v", err)
+	}
+	return data, nil
+}
+
+// publicJSONWebKey creates the JSON Web Key for the public key of 'k'. Returns false if the key is not public.
+func publicJSONWebKey(k *key) (JSONWebKey, bool) {
+	jwk := JSONWebKey{KeyID: k.id, Use: "sig", Algorithm: k.method.Alg()}
+	switch publicKey := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encodeSegment(publicKey.N.Bytes())
+		jwk.E = encodeSegment(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		params := publicKey.Curve.Params()
+		size := (params.BitSize + 7) / 8
+		jwk.KeyType = "EC"
+		jwk.Curve = params.Name
+		jwk.X = encodeSegment(padBytes(publicKey.X.Bytes(), size))
+		jwk.Y = encodeSegment(padBytes(publicKey.Y.Bytes(), size))
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = encodeSegment(publicKey)
+	default:
+		return jwk, false
+	}
+	return jwk, true
+}
+
+// padBytes left pads the 'data' with zeros to given 'size'.
+func padBytes(data []byte, size int) []byte {
+	if len(data) >= size {
+		return data
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(data):], data)
+	return padded
+}
@@ -0,0 +1,280 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// key is the tokener signing or verification key.
+type key struct {
+	id         string
+	method     auth.SigningMethod
+	signingKey interface{}
+	verifyKey  interface{}
+	retiresAt  time.Time
+}
+
+// isRetired checks if the key is retired at the time 'now'.
+func (k *key) isRetired(now time.Time) bool {
+	return !k.retiresAt.IsZero() && !now.Before(k.retiresAt)
+}
+
+// RotateKey sets the 'signingKey' as the primary key of the tokener. The previous primary key is used only
+// to verify the tokens. Unless its retirement time is set, it retires after the longest token expiration time, so
+// that all the tokens signed with it would expire.
+func (t *Tokener) RotateKey(signingKey *auth.SigningKey) error {
+	k, err := newKey(signingKey, true)
+	if err != nil {
+		return err
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.keys[k.id]; ok {
+		return errors.WrapDetf(auth.ErrInitialization, "duplicated tokener key id: '%s'", k.id)
+	}
+	previous := *t.primary
+	previous.signingKey = nil
+	if previous.retiresAt.IsZero() {
+		expiration := t.Options.TokenExpiration
+		if t.Options.RefreshTokenExpiration > expiration {
+			expiration = t.Options.RefreshTokenExpiration
+		}
+		previous.retiresAt = t.Options.TimeFunc().Add(expiration)
+	}
+	t.keys[previous.id] = &previous
+	t.keys[k.id] = k
+	t.primary = k
+	return nil
+}
+
+// signingKey gets the primary key used to sign the tokens.
+func (t *Tokener) signingKey() *key {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.primary
+}
+
+// verificationKey gets the key with the identifier 'kid' used to verify the token signed with the 'alg' method.
+func (t *Tokener) verificationKey(kid, alg string) (*key, error) {
+	t.lock.RLock()
+	k, ok := t.keys[kid]
+	primary := t.primary
+	t.lock.RUnlock()
+	if !ok {
+		return nil, errors.WrapDetf(auth.ErrToken, "unknown token key id: '%s'", kid).
+			WithDetail("The token signing key is not valid.")
+	}
+	// The primary key retires only after it is rotated.
+	if k != primary && k.isRetired(t.Options.TimeFunc()) {
+		return nil, errors.WrapDetf(auth.ErrToken, "token key: '%s' is retired", kid).
+			WithDetail("The token signing key is not valid.")
+	}
+	if alg != k.method.Alg() {
+		return nil, errors.WrapDetf(auth.ErrToken, "unexpected token signing method: '%s'", alg).
+			WithDetail("The token signing method is not valid.")
+	}
+	return k, nil
+}
+
+// setKeys sets the tokener primary and verification keys. If the options signing key is not defined, the primary
+// key is created from the options secret or private keys and signs the tokens without the key id.
+func (t *Tokener) setKeys() (err error) {
+	o := t.Options
+	if o.SigningKey != nil {
+		signingKey := *o.SigningKey
+		if signingKey.SigningMethod == nil {
+			signingKey.SigningMethod = o.SigningMethod
+		}
+		t.primary, err = newKey(&signingKey, true)
+	} else {
+		t.primary, err = t.optionsKey()
+	}
+	if err != nil {
+		return err
+	}
+	o.SigningMethod = t.primary.method
+	t.keys = map[string]*key{t.primary.id: t.primary}
+	for _, verificationKey := range o.VerificationKeys {
+		k, err := newKey(verificationKey, false)
+		if err != nil {
+			return err
+		}
+		if _, ok := t.keys[k.id]; ok {
+			return errors.WrapDetf(auth.ErrInitialization, "duplicated tokener key id: '%s'", k.id)
+		}
+		t.keys[k.id] = k
+	}
+	return nil
+}
+
+// optionsKey creates the key from the options secret or private keys matching the options signing method.
+func (t *Tokener) optionsKey() (*key, error) {
+	o := t.Options
+	var signingKey interface{}
+	switch o.SigningMethod.(type) {
+	case nil:
+		switch {
+		case o.Secret != nil:
+			signingKey = o.Secret
+		case o.RsaPrivateKey != nil:
+			signingKey = o.RsaPrivateKey
+		case o.EcdsaPrivateKey != nil:
+			signingKey = o.EcdsaPrivateKey
+		case o.Ed25519PrivateKey != nil:
+			signingKey = o.Ed25519PrivateKey
+		default:
+			return nil, errors.WrapDet(auth.ErrInitialization, "no signing key defined for the tokener")
+		}
+	case *SigningMethodHMAC:
+		signingKey = o.Secret
+	case *SigningMethodRSA, *SigningMethodRSAPSS:
+		if o.RsaPrivateKey == nil {
+			return nil, errors.WrapDetf(auth.ErrInvalidRSAKey, "no RSA private key defined for the signing method: '%s'", o.SigningMethod.Alg())
+		}
+		signingKey = o.RsaPrivateKey
+	case *SigningMethodECDSA:
+		if o.EcdsaPrivateKey == nil {
+			return nil, errors.WrapDetf(auth.ErrInvalidECDSAKey, "no ECDSA private key defined for the signing method: '%s'", o.SigningMethod.Alg())
+		}
+		signingKey = o.EcdsaPrivateKey
+	case *SigningMethodEd25519:
+		if o.Ed25519PrivateKey == nil {
+			return nil, errors.WrapDetf(auth.ErrInvalidEd25519Key, "no Ed25519 private key defined for the signing method: '%s'", o.SigningMethod.Alg())
+		}
+		signingKey = o.Ed25519PrivateKey
+	default:
+		// Custom signing methods uses the first defined key.
+		switch {
+		case o.Secret != nil:
+			signingKey = o.Secret
+		case o.RsaPrivateKey != nil:
+			signingKey = o.RsaPrivateKey
+		case o.EcdsaPrivateKey != nil:
+			signingKey = o.EcdsaPrivateKey
+		case o.Ed25519PrivateKey != nil:
+			signingKey = o.Ed25519PrivateKey
+		default:
+			return nil, errors.WrapDetf(auth.ErrInitialization, "no signing key defined for the signing method: '%s'", o.SigningMethod.Alg())
+		}
+	}
+	return newKey(&auth.SigningKey{Key: signingKey, SigningMethod: o.SigningMethod}, true)
+}
+
+// newKey creates and validates the key for the 'signingKey'. If the key is not used for 'signing' it might be
+// a public key.
+func newKey(signingKey *auth.SigningKey, signing bool) (*key, error) {
+	k := &key{id: signingKey.ID, method: signingKey.SigningMethod, retiresAt: signingKey.RetiresAt}
+	if k.method == nil {
+		k.method = defaultSigningMethod(signingKey.Key)
+		if k.method == nil {
+			return nil, errors.WrapDetf(auth.ErrInitialization, "no signing method defined for the key type: '%T'", signingKey.Key)
+		}
+	}
+	switch method := k.method.(type) {
+	case *SigningMethodHMAC:
+		secret, ok := signingKey.Key.([]byte)
+		if !ok || len(secret) < MinimalSecretLength {
+			return nil, errors.WrapDetf(auth.ErrInvalidSecret, "the secret needs to be at least %d bytes long", MinimalSecretLength)
+		}
+		k.signingKey, k.verifyKey = secret, secret
+	case *SigningMethodRSA, *SigningMethodRSAPSS:
+		switch rsaKey := signingKey.Key.(type) {
+		case *rsa.PrivateKey:
+			if err := rsaKey.Validate(); err != nil {
+				return nil, errors.WrapDetf(auth.ErrInvalidRSAKey, "invalid RSA private key: %v", err)
+			}
+			k.signingKey, k.verifyKey = rsaKey, &rsaKey.PublicKey
+		case *rsa.PublicKey:
+			if signing {
+				return nil, errors.WrapDetf(auth.ErrInvalidRSAKey, "RSA public key cannot sign the tokens: '%s'", k.id)
+			}
+			k.verifyKey = rsaKey
+		default:
+			return nil, errors.WrapDetf(auth.ErrInvalidRSAKey, "invalid key type: '%T' for the signing method: '%s'", signingKey.Key, method.Alg())
+		}
+	case *SigningMethodECDSA:
+		var publicKey *ecdsa.PublicKey
+		switch ecdsaKey := signingKey.Key.(type) {
+		case *ecdsa.PrivateKey:
+			k.signingKey, publicKey = ecdsaKey, &ecdsaKey.PublicKey
+		case *ecdsa.PublicKey:
+			if signing {
+				return nil, errors.WrapDetf(auth.ErrInvalidECDSAKey, "ECDSA public key cannot sign the tokens: '%s'", k.id)
+			}
+			publicKey = ecdsaKey
+		default:
+			return nil, errors.WrapDetf(auth.ErrInvalidECDSAKey, "invalid key type: '%T' for the signing method: '%s'", signingKey.Key, method.Alg())
+		}
+		if publicKey.Curve != method.Curve {
+			return nil, errors.WrapDetf(auth.ErrInvalidECDSAKey, "ECDSA key curve doesn't match the signing method: '%s'", method.Alg())
+		}
+		k.verifyKey = publicKey
+	case *SigningMethodEd25519:
+		switch ed25519Key := signingKey.Key.(type) {
+		case ed25519.PrivateKey:
+			if len(ed25519Key) != ed25519.PrivateKeySize {
+				return nil, errors.WrapDetf(auth.ErrInvalidEd25519Key, "invalid Ed25519 private key size: %d", len(ed25519Key))
+			}
+			k.signingKey, k.verifyKey = ed25519Key, ed25519Key.Public()
+		case ed25519.PublicKey:
+			if signing {
+				return nil, errors.WrapDetf(auth.ErrInvalidEd25519Key, "Ed25519 public key cannot sign the tokens: '%s'", k.id)
+			}
+			if len(ed25519Key) != ed25519.PublicKeySize {
+				return nil, errors.WrapDetf(auth.ErrInvalidEd25519Key, "invalid Ed25519 public key size: %d", len(ed25519Key))
+			}
+			k.verifyKey = ed25519Key
+		default:
+			return nil, errors.WrapDetf(auth.ErrInvalidEd25519Key, "invalid key type: '%T' for the signing method: '%s'", signingKey.Key, method.Alg())
+		}
+	default:
+		// Custom signing methods verifies the tokens with the public key of the private key.
+		k.signingKey, k.verifyKey = signingKey.Key, signingKey.Key
+		switch privateKey := signingKey.Key.(type) {
+		case *rsa.PrivateKey:
+			k.verifyKey = &privateKey.PublicKey
+		case *ecdsa.PrivateKey:
+			k.verifyKey = &privateKey.PublicKey
+		case ed25519.PrivateKey:
+			k.verifyKey = privateKey.Public()
+		}
+		if !signing {
+			k.signingKey = nil
+		}
+	}
+	return k, nil
+}
+
+// defaultSigningMethod gets the signing method for the 'key': HS256 for the secret, RS256 for the RSA key, EdDSA
+// for the Ed25519 key and ES256, ES384 or ES512 for the ECDSA key curve.
+func defaultSigningMethod(key interface{}) auth.SigningMethod {
+	var curve elliptic.Curve
+	switch k := key.(type) {
+	case []byte:
+		return SigningMethodHS256
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return SigningMethodRS256
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return SigningMethodEdDSA
+	case *ecdsa.PrivateKey:
+		curve = k.Curve
+	case *ecdsa.PublicKey:
+		curve = k.Curve
+	default:
+		return nil
+	}
+	switch curve {
+	case elliptic.P384():
+		return SigningMethodES384
+	case elliptic.P521():
+		return SigningMethodES512
+	default:
+		return SigningMethodES256
+	}
+}
@@ -0,0 +1,170 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+)
+
+func tokenHeader(t *testing.T, token string) *header {
+	t.Helper()
+	data, err := decodeSegment(strings.Split(token, ".")[0])
+	require.NoError(t, err)
+	h := &header{}
+	require.NoError(t, json.Unmarshal(data, h))
+	return h
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	account := &testmodels.User{ID: 1, Username: "user"}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	// The tokener without the signing key signs the tokens without the key id.
+	tokener, clock := testTokener(t, auth.TokenerTokenExpiration(time.Hour), auth.TokenerRefreshTokenExpiration(2*time.Hour))
+	legacy, err := tokener.Token(ctx, account)
+	require.NoError(t, err)
+	assert.Empty(t, tokenHeader(t, legacy.AccessToken).KeyID)
+
+	require.NoError(t, tokener.RotateKey(&auth.SigningKey{ID: "rsa", Key: rsaKey}))
+	assert.True(t, errors.Is(tokener.RotateKey(&auth.SigningKey{ID: "rsa", Key: rsaKey}), auth.ErrInitialization))
+	rotated, err := tokener.Token(ctx, account)
+	require.NoError(t, err)
+	h := tokenHeader(t, rotated.AccessToken)
+	assert.Equal(t, "rsa", h.KeyID)
+	assert.Equal(t, "RS256", h.Algorithm)
+
+	// The tokens signed with the previous key are valid until it retires.
+	_, err = tokener.InspectToken(ctx, legacy.AccessToken)
+	require.NoError(t, err)
+	_, err = tokener.InspectToken(ctx, rotated.AccessToken)
+	require.NoError(t, err)
+
+	require.NoError(t, tokener.RotateKey(&auth.SigningKey{ID: "ecdsa", Key: ecdsaKey}))
+	clock.Add(90 * time.Minute)
+	_, err = tokener.Token(ctx, account, auth.TokenRefreshToken(legacy.RefreshToken))
+	require.NoError(t, err)
+
+	// Both previous keys retires after the refresh token expiration.
+	clock.Add(time.Hour)
+	_, err = tokener.InspectToken(ctx, legacy.RefreshToken)
+	assert.True(t, errors.Is(err, auth.ErrToken))
+	_, err = tokener.InspectToken(ctx, rotated.RefreshToken)
+	assert.True(t, errors.Is(err, auth.ErrToken))
+	current, err := tokener.Token(ctx, account)
+	require.NoError(t, err)
+	_, err = tokener.InspectToken(ctx, current.AccessToken)
+	require.NoError(t, err)
+}
+
+func TestVerificationKeys(t *testing.T) {
+	ctx := context.Background()
+	account := &testmodels.User{ID: 1}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	previous, clock := testTokener(t, auth.TokenerSigningKey(&auth.SigningKey{ID: "previous", Key: rsaKey}))
+	token, err := previous.Token(ctx, account)
+	require.NoError(t, err)
+
+	tokener, err := New(
+		auth.TokenerAccount(&testmodels.User{}),
+		auth.TokenerTimeFunc(clock.Now),
+		auth.TokenerSigningKey(&auth.SigningKey{ID: "current", Key: testSecret, SigningMethod: SigningMethodHS512}),
+		auth.TokenerVerificationKeys(&auth.SigningKey{ID: "previous", Key: &rsaKey.PublicKey, RetiresAt: clock.Now().Add(time.Minute)}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, SigningMethodHS512, tokener.Options.SigningMethod)
+
+	_, err = tokener.InspectToken(ctx, token.AccessToken)
+	require.NoError(t, err)
+	clock.Add(time.Minute)
+	_, err = tokener.InspectToken(ctx, token.AccessToken)
+	assert.True(t, errors.Is(err, auth.ErrToken))
+
+	// The token without the key id is not verified by the key set.
+	legacy, _ := testTokener(t)
+	token, err = legacy.Token(ctx, account)
+	require.NoError(t, err)
+	_, err = tokener.InspectToken(ctx, token.AccessToken)
+	assert.True(t, errors.Is(err, auth.ErrToken))
+
+	_, err = New(
+		auth.TokenerAccount(&testmodels.User{}),
+		auth.TokenerSigningKey(&auth.SigningKey{ID: "current", Key: testSecret}),
+		auth.TokenerVerificationKeys(&auth.SigningKey{ID: "current", Key: &rsaKey.PublicKey}),
+	)
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+
+	_, err = New(auth.TokenerAccount(&testmodels.User{}), auth.TokenerSigningKey(&auth.SigningKey{ID: "public", Key: &rsaKey.PublicKey}))
+	assert.True(t, errors.Is(err, auth.ErrInvalidRSAKey))
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	tokener, clock := testTokener(t,
+		auth.TokenerSigningKey(&auth.SigningKey{ID: "ecdsa", Key: ecdsaKey}),
+		auth.TokenerVerificationKeys(
+			&auth.SigningKey{ID: "rsa", Key: &rsaKey.PublicKey, RetiresAt: time.Now().Add(time.Hour)},
+			&auth.SigningKey{ID: "secret", Key: testSecret},
+		),
+	)
+	data, err := tokener.JWKS()
+	require.NoError(t, err)
+	set := &JSONWebKeySet{}
+	require.NoError(t, json.Unmarshal(data, set))
+	require.Len(t, set.Keys, 2)
+
+	ec := set.Keys[0]
+	assert.Equal(t, "EC", ec.KeyType)
+	assert.Equal(t, "ecdsa", ec.KeyID)
+	assert.Equal(t, "ES384", ec.Algorithm)
+	assert.Equal(t, "P-384", ec.Curve)
+	x, err := decodeSegment(ec.X)
+	require.NoError(t, err)
+	assert.Len(t, x, 48)
+	assert.Equal(t, 0, new(big.Int).SetBytes(x).Cmp(ecdsaKey.X))
+
+	r := set.Keys[1]
+	assert.Equal(t, "RSA", r.KeyType)
+	assert.Equal(t, "rsa", r.KeyID)
+	assert.Equal(t, "sig", r.Use)
+	assert.Equal(t, "AQAB", r.E)
+	n, err := decodeSegment(r.N)
+	require.NoError(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(n).Cmp(rsaKey.N))
+
+	clock.Add(2 * time.Hour)
+	assert.Len(t, tokener.KeySet().Keys, 1)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, tokener.RotateKey(&auth.SigningKey{ID: "ed25519", Key: privateKey}))
+	okp := tokener.KeySet().Keys[0]
+	assert.Equal(t, "OKP", okp.KeyType)
+	assert.Equal(t, "EdDSA", okp.Algorithm)
+	assert.Equal(t, "Ed25519", okp.Curve)
+	assert.Equal(t, encodeSegment(publicKey), okp.X)
+}
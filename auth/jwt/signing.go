@@ -0,0 +1,300 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	// Register hash functions used by the signing methods.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// Compile time check for the auth.SigningMethod interface.
+var (
+	_ auth.SigningMethod = &SigningMethodHMAC{}
+	_ auth.SigningMethod = &SigningMethodRSA{}
+	_ auth.SigningMethod = &SigningMethodRSAPSS{}
+	_ auth.SigningMethod = &SigningMethodECDSA{}
+	_ auth.SigningMethod = &SigningMethodEd25519{}
+)
+
+// The signing methods supported by the tokener.
+var (
+	SigningMethodHS256 = &SigningMethodHMAC{Name: "HS256", Hash: crypto.SHA256}
+	SigningMethodHS384 = &SigningMethodHMAC{Name: "HS384", Hash: crypto.SHA384}
+	SigningMethodHS512 = &SigningMethodHMAC{Name: "HS512", Hash: crypto.SHA512}
+
+	SigningMethodRS256 = &SigningMethodRSA{Name: "RS256", Hash: crypto.SHA256}
+	SigningMethodRS384 = &SigningMethodRSA{Name: "RS384", Hash: crypto.SHA384}
+	SigningMethodRS512 = &SigningMethodRSA{Name: "RS512", Hash: crypto.SHA512}
+
+	SigningMethodPS256 = &SigningMethodRSAPSS{SigningMethodRSA: SigningMethodRSA{Name: "PS256", Hash: crypto.SHA256}}
+	SigningMethodPS384 = &SigningMethodRSAPSS{SigningMethodRSA: SigningMethodRSA{Name: "PS384", Hash: crypto.SHA384}}
+	SigningMethodPS512 = &SigningMethodRSAPSS{SigningMethodRSA: SigningMethodRSA{Name: "PS512", Hash: crypto.SHA512}}
+
+	SigningMethodES256 = &SigningMethodECDSA{Name: "ES256", Hash: crypto.SHA256, Curve: elliptic.P256()}
+	SigningMethodES384 = &SigningMethodECDSA{Name: "ES384", Hash: crypto.SHA384, Curve: elliptic.P384()}
+	SigningMethodES512 = &SigningMethodECDSA{Name: "ES512", Hash: crypto.SHA512, Curve: elliptic.P521()}
+
+	SigningMethodEdDSA = &SigningMethodEd25519{Name: "EdDSA"}
+)
+
+// SigningMethods are all the supported signing methods mapped by their algorithm names.
+var SigningMethods = map[string]auth.SigningMethod{}
+
+func init() {
+	for _, method := range []auth.SigningMethod{
+		SigningMethodHS256, SigningMethodHS384, SigningMethodHS512,
+		SigningMethodRS256, SigningMethodRS384, SigningMethodRS512,
+		SigningMethodPS256, SigningMethodPS384, SigningMethodPS512,
+		SigningMethodES256, SigningMethodES384, SigningMethodES512,
+		SigningMethodEdDSA,
+	} {
+		SigningMethods[method.Alg()] = method
+	}
+}
+
+// SigningMethodHMAC is the HMAC signing method. The key for both signing and verifying is the []byte secret.
+type SigningMethodHMAC struct {
+	Name string
+	Hash crypto.Hash
+}
+
+// Alg implements auth.SigningMethod interface.
+func (m *SigningMethodHMAC) Alg() string {
+	return m.Name
+}
+
+// Sign implements auth.SigningMethod interface.
+func (m *SigningMethodHMAC) Sign(signingString string, key interface{}) (string, error) {
+	secret, ok := key.([]byte)
+	if !ok || len(secret) == 0 {
+		return "", errInvalidKey(m, key)
+	}
+	h := hmac.New(m.Hash.New, secret)
+	h.Write([]byte(signingString))
+	return encodeSegment(h.Sum(nil)), nil
+}
+
+// Verify implements auth.SigningMethod interface.
+func (m *SigningMethodHMAC) Verify(signingString, signature string, key interface{}) error {
+	secret, ok := key.([]byte)
+	if !ok || len(secret) == 0 {
+		return errInvalidKey(m, key)
+	}
+	sig, err := decodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	h := hmac.New(m.Hash.New, secret)
+	h.Write([]byte(signingString))
+	if !hmac.Equal(sig, h.Sum(nil)) {
+		return errInvalidSignature()
+	}
+	return nil
+}
+
+// SigningMethodRSA is the RSA PKCS #1 v1.5 signing method. It signs with *rsa.PrivateKey and verifies with
+// *rsa.PublicKey.
+type SigningMethodRSA struct {
+	Name string
+	Hash crypto.Hash
+}
+
+// Alg implements auth.SigningMethod interface.
+func (m *SigningMethodRSA) Alg() string {
+	return m.Name
+}
+
+// Sign implements auth.SigningMethod interface.
+func (m *SigningMethodRSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return "", errInvalidKey(m, key)
+	}
+	sig, err := rsa.SignPKCS1v15(rand.Reader, privateKey, m.Hash, hashString(m.Hash, signingString))
+	if err != nil {
+		return "", errors.WrapDetf(auth.ErrInternalError, "signing token failed: %v", err)
+	}
+	return encodeSegment(sig), nil
+}
+
+// Verify implements auth.SigningMethod interface.
+func (m *SigningMethodRSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return errInvalidKey(m, key)
+	}
+	sig, err := decodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if err = rsa.VerifyPKCS1v15(publicKey, m.Hash, hashString(m.Hash, signingString), sig); err != nil {
+		return errInvalidSignature()
+	}
+	return nil
+}
+
+// SigningMethodRSAPSS is the RSA PSS signing method. It signs with *rsa.PrivateKey and verifies with *rsa.PublicKey.
+type SigningMethodRSAPSS struct {
+	SigningMethodRSA
+}
+
+// Sign implements auth.SigningMethod interface.
+func (m *SigningMethodRSAPSS) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return "", errInvalidKey(m, key)
+	}
+	sig, err := rsa.SignPSS(rand.Reader, privateKey, m.Hash, hashString(m.Hash, signingString), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	if err != nil {
+		return "", errors.WrapDetf(auth.ErrInternalError, "signing token failed: %v", err)
+	}
+	return encodeSegment(sig), nil
+}
+
+// Verify implements auth.SigningMethod interface.
+func (m *SigningMethodRSAPSS) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return errInvalidKey(m, key)
+	}
+	sig, err := decodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if err = rsa.VerifyPSS(publicKey, m.Hash, hashString(m.Hash, signingString), sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto}); err != nil {
+		return errInvalidSignature()
+	}
+	return nil
+}
+
+// SigningMethodECDSA is the ECDSA signing method. It signs with *ecdsa.PrivateKey and verifies with *ecdsa.PublicKey.
+// The keys must use the method 'Curve'.
+type SigningMethodECDSA struct {
+	Name  string
+	Hash  crypto.Hash
+	Curve elliptic.Curve
+}
+
+// Alg implements auth.SigningMethod interface.
+func (m *SigningMethodECDSA) Alg() string {
+	return m.Name
+}
+
+// Sign implements auth.SigningMethod interface.
+func (m *SigningMethodECDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || privateKey.Curve != m.Curve {
+		return "", errInvalidKey(m, key)
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hashString(m.Hash, signingString))
+	if err != nil {
+		return "", errors.WrapDetf(auth.ErrInternalError, "signing token failed: %v", err)
+	}
+	// The signature is the concatenation of the fixed size 'r' and 's' values.
+	size := m.keySize()
+	sig := make([]byte, 2*size)
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	copy(sig[size-len(rBytes):size], rBytes)
+	copy(sig[2*size-len(sBytes):], sBytes)
+	return encodeSegment(sig), nil
+}
+
+// Verify implements auth.SigningMethod interface.
+func (m *SigningMethodECDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || publicKey.Curve != m.Curve {
+		return errInvalidKey(m, key)
+	}
+	sig, err := decodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	size := m.keySize()
+	if len(sig) != 2*size {
+		return errInvalidSignature()
+	}
+	r := new(big.Int).SetBytes(sig[:size])
+	s := new(big.Int).SetBytes(sig[size:])
+	if !ecdsa.Verify(publicKey, hashString(m.Hash, signingString), r, s) {
+		return errInvalidSignature()
+	}
+	return nil
+}
+
+// SigningMethodEd25519 is the EdDSA signing method using the Ed25519 curve. It signs with ed25519.PrivateKey and
+// verifies with ed25519.PublicKey.
+type SigningMethodEd25519 struct {
+	Name string
+}
+
+// Alg implements auth.SigningMethod interface.
+func (m *SigningMethodEd25519) Alg() string {
+	return m.Name
+}
+
+// Sign implements auth.SigningMethod interface.
+func (m *SigningMethodEd25519) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok || len(privateKey) != ed25519.PrivateKeySize {
+		return "", errInvalidKey(m, key)
+	}
+	return encodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
+
+// Verify implements auth.SigningMethod interface.
+func (m *SigningMethodEd25519) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok || len(publicKey) != ed25519.PublicKeySize {
+		return errInvalidKey(m, key)
+	}
+	sig, err := decodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return errInvalidSignature()
+	}
+	return nil
+}
+
+func (m *SigningMethodECDSA) keySize() int {
+	return (m.Curve.Params().BitSize + 7) / 8
+}
+
+func hashString(h crypto.Hash, s string) []byte {
+	hasher := h.New()
+	hasher.Write([]byte(s))
+	return hasher.Sum(nil)
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegment(segment string) ([]byte, error) {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return nil, errors.WrapDet(auth.ErrToken, "malformed token segment").WithDetail("The token is malformed.")
+	}
+	return data, nil
+}
+
+func errInvalidKey(method auth.SigningMethod, key interface{}) error {
+	return errors.WrapDetf(auth.ErrInitialization, "invalid key type: '%T' for the signing method: '%s'", key, method.Alg())
+}
+
+func errInvalidSignature() error {
+	return errors.WrapDet(auth.ErrToken, "token signature is invalid").WithDetail("The token signature is invalid.")
+}
@@ -0,0 +1,287 @@
+// Package jwt implements the auth.Tokener that creates and inspects JSON Web Tokens.
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+	"github.com/neuronlabs/neuron/store/memory"
+)
+
+const (
+	// DefaultTokenExpiration is the default access token expiration time.
+	DefaultTokenExpiration = 10 * time.Minute
+	// DefaultRefreshTokenExpiration is the default refresh token expiration time.
+	DefaultRefreshTokenExpiration = 24 * time.Hour
+	// MinimalSecretLength is the minimal length of the HMAC secret.
+	MinimalSecretLength = 32
+	// TokenType is the type of the created tokens.
+	TokenType = "Bearer"
+	// revokedKeyPrefix is the store key prefix of the revoked tokens.
+	revokedKeyPrefix = "neuron_revoked_token:"
+)
+
+// Compile time check for the auth.Tokener interface.
+var _ auth.Tokener = &Tokener{}
+
+// Tokener is the auth.Tokener implementation that creates signed JSON Web Tokens. Each token has a unique
+// identifier (jti). The revoked tokens are stored in the options store until they expire. If no store is
+// defined, the in-memory store is used. The tokens are signed with the primary key and verified with the key
+// matching their 'kid' header, which allows to rotate the signing keys.
+type Tokener struct {
+	Options *auth.TokenerOptions
+
+	primary     *key
+	keys        map[string]*key
+	lock        sync.RWMutex
+	accountType reflect.Type
+}
+
+// New creates new JWT tokener. If no signing method is defined it is chosen with respect to the provided key:
+// HS256 for the secret, RS256 for the RSA key, EdDSA for the Ed25519 key and ES256, ES384 or ES512 for the ECDSA
+// key curve. If the signing key is not defined, the tokener signs the tokens with the options secret or private key
+// without the key id.
+func New(options ...auth.TokenerOption) (*Tokener, error) {
+	o := &auth.TokenerOptions{
+		TokenExpiration:        DefaultTokenExpiration,
+		RefreshTokenExpiration: DefaultRefreshTokenExpiration,
+		TimeFunc:               time.Now,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	if o.Model == nil {
+		return nil, errors.WrapDet(auth.ErrAccountModelNotDefined, "no account model defined for the tokener")
+	}
+	if o.Store == nil {
+		log.Debug("No store defined for the JWT tokener. Using in-memory store for the revoked tokens.")
+		o.Store = memory.New()
+	}
+	t := &Tokener{Options: o, accountType: reflect.TypeOf(o.Model)}
+	if t.accountType.Kind() != reflect.Ptr {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "account model: '%T' is not a pointer", o.Model)
+	}
+	if err := t.setKeys(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Token implements auth.Tokener interface. Creates the access token and the refresh token for given 'account'.
+// If the options contains valid refresh token of the account, it is used instead of creating new one.
+func (t *Tokener) Token(ctx context.Context, account auth.Account, options ...auth.TokenOption) (auth.Token, error) {
+	if account == nil || account.IsPrimaryKeyZero() {
+		return auth.Token{}, errors.WrapDet(auth.ErrAccountNotValid, "provided account has no primary key value")
+	}
+	subject, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return auth.Token{}, err
+	}
+	o := &auth.TokenOptions{
+		ExpirationTime:        t.Options.TokenExpiration,
+		RefreshExpirationTime: t.Options.RefreshTokenExpiration,
+		Audience:              t.Options.Audience,
+		Issuer:                t.Options.Issuer,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	now := t.Options.TimeFunc()
+
+	access := &AccessClaims{
+		Claims:   t.newClaims(TypeAccess, subject, o, now, o.ExpirationTime),
+		Username: account.GetUsername(),
+	}
+	access.ScopeValue = o.Scope
+	access.MFA = o.MFA
+	access.ClientIDValue = o.ClientID
+	if !o.NotBefore.IsZero() {
+		access.NotBeforeValue = o.NotBefore.Unix()
+	}
+	token := auth.Token{TokenType: TokenType, ExpiresIn: int(o.ExpirationTime / time.Second)}
+	if token.AccessToken, err = t.sign(access); err != nil {
+		return auth.Token{}, err
+	}
+
+	if o.RefreshToken != "" {
+		claims, err := t.InspectToken(ctx, o.RefreshToken)
+		if err != nil {
+			return auth.Token{}, err
+		}
+		if _, ok := claims.(*RefreshClaims); !ok || claims.Subject() != subject {
+			return auth.Token{}, errors.WrapDet(auth.ErrToken, "invalid refresh token").
+				WithDetail("The refresh token is not valid for the account.")
+		}
+		token.RefreshToken = o.RefreshToken
+		return token, nil
+	}
+	refresh := &RefreshClaims{Claims: t.newClaims(TypeRefresh, subject, o, now, o.RefreshExpirationTime)}
+	// The refresh token keeps the scope granted to the account, the multi-factor authentication state and the client
+	// it was issued to, so that it could be used to verify the refreshed access token.
+	refresh.ScopeValue = o.Scope
+	refresh.MFA = o.MFA
+	refresh.ClientIDValue = o.ClientID
+	if token.RefreshToken, err = t.sign(refresh); err != nil {
+		return auth.Token{}, err
+	}
+	return token, nil
+}
+
+// InspectToken implements auth.Tokener interface. Verifies the token signature with the key matching the token key
+// id, expiration, not before, issuer, audience and revocation. The access tokens results in *AccessClaims, and the
+// refresh tokens in *RefreshClaims.
+func (t *Tokener) InspectToken(ctx context.Context, token string) (auth.Claims, error) {
+	claims, base, err := t.parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if err = claims.Valid(); err != nil {
+		return nil, err
+	}
+	if t.Options.Issuer != "" && base.IssuerValue != t.Options.Issuer {
+		return nil, errors.WrapDetf(auth.ErrToken, "invalid token issuer: '%s'", base.IssuerValue).
+			WithDetail("The token issuer is not valid.")
+	}
+	if t.Options.Audience != "" && base.AudienceValue != t.Options.Audience {
+		return nil, errors.WrapDetf(auth.ErrToken, "invalid token audience: '%s'", base.AudienceValue).
+			WithDetail("The token audience is not valid.")
+	}
+	_, err = t.Options.Store.Get(ctx, revokedKeyPrefix+base.ID)
+	switch {
+	case err == nil:
+		return nil, errors.WrapDet(auth.ErrTokenRevoked, "token is revoked").WithDetail("The token is revoked.")
+	case !errors.Is(err, store.ErrRecordNotFound):
+		return nil, err
+	}
+	return claims, nil
+}
+
+// RevokeToken implements auth.Tokener interface. The token identifier is stored in the options store until
+// the token expires. Revoking an expired or already revoked token doesn't return an error.
+func (t *Tokener) RevokeToken(ctx context.Context, token string) error {
+	_, base, err := t.parse(token)
+	if err != nil {
+		return err
+	}
+	ttl := time.Duration(-1)
+	if base.ExpiresAt != 0 {
+		expiresAt := time.Unix(base.ExpiresAt, 0)
+		now := t.Options.TimeFunc()
+		if !now.Before(expiresAt) {
+			return nil
+		}
+		ttl = expiresAt.Sub(now)
+	}
+	record := &store.Record{Key: revokedKeyPrefix + base.ID, Value: []byte(base.Type)}
+	return t.Options.Store.Set(ctx, record, store.SetWithTTL(ttl))
+}
+
+func (t *Tokener) newClaims(tokenType, subject string, o *auth.TokenOptions, now time.Time, expiration time.Duration) Claims {
+	c := Claims{
+		ID:            uuid.New().String(),
+		Type:          tokenType,
+		SubjectValue:  subject,
+		AudienceValue: o.Audience,
+		IssuerValue:   o.Issuer,
+		IssuedAt:      now.Unix(),
+		timeFunc:      t.Options.TimeFunc,
+	}
+	if expiration > 0 {
+		c.ExpiresAt = now.Add(expiration).Unix()
+	}
+	return c
+}
+
+// header is the JSON Web Token header.
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// sign creates the signed token with given 'claims'.
+func (t *Tokener) sign(claims interface{}) (string, error) {
+	k := t.signingKey()
+	headerData, err := json.Marshal(&header{Algorithm: k.method.Alg(), Type: "JWT", KeyID: k.id})
+	if err != nil {
+		return "", errors.WrapDetf(auth.ErrInternalError, "marshaling token header failed: %v", err)
+	}
+	claimsData, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.WrapDetf(auth.ErrInternalError, "marshaling token claims failed: %v", err)
+	}
+	signingString := encodeSegment(headerData) + "." + encodeSegment(claimsData)
+	signature, err := k.method.Sign(signingString, k.signingKey)
+	if err != nil {
+		return "", err
+	}
+	return signingString + "." + signature, nil
+}
+
+// parse verifies the 'token' signature and decodes its claims. The claims are not validated.
+func (t *Tokener) parse(token string) (auth.Claims, *Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, errMalformed()
+	}
+	headerData, err := decodeSegment(parts[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	h := &header{}
+	if err = json.Unmarshal(headerData, h); err != nil {
+		return nil, nil, errMalformed()
+	}
+	k, err := t.verificationKey(h.KeyID, h.Algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = k.method.Verify(parts[0]+"."+parts[1], parts[2], k.verifyKey); err != nil {
+		return nil, nil, err
+	}
+	claimsData, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	base := &Claims{}
+	if err = json.Unmarshal(claimsData, base); err != nil || base.ID == "" {
+		return nil, nil, errMalformed()
+	}
+	switch base.Type {
+	case TypeAccess:
+		claims := &AccessClaims{}
+		if err = json.Unmarshal(claimsData, claims); err != nil {
+			return nil, nil, errMalformed()
+		}
+		claims.timeFunc = t.Options.TimeFunc
+		account := reflect.New(t.accountType.Elem()).Interface().(auth.Account)
+		if err = account.SetPrimaryKeyStringValue(claims.SubjectValue); err != nil {
+			return nil, nil, errors.WrapDetf(auth.ErrToken, "invalid token subject: '%s'", claims.SubjectValue).
+				WithDetail("The token subject is not valid.")
+		}
+		account.SetUsername(claims.Username)
+		claims.account = account
+		return claims, &claims.Claims, nil
+	case TypeRefresh:
+		claims := &RefreshClaims{Claims: *base}
+		claims.timeFunc = t.Options.TimeFunc
+		return claims, &claims.Claims, nil
+	default:
+		return nil, nil, errors.WrapDetf(auth.ErrToken, "unknown token type: '%s'", base.Type).
+			WithDetail("The token type is not valid.")
+	}
+}
+
+func errMalformed() error {
+	return errors.WrapDet(auth.ErrToken, "malformed token").WithDetail("The token is malformed.")
+}
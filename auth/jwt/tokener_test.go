@@ -0,0 +1,287 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+)
+
+var testSecret = []byte("01234567890123456789012345678901")
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func testTokener(t *testing.T, options ...auth.TokenerOption) (*Tokener, *testClock) {
+	t.Helper()
+	clock := &testClock{now: time.Now()}
+	tokener, err := New(append([]auth.TokenerOption{
+		auth.TokenerAccount(&testmodels.User{}),
+		auth.TokenerSecret(testSecret),
+		auth.TokenerTimeFunc(clock.Now),
+	}, options...)...)
+	require.NoError(t, err)
+	return tokener, clock
+}
+
+func TestSigningMethods(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecdsaKeys := map[elliptic.Curve]*ecdsa.PrivateKey{}
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		ecdsaKeys[curve], err = ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for alg, method := range SigningMethods {
+		t.Run(alg, func(t *testing.T) {
+			options := []auth.TokenerOption{auth.TokenerAccount(&testmodels.User{}), auth.TokenerSigningMethod(method)}
+			switch m := method.(type) {
+			case *SigningMethodHMAC:
+				options = append(options, auth.TokenerSecret(testSecret))
+			case *SigningMethodRSA, *SigningMethodRSAPSS:
+				options = append(options, auth.TokenerRsaPrivateKey(rsaKey))
+			case *SigningMethodECDSA:
+				options = append(options, auth.TokenerEcdsaPrivateKey(ecdsaKeys[m.Curve]))
+			case *SigningMethodEd25519:
+				options = append(options, auth.TokenerEd25519PrivateKey(ed25519Key))
+			}
+			tokener, err := New(options...)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			token, err := tokener.Token(ctx, &testmodels.User{ID: 3, Username: "user"}, auth.TokenScope("read"))
+			require.NoError(t, err)
+			assert.Equal(t, TokenType, token.TokenType)
+			assert.Equal(t, int(DefaultTokenExpiration/time.Second), token.ExpiresIn)
+
+			claims, err := tokener.InspectToken(ctx, token.AccessToken)
+			require.NoError(t, err)
+			accessClaims, ok := claims.(auth.AccessClaims)
+			require.True(t, ok)
+			assert.Equal(t, "3", accessClaims.Subject())
+			assert.Equal(t, "read", accessClaims.(auth.Scoper).Scope())
+			account, ok := accessClaims.GetAccount().(*testmodels.User)
+			require.True(t, ok)
+			assert.Equal(t, 3, account.ID)
+			assert.Equal(t, "user", account.Username)
+
+			// Changing the token payload invalidates the signature.
+			parts := strings.Split(token.AccessToken, ".")
+			parts[1] = encodeSegment([]byte(`{"jti":"id","typ":"access","sub":"1"}`))
+			_, err = tokener.InspectToken(ctx, strings.Join(parts, "."))
+			assert.True(t, errors.Is(err, auth.ErrToken))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(auth.TokenerSecret(testSecret))
+	assert.True(t, errors.Is(err, auth.ErrAccountModelNotDefined))
+
+	_, err = New(auth.TokenerAccount(&testmodels.User{}))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+
+	_, err = New(auth.TokenerAccount(&testmodels.User{}), auth.TokenerSecret([]byte("short")))
+	assert.True(t, errors.Is(err, auth.ErrInvalidSecret))
+
+	_, err = New(auth.TokenerAccount(&testmodels.User{}), auth.TokenerSecret(testSecret), auth.TokenerSigningMethod(SigningMethodRS256))
+	assert.True(t, errors.Is(err, auth.ErrInvalidRSAKey))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, err = New(auth.TokenerAccount(&testmodels.User{}), auth.TokenerEcdsaPrivateKey(key), auth.TokenerSigningMethod(SigningMethodES384))
+	assert.True(t, errors.Is(err, auth.ErrInvalidECDSAKey))
+
+	tokener, err := New(auth.TokenerAccount(&testmodels.User{}), auth.TokenerEcdsaPrivateKey(key))
+	require.NoError(t, err)
+	assert.Equal(t, SigningMethodES256, tokener.Options.SigningMethod)
+
+	_, err = New(auth.TokenerAccount(&testmodels.User{}), auth.TokenerEcdsaPrivateKey(key), auth.TokenerSigningMethod(SigningMethodEdDSA))
+	assert.True(t, errors.Is(err, auth.ErrInvalidEd25519Key))
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	tokener, err = New(auth.TokenerAccount(&testmodels.User{}), auth.TokenerEd25519PrivateKey(ed25519Key))
+	require.NoError(t, err)
+	assert.Equal(t, SigningMethodEdDSA, tokener.Options.SigningMethod)
+}
+
+func TestInspectToken(t *testing.T) {
+	ctx := context.Background()
+	account := &testmodels.User{ID: 1, Username: "user"}
+
+	t.Run("Expired", func(t *testing.T) {
+		tokener, clock := testTokener(t)
+		token, err := tokener.Token(ctx, account, auth.TokenExpirationTime(time.Minute))
+		require.NoError(t, err)
+		clock.Add(time.Minute)
+		_, err = tokener.InspectToken(ctx, token.AccessToken)
+		assert.True(t, errors.Is(err, auth.ErrTokenExpired))
+
+		// The refresh token is still valid.
+		claims, err := tokener.InspectToken(ctx, token.RefreshToken)
+		require.NoError(t, err)
+		_, ok := claims.(*RefreshClaims)
+		assert.True(t, ok)
+	})
+
+	t.Run("NotBefore", func(t *testing.T) {
+		tokener, clock := testTokener(t)
+		token, err := tokener.Token(ctx, account, auth.TokenWithNotBefore(clock.now.Add(time.Minute)))
+		require.NoError(t, err)
+		_, err = tokener.InspectToken(ctx, token.AccessToken)
+		assert.True(t, errors.Is(err, auth.ErrTokenNotValidYet))
+		clock.Add(time.Minute)
+		_, err = tokener.InspectToken(ctx, token.AccessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("AudienceAndIssuer", func(t *testing.T) {
+		tokener, _ := testTokener(t, auth.TokenerAudience("service"), auth.TokenerIssuer("issuer"))
+		token, err := tokener.Token(ctx, account)
+		require.NoError(t, err)
+		claims, err := tokener.InspectToken(ctx, token.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "service", claims.(auth.Audiencer).Audience())
+		assert.Equal(t, "issuer", claims.(auth.Issuer).Issuer())
+
+		token, err = tokener.Token(ctx, account, auth.TokenWithAudience("other"))
+		require.NoError(t, err)
+		_, err = tokener.InspectToken(ctx, token.AccessToken)
+		assert.True(t, errors.Is(err, auth.ErrToken))
+
+		token, err = tokener.Token(ctx, account, auth.TokenWithIssuer("other"))
+		require.NoError(t, err)
+		_, err = tokener.InspectToken(ctx, token.AccessToken)
+		assert.True(t, errors.Is(err, auth.ErrToken))
+	})
+
+	t.Run("SigningMethod", func(t *testing.T) {
+		tokener, _ := testTokener(t)
+		other, _ := testTokener(t, auth.TokenerSigningMethod(SigningMethodHS512))
+		token, err := other.Token(ctx, account)
+		require.NoError(t, err)
+		_, err = tokener.InspectToken(ctx, token.AccessToken)
+		assert.True(t, errors.Is(err, auth.ErrToken))
+
+		_, err = tokener.InspectToken(ctx, "invalid.token")
+		assert.True(t, errors.Is(err, auth.ErrToken))
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	tokener, clock := testTokener(t)
+	account := &testmodels.User{ID: 1, Username: "user"}
+
+	token, err := tokener.Token(ctx, account)
+	require.NoError(t, err)
+	require.NoError(t, tokener.RevokeToken(ctx, token.AccessToken))
+	_, err = tokener.InspectToken(ctx, token.AccessToken)
+	assert.True(t, errors.Is(err, auth.ErrTokenRevoked))
+
+	// Revoking the access token doesn't revoke the refresh token.
+	_, err = tokener.InspectToken(ctx, token.RefreshToken)
+	require.NoError(t, err)
+	require.NoError(t, tokener.RevokeToken(ctx, token.RefreshToken))
+	_, err = tokener.InspectToken(ctx, token.RefreshToken)
+	assert.True(t, errors.Is(err, auth.ErrTokenRevoked))
+
+	// Revoking expired token is a no-op.
+	token, err = tokener.Token(ctx, account)
+	require.NoError(t, err)
+	clock.Add(DefaultTokenExpiration)
+	assert.NoError(t, tokener.RevokeToken(ctx, token.AccessToken))
+
+	assert.True(t, errors.Is(tokener.RevokeToken(ctx, "invalid"), auth.ErrToken))
+}
+
+func TestRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	tokener, clock := testTokener(t)
+	account := &testmodels.User{ID: 1, Username: "user"}
+
+	token, err := tokener.Token(ctx, account)
+	require.NoError(t, err)
+	clock.Add(DefaultTokenExpiration)
+
+	refreshed, err := tokener.Token(ctx, account, auth.TokenRefreshToken(token.RefreshToken))
+	require.NoError(t, err)
+	assert.Equal(t, token.RefreshToken, refreshed.RefreshToken)
+	assert.NotEqual(t, token.AccessToken, refreshed.AccessToken)
+
+	// The refresh token of other account is not valid.
+	_, err = tokener.Token(ctx, &testmodels.User{ID: 2}, auth.TokenRefreshToken(token.RefreshToken))
+	assert.True(t, errors.Is(err, auth.ErrToken))
+
+	// The access token cannot be used as the refresh token.
+	_, err = tokener.Token(ctx, account, auth.TokenRefreshToken(refreshed.AccessToken))
+	assert.True(t, errors.Is(err, auth.ErrToken))
+
+	clock.Add(DefaultRefreshTokenExpiration)
+	_, err = tokener.Token(ctx, account, auth.TokenRefreshToken(token.RefreshToken))
+	assert.True(t, errors.Is(err, auth.ErrTokenExpired))
+
+	_, err = tokener.Token(ctx, &testmodels.User{})
+	assert.True(t, errors.Is(err, auth.ErrAccountNotValid))
+}
+
+func TestMFAClaim(t *testing.T) {
+	ctx := context.Background()
+	tokener, _ := testTokener(t)
+	account := &testmodels.User{ID: 1, Username: "user"}
+
+	token, err := tokener.Token(ctx, account)
+	require.NoError(t, err)
+	claims, err := tokener.InspectToken(ctx, token.AccessToken)
+	require.NoError(t, err)
+	assert.False(t, claims.(auth.MFAClaims).MFASatisfied())
+
+	token, err = tokener.Token(ctx, account, auth.TokenMFA(true))
+	require.NoError(t, err)
+	for _, tokenString := range []string{token.AccessToken, token.RefreshToken} {
+		claims, err = tokener.InspectToken(ctx, tokenString)
+		require.NoError(t, err)
+		assert.True(t, claims.(auth.MFAClaims).MFASatisfied())
+		assert.True(t, auth.CtxMFASatisfied(auth.CtxWithClaims(ctx, claims)))
+	}
+	assert.False(t, auth.CtxMFASatisfied(ctx))
+}
+
+func TestClientIDClaim(t *testing.T) {
+	ctx := context.Background()
+	tokener, _ := testTokener(t)
+	account := &testmodels.User{ID: 1, Username: "user"}
+
+	token, err := tokener.Token(ctx, account, auth.TokenClientID("client"))
+	require.NoError(t, err)
+	for _, tokenString := range []string{token.AccessToken, token.RefreshToken} {
+		claims, err := tokener.InspectToken(ctx, tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, "client", claims.(auth.ClientClaims).ClientID())
+	}
+}
@@ -0,0 +1,243 @@
+// Package lockout implements the brute-force protection of the authentication. The failed attempts are counted per
+// account username and per client IP in the store. After the maximum number of the failed attempts, the account
+// or the IP is locked and each following failure doubles the lockout duration up to the maximum delay. The counters
+// are reset after the window since the last failure. The successful authentication resets only the account counter,
+// so that the attacker with a valid account could not reset the failures of its IP. The counters are updated
+// atomically with the store conditional set.
+package lockout
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+	"github.com/neuronlabs/neuron/store/memory"
+)
+
+const (
+	// accountKeyPrefix is the store key prefix of the account failed attempts counters.
+	accountKeyPrefix = "neuron_lockout_account:"
+	// ipKeyPrefix is the store key prefix of the client IP failed attempts counters.
+	ipKeyPrefix = "neuron_lockout_ip:"
+)
+
+// Lockout counts the failed authentication attempts and locks the accounts and client IPs.
+type Lockout struct {
+	Options *Options
+
+	counters store.ConditionalSetter
+}
+
+// New creates new lockout with provided options. The options store needs to implement store.ConditionalSetter,
+// so that the counters are incremented atomically.
+func New(options ...Option) (*Lockout, error) {
+	o := defaultOptions()
+	for _, option := range options {
+		option(o)
+	}
+	if o.MaxAttempts <= 0 || o.IPMaxAttempts <= 0 {
+		return nil, errors.WrapDet(auth.ErrInitialization, "lockout max attempts needs to be positive")
+	}
+	if o.BaseDelay <= 0 || o.MaxDelay < o.BaseDelay {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "invalid lockout delays: '%s' - '%s'", o.BaseDelay, o.MaxDelay)
+	}
+	if o.Window <= 0 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "invalid lockout window: '%s'", o.Window)
+	}
+	if o.Store == nil {
+		o.Store = memory.New()
+	}
+	counters, ok := o.Store.(store.ConditionalSetter)
+	if !ok {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "lockout store: '%T' doesn't implement store.ConditionalSetter", o.Store)
+	}
+	return &Lockout{Options: o, counters: counters}, nil
+}
+
+// counter is the failed attempts counter stored in the store.
+type counter struct {
+	Failures    int       `json:"failures"`
+	LockedUntil time.Time `json:"locked_until"`
+	ResetAt     time.Time `json:"reset_at"`
+}
+
+// Check checks if the account with the 'username' or the client 'ip' is locked. The locked authentication results
+// in auth.ErrAccountLocked error with the retry after detail. An empty username or ip is not checked.
+func (l *Lockout) Check(ctx context.Context, username, ip string) error {
+	retryAfter, err := l.RetryAfter(ctx, username, ip)
+	if err != nil {
+		return err
+	}
+	if retryAfter > 0 {
+		return lockedError(retryAfter)
+	}
+	return nil
+}
+
+// RetryAfter gets the duration after which the account with the 'username' and the client 'ip' could be
+// authenticated again. If none of them is locked, it returns zero.
+func (l *Lockout) RetryAfter(ctx context.Context, username, ip string) (time.Duration, error) {
+	now := l.Options.TimeFunc()
+	var retryAfter time.Duration
+	for _, key := range keys(username, ip) {
+		c, _, err := l.counter(ctx, key, now)
+		if err != nil {
+			return 0, err
+		}
+		if d := c.LockedUntil.Sub(now); d > retryAfter {
+			retryAfter = d
+		}
+	}
+	return retryAfter, nil
+}
+
+// Fail registers the failed authentication attempt of the account with the 'username' from the client 'ip'.
+// If the attempt locks the account or the ip, the auth.ErrAccountLocked error is returned.
+func (l *Lockout) Fail(ctx context.Context, username, ip string) error {
+	now := l.Options.TimeFunc()
+	var retryAfter time.Duration
+	for _, key := range keys(username, ip) {
+		c, err := l.increment(ctx, key, now)
+		if err != nil {
+			return err
+		}
+		if d := c.LockedUntil.Sub(now); d > retryAfter {
+			retryAfter = d
+		}
+	}
+	if retryAfter > 0 {
+		return lockedError(retryAfter)
+	}
+	return nil
+}
+
+// Reset resets the failed attempts counter of the account with the 'username'. It should be called after
+// the successful authentication. The client IP counter is not reset and expires after the window.
+func (l *Lockout) Reset(ctx context.Context, username string) error {
+	if username == "" {
+		return nil
+	}
+	if err := l.Options.Store.Delete(ctx, accountKeyPrefix+username); err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// ComparePassword checks the lockout of the 'account' and the client 'ip' and compares the 'password' using the
+// 'authenticator'. The failed comparison is registered and the successful one resets the account counter.
+func (l *Lockout) ComparePassword(ctx context.Context, authenticator auth.Authenticator, account auth.Account, password, ip string) error {
+	username := account.GetUsername()
+	if err := l.Check(ctx, username, ip); err != nil {
+		return err
+	}
+	var err error
+	if rehasher, ok := authenticator.(auth.Rehasher); ok {
+		err = rehasher.ComparePasswordAndRehash(ctx, account, password)
+	} else {
+		err = authenticator.ComparePassword(account, password)
+	}
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidPassword) {
+			if failErr := l.Fail(ctx, username, ip); failErr != nil && !errors.Is(failErr, auth.ErrAccountLocked) {
+				return failErr
+			}
+		}
+		return err
+	}
+	return l.Reset(ctx, username)
+}
+
+// delay gets the lockout duration for the failure that exceeded the max attempts by 'exceeded'.
+func (l *Lockout) delay(exceeded int) time.Duration {
+	delay := float64(l.Options.BaseDelay) * math.Pow(2, float64(exceeded))
+	if delay > float64(l.Options.MaxDelay) {
+		return l.Options.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+// increment registers the failure in the counter stored with the 'key'. The counter is set only if it was not
+// changed since it was read, otherwise the increment is retried with the concurrently stored counter.
+func (l *Lockout) increment(ctx context.Context, key string, now time.Time) (*counter, error) {
+	maxAttempts := l.Options.MaxAttempts
+	if strings.HasPrefix(key, ipKeyPrefix) {
+		maxAttempts = l.Options.IPMaxAttempts
+	}
+	for {
+		c, stored, err := l.counter(ctx, key, now)
+		if err != nil {
+			return nil, err
+		}
+		c.Failures++
+		if c.Failures >= maxAttempts {
+			c.LockedUntil = now.Add(l.delay(c.Failures - maxAttempts))
+		}
+		c.ResetAt = now.Add(l.Options.Window)
+		if c.LockedUntil.After(c.ResetAt) {
+			c.ResetAt = c.LockedUntil
+		}
+		value, err := json.Marshal(c)
+		if err != nil {
+			return nil, errors.WrapDetf(auth.ErrInternalError, "marshaling lockout counter: '%s' failed: %v", key, err)
+		}
+		record, ttl := &store.Record{Key: key, Value: value}, store.SetWithTTL(c.ResetAt.Sub(now))
+		if stored == nil {
+			err = l.counters.SetIfNotExists(ctx, record, ttl)
+		} else {
+			err = l.counters.SetIfValue(ctx, record, stored.Value, ttl)
+		}
+		if err == nil {
+			return c, nil
+		}
+		if !errors.Is(err, store.ErrPreconditionFailed) {
+			return nil, err
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// counter gets the counter and the record stored with the 'key'. The counter that should be already reset is
+// empty. If no counter is stored, the record is nil.
+func (l *Lockout) counter(ctx context.Context, key string, now time.Time) (*counter, *store.Record, error) {
+	record, err := l.Options.Store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return &counter{}, nil, nil
+		}
+		return nil, nil, err
+	}
+	c := &counter{}
+	if err = json.Unmarshal(record.Value, c); err != nil {
+		return nil, nil, errors.WrapDetf(auth.ErrInternalError, "unmarshaling lockout counter: '%s' failed: %v", key, err)
+	}
+	if !now.Before(c.ResetAt) {
+		return &counter{}, record, nil
+	}
+	return c, record, nil
+}
+
+// keys gets the store keys of the non empty 'username' and 'ip'.
+func keys(username, ip string) []string {
+	var result []string
+	if username != "" {
+		result = append(result, accountKeyPrefix+username)
+	}
+	if ip != "" {
+		result = append(result, ipKeyPrefix+ip)
+	}
+	return result
+}
+
+// lockedError creates the auth.ErrAccountLocked error with the 'retryAfter' detail.
+func lockedError(retryAfter time.Duration) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	return errors.WrapDetf(auth.ErrAccountLocked, "authentication locked for: %s", retryAfter).
+		WithDetailf("Too many failed authentication attempts. Retry after %d seconds.", seconds)
+}
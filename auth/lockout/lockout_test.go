@@ -0,0 +1,148 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/auth/authenticator"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/store"
+	"github.com/neuronlabs/neuron/store/memory"
+)
+
+// concurrentStore calls the hook once after the first record is read.
+type concurrentStore struct {
+	*memory.Memory
+	hook func()
+}
+
+func (c *concurrentStore) Get(ctx context.Context, key string) (*store.Record, error) {
+	record, err := c.Memory.Get(ctx, key)
+	if hook := c.hook; hook != nil {
+		c.hook = nil
+		hook()
+	}
+	return record, err
+}
+
+func TestLockout(t *testing.T) {
+	now := time.Unix(1603357200, 0)
+	l, err := New(WithMaxAttempts(3), WithIPMaxAttempts(5), WithDelay(time.Second, 5*time.Second), WithWindow(time.Minute),
+		WithTimeFunc(func() time.Time { return now }))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, l.Fail(ctx, "user", "10.0.0.1"))
+		require.NoError(t, l.Check(ctx, "user", "10.0.0.1"))
+	}
+	err = l.Fail(ctx, "user", "10.0.0.1")
+	require.True(t, errors.Is(err, auth.ErrAccountLocked))
+	detailed := &errors.DetailedError{}
+	require.True(t, errors.As(err, &detailed))
+	assert.Equal(t, "Too many failed authentication attempts. Retry after 1 seconds.", detailed.Details)
+
+	// The account is locked from any IP.
+	assert.True(t, errors.Is(l.Check(ctx, "user", "10.0.0.2"), auth.ErrAccountLocked))
+	require.NoError(t, l.Check(ctx, "other", "10.0.0.2"))
+
+	// Each following failure doubles the delay up to the max delay.
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for _, delay := range expected {
+		assert.True(t, errors.Is(l.Fail(ctx, "user", "10.0.0.2"), auth.ErrAccountLocked))
+		retryAfter, err := l.RetryAfter(ctx, "user", "")
+		require.NoError(t, err)
+		assert.Equal(t, delay, retryAfter)
+	}
+
+	now = now.Add(5 * time.Second)
+	require.NoError(t, l.Check(ctx, "user", ""))
+
+	t.Run("IP", func(t *testing.T) {
+		// Both '10.0.0.1' and '10.0.0.2' IPs failed three times.
+		require.NoError(t, l.Check(ctx, "", "10.0.0.2"))
+		require.NoError(t, l.Fail(ctx, "another", "10.0.0.2"))
+		err := l.Fail(ctx, "another", "10.0.0.2")
+		assert.True(t, errors.Is(err, auth.ErrAccountLocked))
+		assert.True(t, errors.Is(l.Check(ctx, "other", "10.0.0.2"), auth.ErrAccountLocked))
+		require.NoError(t, l.Check(ctx, "another", "10.0.0.1"))
+	})
+
+	t.Run("Window", func(t *testing.T) {
+		require.NoError(t, l.Fail(ctx, "window", ""))
+		require.NoError(t, l.Fail(ctx, "window", ""))
+		now = now.Add(time.Minute)
+		// The counter is reset after the window, thus the third failure doesn't lock the account.
+		require.NoError(t, l.Fail(ctx, "window", ""))
+		require.NoError(t, l.Check(ctx, "window", ""))
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		require.NoError(t, l.Fail(ctx, "reset", "10.0.0.3"))
+		require.NoError(t, l.Fail(ctx, "reset", "10.0.0.3"))
+		require.NoError(t, l.Reset(ctx, "reset"))
+		require.NoError(t, l.Fail(ctx, "reset", "10.0.0.3"))
+		require.NoError(t, l.Check(ctx, "reset", ""))
+
+		// The IP counter is not reset by the successful authentication.
+		require.NoError(t, l.Fail(ctx, "first", "10.0.0.3"))
+		assert.True(t, errors.Is(l.Fail(ctx, "second", "10.0.0.3"), auth.ErrAccountLocked))
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		// The store registers the concurrent failure after the counter is read.
+		concurrent := &concurrentStore{Memory: memory.New()}
+		l, err := New(WithStore(concurrent), WithTimeFunc(func() time.Time { return now }))
+		require.NoError(t, err)
+		concurrent.hook = func() {
+			require.NoError(t, l.Fail(ctx, "concurrent", ""))
+		}
+		require.NoError(t, l.Fail(ctx, "concurrent", ""))
+		c, _, err := l.counter(ctx, accountKeyPrefix+"concurrent", now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, c.Failures)
+	})
+}
+
+func TestComparePassword(t *testing.T) {
+	now := time.Unix(1603357200, 0)
+	l, err := New(WithMaxAttempts(2), WithTimeFunc(func() time.Time { return now }))
+	require.NoError(t, err)
+	a, err := authenticator.New(auth.AuthenticatorMethod(auth.SHA256))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	user := &testmodels.User{Username: "user"}
+	require.NoError(t, a.HashAndSetPassword(user, auth.NewPassword("password")))
+
+	err = l.ComparePassword(ctx, a, user, "invalid", "10.0.0.1")
+	assert.True(t, errors.Is(err, auth.ErrInvalidPassword))
+	// The successful comparison resets the account counter.
+	require.NoError(t, l.ComparePassword(ctx, a, user, "password", "10.0.0.1"))
+
+	for i := 0; i < 2; i++ {
+		err = l.ComparePassword(ctx, a, user, "invalid", "10.0.0.1")
+		assert.True(t, errors.Is(err, auth.ErrInvalidPassword))
+	}
+	// The locked account could not be authenticated even with a valid password.
+	err = l.ComparePassword(ctx, a, user, "password", "10.0.0.1")
+	assert.True(t, errors.Is(err, auth.ErrAccountLocked))
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, l.ComparePassword(ctx, a, user, "password", "10.0.0.1"))
+}
+
+func TestNew(t *testing.T) {
+	options := []Option{WithMaxAttempts(0), WithIPMaxAttempts(-1), WithDelay(0, time.Second), WithDelay(time.Minute, time.Second), WithWindow(0),
+		WithStore(struct{ store.Store }{memory.New()})}
+	for _, option := range options {
+		_, err := New(option)
+		assert.True(t, errors.Is(err, auth.ErrInitialization))
+	}
+}
@@ -0,0 +1,83 @@
+package lockout
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/store"
+)
+
+// Options are the lockout options.
+type Options struct {
+	// Store keeps the failed attempts counters. The store needs to implement store.ConditionalSetter. If not set,
+	// the in-memory store is used.
+	Store store.Store
+	// MaxAttempts is the number of the account failed attempts after which the account is locked.
+	MaxAttempts int
+	// IPMaxAttempts is the number of the client IP failed attempts after which the IP is locked.
+	IPMaxAttempts int
+	// BaseDelay is the duration of the first lockout. Each following failed attempt doubles the lockout duration.
+	BaseDelay time.Duration
+	// MaxDelay is the maximum duration of the lockout.
+	MaxDelay time.Duration
+	// Window is the duration after the last failed attempt when the counter is reset.
+	Window time.Duration
+	// TimeFunc is the time function used to compute the lockout durations.
+	TimeFunc func() time.Time
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		MaxAttempts:   5,
+		IPMaxAttempts: 20,
+		BaseDelay:     30 * time.Second,
+		MaxDelay:      15 * time.Minute,
+		Window:        15 * time.Minute,
+		TimeFunc:      time.Now,
+	}
+}
+
+// Option is a function that sets the lockout options.
+type Option func(o *Options)
+
+// WithStore sets the store of the failed attempts counters.
+func WithStore(s store.Store) Option {
+	return func(o *Options) {
+		o.Store = s
+	}
+}
+
+// WithMaxAttempts sets the number of the account failed attempts after which the account is locked.
+func WithMaxAttempts(attempts int) Option {
+	return func(o *Options) {
+		o.MaxAttempts = attempts
+	}
+}
+
+// WithIPMaxAttempts sets the number of the client IP failed attempts after which the IP is locked.
+func WithIPMaxAttempts(attempts int) Option {
+	return func(o *Options) {
+		o.IPMaxAttempts = attempts
+	}
+}
+
+// WithDelay sets the duration of the first lockout and the maximum lockout duration.
+func WithDelay(base, max time.Duration) Option {
+	return func(o *Options) {
+		o.BaseDelay = base
+		o.MaxDelay = max
+	}
+}
+
+// WithWindow sets the duration after the last failed attempt when the counter is reset.
+func WithWindow(window time.Duration) Option {
+	return func(o *Options) {
+		o.Window = window
+	}
+}
+
+// WithTimeFunc sets the time function of the lockout.
+func WithTimeFunc(tf func() time.Time) Option {
+	return func(o *Options) {
+		o.TimeFunc = tf
+	}
+}
@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"context"
+)
+
+// SecondFactor is the interface used to verify the second factor of the multi-factor authentication.
+type SecondFactor interface {
+	// IsEnrolled checks if the 'account' has the second factor enrolled and confirmed.
+	IsEnrolled(ctx context.Context, account Account) (bool, error)
+	// Verify checks the 'account' one-time password 'code'.
+	Verify(ctx context.Context, account Account, code string) error
+	// Recover checks and redeems the 'account' one-time recovery 'code'.
+	Recover(ctx context.Context, account Account, code string) error
+}
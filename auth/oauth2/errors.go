@@ -0,0 +1,104 @@
+package oauth2
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// The error codes defined by the RFC 6749.
+const (
+	ErrorInvalidRequest       = "invalid_request"
+	ErrorInvalidClient        = "invalid_client"
+	ErrorInvalidGrant         = "invalid_grant"
+	ErrorUnauthorizedClient   = "unauthorized_client"
+	ErrorUnsupportedGrantType = "unsupported_grant_type"
+	ErrorInvalidScope         = "invalid_scope"
+	ErrorServerError          = "server_error"
+)
+
+// Error is the OAuth2 error response.
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	status      int
+}
+
+// Error implements error interface.
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// newError creates the OAuth2 error with given 'code' and 'description'.
+func newError(code, description string) *Error {
+	status := http.StatusBadRequest
+	if code == ErrorInvalidClient {
+		status = http.StatusUnauthorized
+	}
+	return &Error{Code: code, Description: description, status: status}
+}
+
+// writeError writes the 'err' response. The errors other than *Error are classified into their OAuth2 codes.
+func (s *Server) writeError(rw http.ResponseWriter, req *http.Request, err error) {
+	oauthErr, ok := err.(*Error)
+	if !ok {
+		switch {
+		case errors.Is(err, auth.ErrAccountLocked):
+			oauthErr = newError(ErrorInvalidGrant, "Too many failed authentication attempts.")
+			detailed := &errors.DetailedError{}
+			if errors.As(err, &detailed) && detailed.Details != "" {
+				oauthErr.Description = detailed.Details
+			}
+			s.setRetryAfter(rw, req)
+		case errors.Is(err, auth.ErrMFA), errors.Is(err, auth.ErrMFARequired):
+			oauthErr = newError(ErrorInvalidGrant, "The second factor is not valid.")
+			detailed := &errors.DetailedError{}
+			if errors.As(err, &detailed) && detailed.Details != "" {
+				oauthErr.Description = detailed.Details
+			}
+		case errors.Is(err, auth.ErrToken), errors.Is(err, auth.ErrInvalidPassword), errors.Is(err, query.ErrNoResult):
+			oauthErr = newError(ErrorInvalidGrant, "The provided authorization grant is invalid.")
+		default:
+			log.Errorf("OAuth2 request: '%s' failed: %v", req.URL.Path, err)
+			oauthErr = &Error{Code: ErrorServerError, status: http.StatusInternalServerError}
+		}
+	}
+	if oauthErr.status == http.StatusUnauthorized {
+		if _, _, basic := req.BasicAuth(); basic {
+			rw.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+		}
+	}
+	writeJSON(rw, oauthErr.status, oauthErr)
+}
+
+// setRetryAfter sets the 'Retry-After' header of the locked password grant request.
+func (s *Server) setRetryAfter(rw http.ResponseWriter, req *http.Request) {
+	if s.Options.Lockout == nil {
+		return
+	}
+	retryAfter, err := s.Options.Lockout.RetryAfter(req.Context(), req.PostForm.Get("username"), remoteIP(req))
+	if err != nil || retryAfter <= 0 {
+		return
+	}
+	rw.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+}
+
+// writeJSON writes the 'value' JSON response with given 'status'. The responses are not cached.
+func writeJSON(rw http.ResponseWriter, status int, value interface{}) {
+	rw.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+	rw.WriteHeader(status)
+	if err := json.NewEncoder(rw).Encode(value); err != nil {
+		log.Debugf("Writing OAuth2 response failed: %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package oauth2
+
+import (
+	"net/http"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// IntrospectionResponse is the token introspection endpoint response.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  string `json:"aud,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+}
+
+// HandleRevoke handles the token revocation request. The client is authenticated and the 'token' is revoked
+// only if it was issued to that client (RFC 7009 2.1). The invalid tokens and the tokens of the other clients
+// doesn't result in an error and are not revoked.
+func (s *Server) HandleRevoke(rw http.ResponseWriter, req *http.Request) {
+	if err := parseForm(req); err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	client, err := s.authenticateClient(req)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	token := req.PostForm.Get("token")
+	if token == "" {
+		s.writeError(rw, req, newError(ErrorInvalidRequest, "The token is required."))
+		return
+	}
+	if err = s.revokeClientToken(req, client, token); err != nil && !errors.Is(err, auth.ErrToken) {
+		s.writeError(rw, req, err)
+		return
+	}
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(http.StatusOK)
+}
+
+// revokeClientToken revokes the 'token' if it was issued to the 'client'.
+func (s *Server) revokeClientToken(req *http.Request, client *Client, token string) error {
+	ctx := req.Context()
+	claims, err := s.tokener.InspectToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if clientClaims, ok := claims.(auth.ClientClaims); !ok || clientClaims.ClientID() != client.ID {
+		return nil
+	}
+	return s.tokener.RevokeToken(ctx, token)
+}
+
+// HandleIntrospect handles the token introspection request. Only the confidential clients are allowed to
+// introspect the tokens. The invalid, expired or revoked tokens are not active.
+func (s *Server) HandleIntrospect(rw http.ResponseWriter, req *http.Request) {
+	if err := parseForm(req); err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	client, err := s.authenticateClient(req)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	if client.IsPublic() {
+		s.writeError(rw, req, newError(ErrorUnauthorizedClient, "The public client cannot introspect the tokens."))
+		return
+	}
+	token := req.PostForm.Get("token")
+	if token == "" {
+		s.writeError(rw, req, newError(ErrorInvalidRequest, "The token is required."))
+		return
+	}
+	claims, err := s.tokener.InspectToken(req.Context(), token)
+	if err != nil {
+		if errors.Is(err, auth.ErrToken) {
+			writeJSON(rw, http.StatusOK, &IntrospectionResponse{})
+			return
+		}
+		s.writeError(rw, req, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, s.introspectionResponse(claims))
+}
+
+// introspectionResponse creates the active token introspection response for the 'claims'.
+func (s *Server) introspectionResponse(claims auth.Claims) *IntrospectionResponse {
+	response := &IntrospectionResponse{Active: true, Subject: claims.Subject()}
+	if accessClaims, ok := claims.(auth.AccessClaims); ok {
+		response.TokenType = "access_token"
+		if account := accessClaims.GetAccount(); account != nil {
+			response.Username = account.GetUsername()
+		}
+	} else {
+		response.TokenType = "refresh_token"
+	}
+	if expiresIn := claims.ExpiresIn(); expiresIn >= 0 {
+		response.ExpiresAt = s.Options.TimeFunc().Unix() + expiresIn
+	}
+	if scoper, ok := claims.(auth.Scoper); ok {
+		response.Scope = scoper.Scope()
+	}
+	if notBeforer, ok := claims.(auth.NotBeforer); ok {
+		response.NotBefore = notBeforer.NotBefore()
+	}
+	if audiencer, ok := claims.(auth.Audiencer); ok {
+		response.Audience = audiencer.Audience()
+	}
+	if issuer, ok := claims.(auth.Issuer); ok {
+		response.Issuer = issuer.Issuer()
+	}
+	return response
+}
@@ -0,0 +1,78 @@
+package oauth2
+
+import (
+	"strings"
+
+	"github.com/neuronlabs/neuron/auth"
+)
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=Client .
+
+// Compile time check for the auth.Account interface.
+var _ auth.Account = &Client{}
+
+// Client is the model of the OAuth2 client identified by the 'client_id'. The confidential clients are
+// authenticated with the secret, which hash is stored in the SecretHash. The public clients have no secret.
+// The Client implements auth.Account interface, so that its secret could be hashed and compared by the
+// auth.Authenticator.
+type Client struct {
+	ID         string
+	SecretHash []byte
+	// GrantTypes are the space separated grant types allowed for the client. If empty, all the grants are allowed.
+	GrantTypes string
+	// Scope are the space separated scopes allowed for the client. If empty, any scope is allowed.
+	Scope string
+	// AccountID is the primary key string value of the service account, which receives the tokens issued with
+	// the client credentials grant.
+	AccountID string
+}
+
+// IsPublic checks if the client has no secret.
+func (c *Client) IsPublic() bool {
+	return len(c.SecretHash) == 0
+}
+
+// AllowsGrant checks if the client is allowed to use the 'grantType'.
+func (c *Client) AllowsGrant(grantType string) bool {
+	return c.GrantTypes == "" || containsScope(c.GrantTypes, grantType)
+}
+
+// GetUsername implements auth.Account interface. The client username is its identifier.
+func (c *Client) GetUsername() string {
+	return c.ID
+}
+
+// SetUsername implements auth.Account interface.
+func (c *Client) SetUsername(username string) {
+	c.ID = username
+}
+
+// GetPasswordHash implements auth.Account interface. The client password hash is its secret hash.
+func (c *Client) GetPasswordHash() []byte {
+	return c.SecretHash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (c *Client) SetPasswordHash(hash []byte) {
+	c.SecretHash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (c *Client) UsernameField() string {
+	return "ID"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (c *Client) PasswordHashField() string {
+	return "SecretHash"
+}
+
+// containsScope checks if the space separated 'scopes' contains the 'scope'.
+func containsScope(scopes, scope string) bool {
+	for _, s := range strings.Fields(scopes) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+// Package oauth2 implements the OAuth2 token server on top of the auth.Tokener and auth.Authenticator. The token
+// endpoint supports the 'password', 'refresh_token' and 'client_credentials' grants (RFC 6749). The server also
+// provides the token revocation (RFC 7009) and introspection (RFC 7662) endpoints. The clients are stored in the
+// database using the Client model. With the auth.SecondFactor option the password grant requires the enrolled
+// accounts to provide the 'otp' or 'recovery_code' parameter and issues the tokens with the satisfied multi-factor
+// authentication.
+package oauth2
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// Compile time check for the http.Handler interface.
+var _ http.Handler = &Server{}
+
+// Server is the OAuth2 token server. It serves the token, revocation and introspection endpoints under the options
+// path prefix.
+type Server struct {
+	Options *Options
+
+	db            database.DB
+	tokener       auth.Tokener
+	authenticator auth.Authenticator
+	clients       *mapping.ModelStruct
+	accounts      *mapping.ModelStruct
+	// dummy is the account with the random password hash compared for the unknown usernames, so that they couldn't
+	// be distinguished by the response time.
+	dummy auth.Account
+	mux   *http.ServeMux
+}
+
+// New creates new OAuth2 server for the 'db', 'tokener' and 'authenticator'. The Client model is registered in
+// the database model map if it is not yet registered. The account model option is required.
+func New(db database.DB, tokener auth.Tokener, authenticator auth.Authenticator, options ...Option) (*Server, error) {
+	o := &Options{PathPrefix: DefaultPathPrefix, TimeFunc: time.Now}
+	for _, option := range options {
+		option(o)
+	}
+	if o.AccountModel == nil {
+		return nil, errors.WrapDet(auth.ErrAccountModelNotDefined, "no account model defined for the OAuth2 server")
+	}
+	if tokener == nil || authenticator == nil {
+		return nil, errors.WrapDet(auth.ErrInitialization, "the OAuth2 server requires the tokener and the authenticator")
+	}
+	s := &Server{Options: o, db: db, tokener: tokener, authenticator: authenticator}
+	var err error
+	if s.clients, err = db.ModelMap().ModelStruct(&Client{}); err != nil {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "registering oauth2 client model failed: %v", err)
+	}
+	if s.accounts, err = db.ModelMap().ModelStruct(o.AccountModel); err != nil {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "getting account model: '%T' failed: %v", o.AccountModel, err)
+	}
+	if s.dummy, err = s.dummyAccount(); err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(o.PathPrefix, "/")
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc(prefix+"/token", s.HandleToken)
+	s.mux.HandleFunc(prefix+"/revoke", s.HandleRevoke)
+	s.mux.HandleFunc(prefix+"/introspect", s.HandleIntrospect)
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler interface.
+func (s *Server) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	s.mux.ServeHTTP(rw, req)
+}
+
+// parseForm checks if the request is a POST with url encoded form and parses it.
+func parseForm(req *http.Request) error {
+	if req.Method != http.MethodPost {
+		return newError(ErrorInvalidRequest, "The request method must be POST.")
+	}
+	if err := req.ParseForm(); err != nil {
+		return newError(ErrorInvalidRequest, "The request form is malformed.")
+	}
+	return nil
+}
+
+// authenticateClient authenticates the request client using the HTTP Basic authentication or the 'client_id' and
+// 'client_secret' form parameters. The public clients are identified only with the 'client_id'.
+func (s *Server) authenticateClient(req *http.Request) (*Client, error) {
+	clientID, secret, basic := req.BasicAuth()
+	if basic {
+		var err error
+		if clientID, err = url.QueryUnescape(clientID); err != nil {
+			return nil, newError(ErrorInvalidClient, "The client authentication is malformed.")
+		}
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			return nil, newError(ErrorInvalidClient, "The client authentication is malformed.")
+		}
+	} else {
+		clientID, secret = req.PostForm.Get("client_id"), req.PostForm.Get("client_secret")
+	}
+	if clientID == "" {
+		return nil, newError(ErrorInvalidClient, "No client authentication provided.")
+	}
+	ctx := req.Context()
+	model, err := s.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), s.clients).Filter(filter.New(s.clients.Primary(), filter.OpEqual, clientID)).Get()
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, newError(ErrorInvalidClient, "The client authentication failed.")
+		}
+		return nil, err
+	}
+	client := model.(*Client)
+	if client.IsPublic() {
+		if secret != "" {
+			return nil, newError(ErrorInvalidClient, "The client authentication failed.")
+		}
+		return client, nil
+	}
+	if err = s.comparePassword(ctx, client, secret); err != nil {
+		if errors.Is(err, auth.ErrInvalidPassword) {
+			return nil, newError(ErrorInvalidClient, "The client authentication failed.")
+		}
+		return nil, err
+	}
+	return client, nil
+}
+
+// comparePassword compares the 'account' password. If the authenticator is an auth.Rehasher, the weaker password
+// hashes are upgraded.
+func (s *Server) comparePassword(ctx context.Context, account auth.Account, password string) error {
+	if rehasher, ok := s.authenticator.(auth.Rehasher); ok {
+		return rehasher.ComparePasswordAndRehash(ctx, account, password)
+	}
+	return s.authenticator.ComparePassword(account, password)
+}
+
+// dummyAccount creates the account with the random password hashed by the server authenticator.
+func (s *Server) dummyAccount() (auth.Account, error) {
+	secret, err := auth.GenerateSalt(16)
+	if err != nil {
+		return nil, err
+	}
+	account := mapping.NewModel(s.accounts).(auth.Account)
+	if err = s.authenticator.HashAndSetPassword(account, auth.NewPassword(hex.EncodeToString(secret))); err != nil {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "hashing dummy account password failed: %v", err)
+	}
+	return account, nil
+}
+
+// getAccount gets the account with the 'accountID' primary key string value.
+func (s *Server) getAccount(ctx context.Context, accountID string) (auth.Account, error) {
+	account := mapping.NewModel(s.accounts).(auth.Account)
+	if err := account.SetPrimaryKeyStringValue(accountID); err != nil {
+		return nil, newError(ErrorInvalidGrant, "The account is not valid.")
+	}
+	model, err := s.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), s.accounts).Filter(filter.New(s.accounts.Primary(), filter.OpEqual, account.GetPrimaryKeyValue())).Get()
+	if err != nil {
+		return nil, err
+	}
+	return model.(auth.Account), nil
+}
+
+// remoteIP gets the request remote address IP.
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// checkScope checks if the space separated 'scope' is allowed for the 'client'. If the scope is empty the client
+// scope is used.
+func checkScope(client *Client, scope string) (string, error) {
+	if scope == "" {
+		return client.Scope, nil
+	}
+	if client.Scope == "" {
+		return scope, nil
+	}
+	if err := checkScopeSubset(client.Scope, scope); err != nil {
+		return "", err
+	}
+	return scope, nil
+}
+
+// checkScopeSubset checks if all the 'requested' space separated scopes are within 'allowed' scopes.
+func checkScopeSubset(allowed, requested string) error {
+	for _, scope := range strings.Fields(requested) {
+		if !containsScope(allowed, scope) {
+			return newError(ErrorInvalidScope, "The requested scope: '"+scope+"' is not allowed.")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,382 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/auth/authenticator"
+	"github.com/neuronlabs/neuron/auth/jwt"
+	"github.com/neuronlabs/neuron/auth/lockout"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+)
+
+func testServer(t *testing.T) *Server {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(&testmodels.User{}))
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, db.Dial(ctx))
+
+	a, err := authenticator.New(auth.AuthenticatorMethod(auth.SHA256))
+	require.NoError(t, err)
+	tokener, err := jwt.New(auth.TokenerAccount(&testmodels.User{}), auth.TokenerSecret([]byte("01234567890123456789012345678901")))
+	require.NoError(t, err)
+	s, err := New(db, tokener, a, WithAccountModel(&testmodels.User{}))
+	require.NoError(t, err)
+
+	user := &testmodels.User{Username: "user"}
+	require.NoError(t, a.HashAndSetPassword(user, auth.NewPassword("password")))
+	service := &testmodels.User{Username: "service"}
+	require.NoError(t, db.Insert(ctx, s.accounts, user, service))
+
+	confidential := &Client{ID: "confidential", Scope: "read write", AccountID: "2"}
+	require.NoError(t, a.HashAndSetPassword(confidential, auth.NewPassword("secret")))
+	public := &Client{ID: "public", GrantTypes: "password refresh_token client_credentials"}
+	require.NoError(t, db.Insert(ctx, s.clients, confidential, public))
+	return s
+}
+
+func doRequest(t *testing.T, s *Server, path string, form url.Values, basic ...string) (int, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if len(basic) == 2 {
+		req.SetBasicAuth(basic[0], basic[1])
+	}
+	rw := httptest.NewRecorder()
+	s.ServeHTTP(rw, req)
+
+	body := map[string]interface{}{}
+	if rw.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body), rw.Body.String())
+	}
+	return rw.Code, body
+}
+
+func TestPasswordGrant(t *testing.T) {
+	s := testServer(t)
+
+	status, body := doRequest(t, s, "/oauth2/token", url.Values{
+		"grant_type": {"password"}, "username": {"user"}, "password": {"password"}, "scope": {"read"},
+	}, "confidential", "secret")
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, jwt.TokenType, body["token_type"])
+	assert.Equal(t, "read", body["scope"])
+	assert.NotEmpty(t, body["access_token"])
+	refreshToken, ok := body["refresh_token"].(string)
+	require.True(t, ok)
+
+	t.Run("Refresh", func(t *testing.T) {
+		status, body := doRequest(t, s, "/oauth2/token", url.Values{
+			"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}, "client_id": {"confidential"}, "client_secret": {"secret"},
+		})
+		require.Equal(t, http.StatusOK, status, body)
+		assert.Equal(t, "read", body["scope"])
+		assert.Equal(t, refreshToken, body["refresh_token"])
+
+		// The refreshed scope cannot exceed the granted one.
+		status, body = doRequest(t, s, "/oauth2/token", url.Values{
+			"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}, "scope": {"read write"},
+		}, "confidential", "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrorInvalidScope, body["error"])
+
+		status, body = doRequest(t, s, "/oauth2/token", url.Values{
+			"grant_type": {"refresh_token"}, "refresh_token": {"invalid"},
+		}, "confidential", "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrorInvalidGrant, body["error"])
+
+		// The refresh token issued to the confidential client cannot be used by the other client.
+		status, body = doRequest(t, s, "/oauth2/token", url.Values{
+			"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}, "client_id": {"public"},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrorInvalidGrant, body["error"])
+		assert.Equal(t, "The refresh token was not issued to the client.", body["error_description"])
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		status, body := doRequest(t, s, "/oauth2/token", url.Values{
+			"grant_type": {"password"}, "username": {"user"}, "password": {"invalid"},
+		}, "confidential", "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrorInvalidGrant, body["error"])
+
+		status, body = doRequest(t, s, "/oauth2/token", url.Values{
+			"grant_type": {"password"}, "username": {"unknown"}, "password": {"password"},
+		}, "confidential", "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrorInvalidGrant, body["error"])
+
+		status, body = doRequest(t, s, "/oauth2/token", url.Values{
+			"grant_type": {"password"}, "username": {"user"}, "password": {"password"}, "scope": {"admin"},
+		}, "confidential", "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrorInvalidScope, body["error"])
+
+		status, body = doRequest(t, s, "/oauth2/token", url.Values{"grant_type": {"implicit"}}, "confidential", "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, ErrorUnsupportedGrantType, body["error"])
+	})
+}
+
+func TestClientAuthentication(t *testing.T) {
+	s := testServer(t)
+	form := url.Values{"grant_type": {"password"}, "username": {"user"}, "password": {"password"}}
+
+	status, body := doRequest(t, s, "/oauth2/token", form, "confidential", "invalid")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, ErrorInvalidClient, body["error"])
+
+	status, body = doRequest(t, s, "/oauth2/token", form, "unknown", "secret")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, ErrorInvalidClient, body["error"])
+
+	status, body = doRequest(t, s, "/oauth2/token", form)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, ErrorInvalidClient, body["error"])
+
+	// The public client is identified only by its id.
+	publicForm := url.Values{"client_id": {"public"}}
+	for key, values := range form {
+		publicForm[key] = values
+	}
+	status, body = doRequest(t, s, "/oauth2/token", publicForm)
+	assert.Equal(t, http.StatusOK, status, body)
+
+	req := httptest.NewRequest(http.MethodGet, "/oauth2/token", nil)
+	rw := httptest.NewRecorder()
+	s.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
+
+func TestClientCredentialsGrant(t *testing.T) {
+	s := testServer(t)
+
+	status, body := doRequest(t, s, "/oauth2/token", url.Values{"grant_type": {"client_credentials"}}, "confidential", "secret")
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, "read write", body["scope"])
+	assert.NotContains(t, body, "refresh_token")
+
+	claims, err := s.tokener.InspectToken(context.Background(), body["access_token"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "2", claims.Subject())
+
+	status, body = doRequest(t, s, "/oauth2/token", url.Values{"grant_type": {"client_credentials"}, "client_id": {"public"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, ErrorUnauthorizedClient, body["error"])
+}
+
+func TestRevokeAndIntrospect(t *testing.T) {
+	s := testServer(t)
+	_, body := doRequest(t, s, "/oauth2/token", url.Values{
+		"grant_type": {"password"}, "username": {"user"}, "password": {"password"}, "scope": {"read"},
+	}, "confidential", "secret")
+	accessToken := body["access_token"].(string)
+
+	status, body := doRequest(t, s, "/oauth2/introspect", url.Values{"token": {accessToken}}, "confidential", "secret")
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, true, body["active"])
+	assert.Equal(t, "user", body["username"])
+	assert.Equal(t, "1", body["sub"])
+	assert.Equal(t, "read", body["scope"])
+	assert.Equal(t, "access_token", body["token_type"])
+	assert.NotZero(t, body["exp"])
+
+	// The public clients cannot introspect the tokens.
+	status, _ = doRequest(t, s, "/oauth2/introspect", url.Values{"token": {accessToken}, "client_id": {"public"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// The token issued to another client is not revoked.
+	status, _ = doRequest(t, s, "/oauth2/revoke", url.Values{"token": {accessToken}, "client_id": {"public"}})
+	require.Equal(t, http.StatusOK, status)
+	_, err := s.tokener.InspectToken(context.Background(), accessToken)
+	require.NoError(t, err)
+
+	status, _ = doRequest(t, s, "/oauth2/revoke", url.Values{"token": {accessToken}}, "confidential", "secret")
+	require.Equal(t, http.StatusOK, status)
+	_, err = s.tokener.InspectToken(context.Background(), accessToken)
+	assert.True(t, errors.Is(err, auth.ErrTokenRevoked))
+
+	status, body = doRequest(t, s, "/oauth2/introspect", url.Values{"token": {accessToken}}, "confidential", "secret")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"active": false}, body)
+
+	// Revoking an invalid token succeeds.
+	status, _ = doRequest(t, s, "/oauth2/revoke", url.Values{"token": {"invalid"}}, "confidential", "secret")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestNew(t *testing.T) {
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()))
+	require.NoError(t, err)
+	_, err = New(db, nil, nil)
+	assert.True(t, errors.Is(err, auth.ErrAccountModelNotDefined))
+	_, err = New(db, nil, nil, WithAccountModel(&testmodels.User{}))
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+}
+
+func TestRefreshTokenMFA(t *testing.T) {
+	s := testServer(t)
+	ctx := context.Background()
+	token, err := s.tokener.Token(ctx, &testmodels.User{ID: 1, Username: "user"}, auth.TokenMFA(true), auth.TokenClientID("confidential"))
+	require.NoError(t, err)
+
+	status, body := doRequest(t, s, "/oauth2/token", url.Values{
+		"grant_type": {"refresh_token"}, "refresh_token": {token.RefreshToken},
+	}, "confidential", "secret")
+	require.Equal(t, http.StatusOK, status, body)
+	claims, err := s.tokener.InspectToken(ctx, body["access_token"].(string))
+	require.NoError(t, err)
+	assert.True(t, claims.(auth.MFAClaims).MFASatisfied())
+}
+
+func TestPasswordGrantLockout(t *testing.T) {
+	s := testServer(t)
+	l, err := lockout.New(lockout.WithMaxAttempts(2))
+	require.NoError(t, err)
+	s.Options.Lockout = l
+
+	password := func(username, password string) (int, map[string]interface{}) {
+		return doRequest(t, s, "/oauth2/token", url.Values{
+			"grant_type": {"password"}, "username": {username}, "password": {password},
+		}, "confidential", "secret")
+	}
+	status, body := password("user", "invalid")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "The username or password is not valid.", body["error_description"])
+	// The successful authentication resets the failed attempts.
+	status, body = password("user", "password")
+	require.Equal(t, http.StatusOK, status, body)
+
+	for i := 0; i < 2; i++ {
+		status, body = password("user", "invalid")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "The username or password is not valid.", body["error_description"])
+	}
+	status, body = password("user", "password")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, ErrorInvalidGrant, body["error"])
+	assert.Equal(t, "Too many failed authentication attempts. Retry after 30 seconds.", body["error_description"])
+
+	// The unknown accounts are locked as well.
+	for i := 0; i < 2; i++ {
+		password("unknown", "password")
+	}
+	_, body = password("unknown", "password")
+	assert.Equal(t, "Too many failed authentication attempts. Retry after 30 seconds.", body["error_description"])
+}
+
+// testSecondFactor maps the enrolled account usernames to their valid one-time password.
+type testSecondFactor map[string]string
+
+func (f testSecondFactor) IsEnrolled(_ context.Context, account auth.Account) (bool, error) {
+	_, ok := f[account.GetUsername()]
+	return ok, nil
+}
+
+func (f testSecondFactor) Verify(_ context.Context, account auth.Account, code string) error {
+	if f[account.GetUsername()] != code {
+		return errors.WrapDet(auth.ErrInvalidOTP, "invalid code").WithDetail("The code is not valid.")
+	}
+	return nil
+}
+
+func (f testSecondFactor) Recover(_ context.Context, account auth.Account, code string) error {
+	if code != "recovery" {
+		return errors.WrapDet(auth.ErrInvalidOTP, "invalid recovery code").WithDetail("The recovery code is not valid.")
+	}
+	return nil
+}
+
+func TestPasswordGrantSecondFactor(t *testing.T) {
+	s := testServer(t)
+	s.Options.SecondFactor = testSecondFactor{"user": "123456"}
+	ctx := context.Background()
+
+	password := func(values url.Values) (int, map[string]interface{}) {
+		values.Set("grant_type", "password")
+		values.Set("username", "user")
+		values.Set("password", "password")
+		return doRequest(t, s, "/oauth2/token", values, "confidential", "secret")
+	}
+	status, body := password(url.Values{})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, ErrorInvalidGrant, body["error"])
+	assert.Equal(t, "The one-time password or recovery code is required.", body["error_description"])
+
+	status, body = password(url.Values{"otp": {"654321"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "The code is not valid.", body["error_description"])
+
+	for _, values := range []url.Values{{"otp": {"123456"}}, {"recovery_code": {"recovery"}}} {
+		status, body = password(values)
+		require.Equal(t, http.StatusOK, status, body)
+		claims, err := s.tokener.InspectToken(ctx, body["access_token"].(string))
+		require.NoError(t, err)
+		assert.True(t, claims.(auth.MFAClaims).MFASatisfied())
+	}
+
+	t.Run("NotEnrolled", func(t *testing.T) {
+		s.Options.SecondFactor = testSecondFactor{}
+		status, body := password(url.Values{})
+		require.Equal(t, http.StatusOK, status, body)
+		claims, err := s.tokener.InspectToken(ctx, body["access_token"].(string))
+		require.NoError(t, err)
+		assert.False(t, claims.(auth.MFAClaims).MFASatisfied())
+	})
+
+	t.Run("Lockout", func(t *testing.T) {
+		s.Options.SecondFactor = testSecondFactor{"user": "123456"}
+		l, err := lockout.New(lockout.WithMaxAttempts(2))
+		require.NoError(t, err)
+		s.Options.Lockout = l
+		for i := 0; i < 2; i++ {
+			password(url.Values{"otp": {"654321"}})
+		}
+		_, body := password(url.Values{"otp": {"123456"}})
+		assert.Equal(t, "Too many failed authentication attempts. Retry after 30 seconds.", body["error_description"])
+	})
+}
+
+// comparingAuthenticator counts the compared passwords.
+type comparingAuthenticator struct {
+	auth.Authenticator
+	compared int
+}
+
+func (a *comparingAuthenticator) ComparePassword(account auth.Account, password string) error {
+	a.compared++
+	return a.Authenticator.ComparePassword(account, password)
+}
+
+func TestPasswordGrantUnknownUsername(t *testing.T) {
+	s := testServer(t)
+	a := &comparingAuthenticator{Authenticator: s.authenticator}
+	s.authenticator = a
+
+	status, body := doRequest(t, s, "/oauth2/token", url.Values{
+		"grant_type": {"password"}, "username": {"unknown"}, "password": {"password"},
+	}, "public", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "The username or password is not valid.", body["error_description"])
+	// The unknown username password is compared with the dummy account hash.
+	assert.Equal(t, 1, a.compared)
+	assert.NotEmpty(t, s.dummy.GetPasswordHash())
+}
@@ -0,0 +1,66 @@
+package oauth2
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/auth/lockout"
+)
+
+// DefaultPathPrefix is the default path prefix of the OAuth2 endpoints.
+const DefaultPathPrefix = "/oauth2"
+
+// Options are the OAuth2 server options.
+type Options struct {
+	// AccountModel is the account model that receives the tokens.
+	AccountModel auth.Account
+	// PathPrefix is the path prefix of the token, revocation and introspection endpoints.
+	PathPrefix string
+	// TimeFunc is the time function used to compute the introspected token expiration time.
+	TimeFunc func() time.Time
+	// Lockout is the optional brute-force protection of the password grant. The failed attempts are counted per
+	// account username and the request remote IP.
+	Lockout *lockout.Lockout
+	// SecondFactor is the optional second factor of the password grant. The accounts with the enrolled second
+	// factor need to provide the 'otp' or 'recovery_code' parameter and receive the tokens with satisfied
+	// multi-factor authentication.
+	SecondFactor auth.SecondFactor
+}
+
+// Option is a function that sets the OAuth2 server options.
+type Option func(o *Options)
+
+// WithAccountModel sets the account model that receives the tokens.
+func WithAccountModel(model auth.Account) Option {
+	return func(o *Options) {
+		o.AccountModel = model
+	}
+}
+
+// WithPathPrefix sets the path prefix of the endpoints.
+func WithPathPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.PathPrefix = prefix
+	}
+}
+
+// WithLockout sets the brute-force protection of the password grant.
+func WithLockout(l *lockout.Lockout) Option {
+	return func(o *Options) {
+		o.Lockout = l
+	}
+}
+
+// WithSecondFactor sets the second factor of the password grant.
+func WithSecondFactor(secondFactor auth.SecondFactor) Option {
+	return func(o *Options) {
+		o.SecondFactor = secondFactor
+	}
+}
+
+// WithTimeFunc sets the time function of the server.
+func WithTimeFunc(tf func() time.Time) Option {
+	return func(o *Options) {
+		o.TimeFunc = tf
+	}
+}
@@ -0,0 +1,236 @@
+package oauth2
+
+import (
+	"net/http"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// The grant types supported by the token endpoint.
+const (
+	GrantPassword          = "password"
+	GrantRefreshToken      = "refresh_token"
+	GrantClientCredentials = "client_credentials"
+)
+
+// TokenResponse is the successful token endpoint response.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// HandleToken handles the token endpoint request. The client is authenticated and the token is issued for the
+// request 'grant_type'.
+func (s *Server) HandleToken(rw http.ResponseWriter, req *http.Request) {
+	if err := parseForm(req); err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	client, err := s.authenticateClient(req)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	grantType := req.PostForm.Get("grant_type")
+	var response *TokenResponse
+	switch grantType {
+	case GrantPassword, GrantRefreshToken, GrantClientCredentials:
+		if !client.AllowsGrant(grantType) {
+			s.writeError(rw, req, newError(ErrorUnauthorizedClient, "The client is not allowed to use the grant type: '"+grantType+"'."))
+			return
+		}
+	case "":
+		s.writeError(rw, req, newError(ErrorInvalidRequest, "No grant type provided."))
+		return
+	default:
+		s.writeError(rw, req, newError(ErrorUnsupportedGrantType, "The grant type: '"+grantType+"' is not supported."))
+		return
+	}
+	switch grantType {
+	case GrantPassword:
+		response, err = s.passwordGrant(req, client)
+	case GrantRefreshToken:
+		response, err = s.refreshTokenGrant(req, client)
+	case GrantClientCredentials:
+		response, err = s.clientCredentialsGrant(req, client)
+	}
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, response)
+}
+
+// passwordGrant issues the token for the account authenticated with the 'username' and 'password'. If the lockout
+// is defined, the failed attempts are registered and the locked accounts are not authenticated.
+func (s *Server) passwordGrant(req *http.Request, client *Client) (*TokenResponse, error) {
+	username, password := req.PostForm.Get("username"), req.PostForm.Get("password")
+	if username == "" || password == "" {
+		return nil, newError(ErrorInvalidRequest, "The username and password are required.")
+	}
+	scope, err := checkScope(client, req.PostForm.Get("scope"))
+	if err != nil {
+		return nil, err
+	}
+	ctx := req.Context()
+	ip := remoteIP(req)
+	if s.Options.Lockout != nil {
+		if err = s.Options.Lockout.Check(ctx, username, ip); err != nil {
+			return nil, err
+		}
+	}
+	model, err := s.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), s.accounts).Where(s.Options.AccountModel.UsernameField()+" = ?", username).Get()
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			// Compare the dummy account password, so that the unknown username takes as long as the invalid password.
+			_ = s.authenticator.ComparePassword(s.dummy, password)
+			return nil, s.failedAttempt(req, username, ip)
+		}
+		return nil, err
+	}
+	account := model.(auth.Account)
+	if err = s.comparePassword(ctx, account, password); err != nil {
+		if errors.Is(err, auth.ErrInvalidPassword) {
+			return nil, s.failedAttempt(req, username, ip)
+		}
+		return nil, err
+	}
+	mfa, err := s.verifySecondFactor(req, account)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidOTP) && s.Options.Lockout != nil {
+			if lockErr := s.Options.Lockout.Fail(ctx, username, ip); lockErr != nil && !errors.Is(lockErr, auth.ErrAccountLocked) {
+				return nil, lockErr
+			}
+		}
+		return nil, err
+	}
+	if s.Options.Lockout != nil {
+		if err = s.Options.Lockout.Reset(ctx, username); err != nil {
+			return nil, err
+		}
+	}
+	return s.token(req, account, scope, true, auth.TokenScope(scope), auth.TokenClientID(client.ID), auth.TokenMFA(mfa))
+}
+
+// verifySecondFactor checks the 'otp' or 'recovery_code' parameter of the 'account' with the enrolled second factor.
+// It returns true if the multi-factor authentication is satisfied.
+func (s *Server) verifySecondFactor(req *http.Request, account auth.Account) (bool, error) {
+	if s.Options.SecondFactor == nil {
+		return false, nil
+	}
+	ctx := req.Context()
+	enrolled, err := s.Options.SecondFactor.IsEnrolled(ctx, account)
+	if err != nil || !enrolled {
+		return false, err
+	}
+	switch {
+	case req.PostForm.Get("otp") != "":
+		err = s.Options.SecondFactor.Verify(ctx, account, req.PostForm.Get("otp"))
+	case req.PostForm.Get("recovery_code") != "":
+		err = s.Options.SecondFactor.Recover(ctx, account, req.PostForm.Get("recovery_code"))
+	default:
+		err = errors.WrapDet(auth.ErrMFARequired, "second factor code is required").
+			WithDetail("The one-time password or recovery code is required.")
+	}
+	return err == nil, err
+}
+
+// failedAttempt registers the failed password grant attempt in the lockout and returns the invalid grant error.
+func (s *Server) failedAttempt(req *http.Request, username, ip string) error {
+	if s.Options.Lockout != nil {
+		if err := s.Options.Lockout.Fail(req.Context(), username, ip); err != nil && !errors.Is(err, auth.ErrAccountLocked) {
+			return err
+		}
+	}
+	return newError(ErrorInvalidGrant, "The username or password is not valid.")
+}
+
+// refreshTokenGrant issues new access token for the 'refresh_token'. The refresh token must be issued to the
+// authenticated client and the requested scope cannot exceed the scope granted with the refresh token.
+func (s *Server) refreshTokenGrant(req *http.Request, client *Client) (*TokenResponse, error) {
+	refreshToken := req.PostForm.Get("refresh_token")
+	if refreshToken == "" {
+		return nil, newError(ErrorInvalidRequest, "The refresh token is required.")
+	}
+	ctx := req.Context()
+	claims, err := s.tokener.InspectToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := claims.(auth.AccessClaims); ok {
+		return nil, newError(ErrorInvalidGrant, "The provided token is not a refresh token.")
+	}
+	if clientClaims, ok := claims.(auth.ClientClaims); !ok || clientClaims.ClientID() != client.ID {
+		return nil, newError(ErrorInvalidGrant, "The refresh token was not issued to the client.")
+	}
+	var granted string
+	if scoper, ok := claims.(auth.Scoper); ok {
+		granted = scoper.Scope()
+	}
+	scope := req.PostForm.Get("scope")
+	switch {
+	case scope == "":
+		scope = granted
+	case granted != "":
+		if err = checkScopeSubset(granted, scope); err != nil {
+			return nil, err
+		}
+	}
+	if scope, err = checkScope(client, scope); err != nil {
+		return nil, err
+	}
+	account, err := s.getAccount(ctx, claims.Subject())
+	if err != nil {
+		return nil, err
+	}
+	options := []auth.TokenOption{auth.TokenScope(scope), auth.TokenRefreshToken(refreshToken), auth.TokenClientID(client.ID)}
+	// The refreshed access token keeps the multi-factor authentication state of the refresh token.
+	if mfaClaims, ok := claims.(auth.MFAClaims); ok {
+		options = append(options, auth.TokenMFA(mfaClaims.MFASatisfied()))
+	}
+	return s.token(req, account, scope, true, options...)
+}
+
+// clientCredentialsGrant issues the token for the confidential client service account. The refresh token is not
+// included in the response.
+func (s *Server) clientCredentialsGrant(req *http.Request, client *Client) (*TokenResponse, error) {
+	if client.IsPublic() {
+		return nil, newError(ErrorUnauthorizedClient, "The public client cannot use the client credentials grant.")
+	}
+	if client.AccountID == "" {
+		return nil, newError(ErrorUnauthorizedClient, "The client has no service account.")
+	}
+	scope, err := checkScope(client, req.PostForm.Get("scope"))
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.getAccount(req.Context(), client.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return s.token(req, account, scope, false, auth.TokenScope(scope), auth.TokenClientID(client.ID))
+}
+
+// token creates the token response for the 'account' with granted 'scope'.
+func (s *Server) token(req *http.Request, account auth.Account, scope string, withRefresh bool, options ...auth.TokenOption) (*TokenResponse, error) {
+	token, err := s.tokener.Token(req.Context(), account, options...)
+	if err != nil {
+		return nil, err
+	}
+	response := &TokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		ExpiresIn:   token.ExpiresIn,
+		Scope:       scope,
+	}
+	if withRefresh {
+		response.RefreshToken = token.RefreshToken
+	}
+	return response, nil
+}
@@ -1,11 +1,11 @@
 package auth
 
 import (
-	"bytes"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/subtle"
 	"hash"
 	"strings"
 	"unicode"
@@ -221,7 +221,7 @@ func equalPassword(h hash.Hash, password string, hashedPassword, salt []byte) (b
 	if err != nil {
 		return false, err
 	}
-	return bytes.Equal(thisHashed, hashedPassword), nil
+	return subtle.ConstantTimeCompare(thisHashed, hashedPassword) == 1, nil
 }
 
 // getRandomBytes will generate random bytes.  This is for internal
@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -9,21 +11,132 @@ import (
 	"github.com/neuronlabs/neuron/errors"
 )
 
-// ParsePemRsaPrivateKey parses 'pem' encoded 'rsa.PrivateKey'.
+// ParsePemRsaPrivateKey parses 'pem' encoded 'rsa.PrivateKey'. The key might be encoded in PKCS #1 or PKCS #8 form.
 func ParsePemRsaPrivateKey(pemPrivateKey []byte) (*rsa.PrivateKey, error) {
-	block, _ := pem.Decode(pemPrivateKey)
+	key, err := parsePemPrivateKey(pemPrivateKey, ErrInvalidRSAKey)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.WrapDetf(ErrInvalidRSAKey, "PEM encoded key: '%T' is not a RSA private key", key)
+	}
+	return rsaKey, nil
+}
+
+// ParsePemECDSAPrivateKey parses 'pem' encoded 'ecdsa.PrivateKey'. The key might be encoded in SEC 1 or PKCS #8 form.
+func ParsePemECDSAPrivateKey(key []byte) (*ecdsa.PrivateKey, error) {
+	privateKey, err := parsePemPrivateKey(key, ErrInvalidECDSAKey)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.WrapDetf(ErrInvalidECDSAKey, "PEM encoded key: '%T' is not an ECDSA private key", privateKey)
+	}
+	return ecdsaKey, nil
+}
+
+// ParsePemEd25519PrivateKey parses 'pem' encoded PKCS #8 'ed25519.PrivateKey'.
+func ParsePemEd25519PrivateKey(key []byte) (ed25519.PrivateKey, error) {
+	privateKey, err := parsePemPrivateKey(key, ErrInvalidEd25519Key)
+	if err != nil {
+		return nil, err
+	}
+	ed25519Key, ok := privateKey.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.WrapDetf(ErrInvalidEd25519Key, "PEM encoded key: '%T' is not an Ed25519 private key", privateKey)
+	}
+	return ed25519Key, nil
+}
+
+// ParsePemPrivateKey parses 'pem' encoded private key. The key form is detected automatically and might be PKCS #1,
+// SEC 1 or PKCS #8. The result is one of *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey.
+func ParsePemPrivateKey(key []byte) (crypto.PrivateKey, error) {
+	return parsePemPrivateKey(key, ErrInvalidKey)
+}
+
+// ParsePemPublicKey parses 'pem' encoded public key. The key might be a PKIX or PKCS #1 public key, or the X.509
+// certificate. The result is one of *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey.
+func ParsePemPublicKey(key []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(key)
 	if block == nil {
-		return nil, errors.Wrap(ErrInvalidRSAKey, "failed to parse PEM block containing the key")
+		return nil, errors.Wrap(ErrInvalidKey, "failed to parse PEM block containing the key")
+	}
+	var (
+		publicKey crypto.PublicKey
+		err       error
+	)
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var certificate *x509.Certificate
+		if certificate, err = x509.ParseCertificate(block.Bytes); err == nil {
+			publicKey = certificate.PublicKey
+		}
+	default:
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, errors.WrapDetf(ErrInvalidKey, "parsing PEM encoded public key failed: %v", err)
 	}
+	switch publicKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, errors.WrapDetf(ErrInvalidKey, "unsupported public key type: '%T'", publicKey)
+	}
+}
 
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
+// ParsePemKey parses 'pem' encoded private key, public key or X.509 certificate. The private keys results in
+// one of the ParsePemPrivateKey results, whereas the public keys and certificates in the ParsePemPublicKey results.
+func ParsePemKey(key []byte) (interface{}, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.Wrap(ErrInvalidKey, "failed to parse PEM block containing the key")
+	}
+	switch block.Type {
+	case "PUBLIC KEY", "RSA PUBLIC KEY", "CERTIFICATE":
+		return ParsePemPublicKey(key)
+	}
+	return ParsePemPrivateKey(key)
 }
 
-// ParsePemECDSAPrivateKey parses 'pem' encoded 'ecdsa.PrivateKey'
-func ParsePemECDSAPrivateKey(key []byte) (*ecdsa.PrivateKey, error) {
+// parsePemPrivateKey parses the 'pem' encoded private key. The PEM block type is used to determine the key form,
+// if it doesn't match any, all the forms are checked. The errors are wrapped with the 'class'.
+func parsePemPrivateKey(key []byte, class error) (crypto.PrivateKey, error) {
 	block, _ := pem.Decode(key)
 	if block == nil {
-		return nil, errors.Wrap(ErrInvalidECDSAKey, "failed to parse PEM block containing the key")
+		return nil, errors.Wrap(class, "failed to parse PEM block containing the key")
+	}
+	var (
+		privateKey crypto.PrivateKey
+		err        error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		if privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			break
+		}
+		if privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			break
+		}
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, errors.WrapDetf(class, "parsing PEM encoded private key failed: %v", err)
+	}
+	switch privateKey.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return privateKey, nil
+	default:
+		return nil, errors.WrapDetf(class, "unsupported private key type: '%T'", privateKey)
 	}
-	return x509.ParseECPrivateKey(block.Bytes)
 }
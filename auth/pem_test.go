@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+)
+
+func encodePem(blockType string, data []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data})
+}
+
+func TestParsePemPrivateKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pkcs8 := func(key interface{}) []byte {
+		data, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		return encodePem("PRIVATE KEY", data)
+	}
+	ecData, err := x509.MarshalECPrivateKey(ecdsaKey)
+	require.NoError(t, err)
+
+	for _, data := range [][]byte{encodePem("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)), pkcs8(rsaKey)} {
+		parsed, err := ParsePemRsaPrivateKey(data)
+		require.NoError(t, err)
+		assert.Equal(t, rsaKey.D, parsed.D)
+	}
+	for _, data := range [][]byte{encodePem("EC PRIVATE KEY", ecData), pkcs8(ecdsaKey)} {
+		parsed, err := ParsePemECDSAPrivateKey(data)
+		require.NoError(t, err)
+		assert.Equal(t, ecdsaKey.D, parsed.D)
+	}
+	parsed, err := ParsePemEd25519PrivateKey(pkcs8(ed25519Key))
+	require.NoError(t, err)
+	assert.Equal(t, ed25519Key, parsed)
+
+	// The key form is detected automatically.
+	key, err := ParsePemPrivateKey(encodePem("EC PRIVATE KEY", ecData))
+	require.NoError(t, err)
+	assert.IsType(t, &ecdsa.PrivateKey{}, key)
+	key, err = ParsePemPrivateKey(encodePem("UNKNOWN", x509.MarshalPKCS1PrivateKey(rsaKey)))
+	require.NoError(t, err)
+	assert.IsType(t, &rsa.PrivateKey{}, key)
+
+	_, err = ParsePemRsaPrivateKey(pkcs8(ecdsaKey))
+	assert.True(t, errors.Is(err, ErrInvalidRSAKey))
+	_, err = ParsePemECDSAPrivateKey([]byte("invalid"))
+	assert.True(t, errors.Is(err, ErrInvalidECDSAKey))
+	_, err = ParsePemEd25519PrivateKey(encodePem("PRIVATE KEY", []byte("invalid")))
+	assert.True(t, errors.Is(err, ErrInvalidEd25519Key))
+}
+
+func TestParsePemPublicKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ed25519Public, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pkixData, err := x509.MarshalPKIXPublicKey(ed25519Public)
+	require.NoError(t, err)
+	publicKey, err := ParsePemPublicKey(encodePem("PUBLIC KEY", pkixData))
+	require.NoError(t, err)
+	assert.Equal(t, ed25519Public, publicKey)
+
+	publicKey, err = ParsePemPublicKey(encodePem("RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)))
+	require.NoError(t, err)
+	assert.Equal(t, &rsaKey.PublicKey, publicKey)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "neuron"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, ed25519Public, ed25519Key)
+	require.NoError(t, err)
+	key, err := ParsePemKey(encodePem("CERTIFICATE", certificate))
+	require.NoError(t, err)
+	assert.Equal(t, ed25519Public, key)
+
+	key, err = ParsePemKey(encodePem("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)))
+	require.NoError(t, err)
+	assert.IsType(t, &rsa.PrivateKey{}, key)
+
+	_, err = ParsePemPublicKey(encodePem("CERTIFICATE", []byte("invalid")))
+	assert.True(t, errors.Is(err, ErrInvalidKey))
+}
@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"time"
+)
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=History .
+
+// History is the model of the account previous password hash. It implements auth.Account and auth.Salter interfaces
+// so that the password could be compared with the authenticator.
+type History struct {
+	ID        int
+	AccountID string `db:";index"`
+	Hash      []byte
+	Salt      []byte
+	CreatedAt time.Time
+}
+
+// GetUsername implements auth.Account interface.
+func (h *History) GetUsername() string {
+	return h.AccountID
+}
+
+// SetUsername implements auth.Account interface.
+func (h *History) SetUsername(username string) {
+	h.AccountID = username
+}
+
+// GetPasswordHash implements auth.Account interface.
+func (h *History) GetPasswordHash() []byte {
+	return h.Hash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (h *History) SetPasswordHash(hash []byte) {
+	h.Hash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (h *History) UsernameField() string {
+	return "AccountID"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (h *History) PasswordHashField() string {
+	return "Hash"
+}
+
+// SaltField implements auth.Salter interface.
+func (h *History) SaltField() string {
+	return "Salt"
+}
+
+// SetSalt implements auth.Salter interface.
+func (h *History) SetSalt(salt []byte) {
+	h.Salt = salt
+}
+
+// GetSalt implements auth.Salter interface.
+func (h *History) GetSalt() []byte {
+	return h.Salt
+}
@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"time"
+)
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=User .
+
+// User is the account model used by the tests.
+type User struct {
+	ID                int
+	Username          string
+	PasswordHash      []byte
+	PasswordSalt      []byte
+	PasswordChangedAt time.Time
+}
+
+// GetUsername implements auth.Account interface.
+func (u *User) GetUsername() string {
+	return u.Username
+}
+
+// SetUsername implements auth.Account interface.
+func (u *User) SetUsername(username string) {
+	u.Username = username
+}
+
+// GetPasswordHash implements auth.Account interface.
+func (u *User) GetPasswordHash() []byte {
+	return u.PasswordHash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (u *User) SetPasswordHash(hash []byte) {
+	u.PasswordHash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (u *User) UsernameField() string {
+	return "Username"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (u *User) PasswordHashField() string {
+	return "PasswordHash"
+}
+
+// SaltField implements auth.Salter interface.
+func (u *User) SaltField() string {
+	return "PasswordSalt"
+}
+
+// SetSalt implements auth.Salter interface.
+func (u *User) SetSalt(salt []byte) {
+	u.PasswordSalt = salt
+}
+
+// GetSalt implements auth.Salter interface.
+func (u *User) GetSalt() []byte {
+	return u.PasswordSalt
+}
+
+// GetPasswordChangedAt implements auth.PasswordAger interface.
+func (u *User) GetPasswordChangedAt() time.Time {
+	return u.PasswordChangedAt
+}
+
+// SetPasswordChangedAt implements auth.PasswordAger interface.
+func (u *User) SetPasswordChangedAt(changedAt time.Time) {
+	u.PasswordChangedAt = changedAt
+}
+
+// PasswordChangedAtField implements auth.PasswordAger interface.
+func (u *User) PasswordChangedAtField() string {
+	return "PasswordChangedAt"
+}
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+)
+
+// Options are the password policy options.
+type Options struct {
+	// MinLength is the minimum number of the password characters.
+	MinLength int
+	// MinScore is the minimum password score computed by the Scorer.
+	MinScore int
+	// Scorer is the function that computes the password score. By default auth.DefaultPasswordScorer is used.
+	Scorer auth.PasswordScorer
+	// Dictionary are the breached or common passwords that are rejected by the policy. The passwords are compared
+	// case insensitive.
+	Dictionary []string
+	// DictionaryFile is the path to the file with the breached or common passwords, one per line. Empty lines and
+	// the lines starting with '#' are ignored.
+	DictionaryFile string
+	// AllowUsername allows the passwords that contain the account username.
+	AllowUsername bool
+	// HistorySize is the number of the recent account passwords, including the current one, that could not be
+	// reused. If it is zero, the password history is not stored.
+	HistorySize int
+	// MaxAge is the maximum age of the account password. If it is zero, the passwords never expire.
+	MaxAge time.Duration
+	// TimeFunc is the time function used to set and check the password age.
+	TimeFunc func() time.Time
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		MinLength: 8,
+		MinScore:  3,
+		Scorer:    auth.DefaultPasswordScorer,
+		TimeFunc:  time.Now,
+	}
+}
+
+// Option is a function that sets the password policy options.
+type Option func(o *Options)
+
+// WithMinLength sets the minimum number of the password characters.
+func WithMinLength(length int) Option {
+	return func(o *Options) {
+		o.MinLength = length
+	}
+}
+
+// WithMinScore sets the minimum password score.
+func WithMinScore(score int) Option {
+	return func(o *Options) {
+		o.MinScore = score
+	}
+}
+
+// WithScorer sets the password scoring function.
+func WithScorer(scorer auth.PasswordScorer) Option {
+	return func(o *Options) {
+		o.Scorer = scorer
+	}
+}
+
+// WithDictionary adds the breached or common 'passwords' rejected by the policy.
+func WithDictionary(passwords ...string) Option {
+	return func(o *Options) {
+		o.Dictionary = append(o.Dictionary, passwords...)
+	}
+}
+
+// WithDictionaryFile sets the path to the file with the breached or common passwords. The file is loaded while
+// creating the policy.
+func WithDictionaryFile(path string) Option {
+	return func(o *Options) {
+		o.DictionaryFile = path
+	}
+}
+
+// WithAllowUsername allows the passwords that contain the account username.
+func WithAllowUsername(allow bool) Option {
+	return func(o *Options) {
+		o.AllowUsername = allow
+	}
+}
+
+// WithHistorySize sets the number of the recent account passwords that could not be reused.
+func WithHistorySize(size int) Option {
+	return func(o *Options) {
+		o.HistorySize = size
+	}
+}
+
+// WithMaxAge sets the maximum age of the account password.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(o *Options) {
+		o.MaxAge = maxAge
+	}
+}
+
+// WithTimeFunc sets the time function of the policy.
+func WithTimeFunc(tf func() time.Time) Option {
+	return func(o *Options) {
+		o.TimeFunc = tf
+	}
+}
@@ -0,0 +1,314 @@
+// Package policy implements the configurable password policy. The policy enforces the minimum password length and
+// score, rejects the passwords found in the breached or common passwords dictionary or containing the account
+// username, prevents the reuse of the recent account passwords and sets the maximum password age. Each violation
+// is returned as a separate errors.DetailedError within the errors.MultiError.
+package policy
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// minUsernameLength is the minimum length of the username checked within the password.
+const minUsernameLength = 3
+
+// Policy validates the passwords and changes the account passwords.
+type Policy struct {
+	Options *Options
+
+	db            database.DB
+	authenticator auth.Authenticator
+	dictionary    map[string]struct{}
+	history       *mapping.ModelStruct
+}
+
+// New creates new password policy for the 'db' and 'authenticator'. The History model is registered in the
+// database model map if the password history is enabled. The dictionary file is loaded while creating the policy.
+func New(db database.DB, authenticator auth.Authenticator, options ...Option) (*Policy, error) {
+	o := defaultOptions()
+	for _, option := range options {
+		option(o)
+	}
+	if db == nil || authenticator == nil {
+		return nil, errors.WrapDet(auth.ErrInitialization, "the password policy requires the database and the authenticator")
+	}
+	if o.MinLength < 0 || o.MinScore < 0 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "invalid password policy minimum length: '%d' or score: '%d'", o.MinLength, o.MinScore)
+	}
+	if o.HistorySize < 0 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "invalid password history size: '%d'", o.HistorySize)
+	}
+	if o.MaxAge < 0 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "invalid password max age: '%s'", o.MaxAge)
+	}
+	if o.Scorer == nil {
+		o.Scorer = auth.DefaultPasswordScorer
+	}
+	p := &Policy{Options: o, db: db, authenticator: authenticator, dictionary: map[string]struct{}{}}
+	for _, password := range o.Dictionary {
+		p.addToDictionary(password)
+	}
+	if o.DictionaryFile != "" {
+		if err := p.loadDictionary(o.DictionaryFile); err != nil {
+			return nil, err
+		}
+	}
+	if o.HistorySize > 0 {
+		var err error
+		if p.history, err = db.ModelMap().ModelStruct(&History{}); err != nil {
+			return nil, errors.WrapDetf(auth.ErrInitialization, "registering password history model failed: %v", err)
+		}
+	}
+	return p, nil
+}
+
+// Validate checks if the 'password' satisfies the policy minimum length, score and is not found in the dictionary.
+// It is the auth.PasswordValidator function. The violations are returned as errors.MultiError.
+func (p *Policy) Validate(password *auth.Password) error {
+	violations := p.validate(password)
+	if len(violations) == 0 {
+		return nil
+	}
+	return violations
+}
+
+// ValidateAccount checks if the 'password' satisfies the policy for the 'account'. Besides the Validate checks,
+// the password cannot contain the account username and cannot be one of the recent account passwords.
+// The violations are returned as errors.MultiError.
+func (p *Policy) ValidateAccount(ctx context.Context, account auth.Account, password *auth.Password) error {
+	violations := p.validate(password)
+	if password == nil {
+		return violations
+	}
+	username := account.GetUsername()
+	if !p.Options.AllowUsername && utf8.RuneCountInString(username) >= minUsernameLength &&
+		strings.Contains(strings.ToLower(password.Password), strings.ToLower(username)) {
+		violations = append(violations, errors.WrapDet(auth.ErrPasswordContainsUsername, "password contains the username").
+			WithDetail("Password cannot contain the username."))
+	}
+	reused, err := p.reused(ctx, account, password.Password)
+	if err != nil {
+		return err
+	}
+	if reused {
+		violations = append(violations, errors.WrapDet(auth.ErrPasswordReused, "password was recently used").
+			WithDetailf("Password cannot be one of the last %d passwords.", p.Options.HistorySize))
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return violations
+}
+
+// SetPassword validates the 'password' for the 'account', hashes and sets it with the authenticator. If the account
+// implements auth.PasswordAger its password change time is set. If the account is already stored, its previous
+// password is added to the history and the account password fields are updated in the database.
+func (p *Policy) SetPassword(ctx context.Context, account auth.Account, password *auth.Password) error {
+	if err := p.ValidateAccount(ctx, account, password); err != nil {
+		return err
+	}
+	stored := !account.IsPrimaryKeyZero()
+	if stored && p.Options.HistorySize > 0 && len(account.GetPasswordHash()) > 0 {
+		if err := p.addHistory(ctx, account); err != nil {
+			return err
+		}
+	}
+	if err := p.authenticator.HashAndSetPassword(account, password); err != nil {
+		return err
+	}
+	ager, isAger := account.(auth.PasswordAger)
+	if isAger {
+		ager.SetPasswordChangedAt(p.Options.TimeFunc())
+	}
+	if !stored {
+		return nil
+	}
+	mStruct, err := p.db.ModelMap().ModelStruct(account)
+	if err != nil {
+		return err
+	}
+	fieldNames := []string{account.PasswordHashField()}
+	if salter, ok := account.(auth.Salter); ok {
+		fieldNames = append(fieldNames, salter.SaltField())
+	}
+	if isAger {
+		fieldNames = append(fieldNames, ager.PasswordChangedAtField())
+	}
+	fields := make([]*mapping.StructField, len(fieldNames))
+	for i, name := range fieldNames {
+		field, ok := mStruct.FieldByName(name)
+		if !ok {
+			return errors.WrapDetf(auth.ErrAccountNotValid, "field: '%s' not found in the account model: '%s'", name, mStruct)
+		}
+		fields[i] = field
+	}
+	_, err = p.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), mStruct, account).Select(fields...).Update()
+	return err
+}
+
+// IsExpired checks if the 'account' password is older than the policy maximum age. The passwords of the accounts
+// that doesn't implement auth.PasswordAger or have no password change time never expire.
+func (p *Policy) IsExpired(account auth.Account) bool {
+	if p.Options.MaxAge == 0 {
+		return false
+	}
+	ager, ok := account.(auth.PasswordAger)
+	if !ok || ager.GetPasswordChangedAt().IsZero() {
+		return false
+	}
+	return !p.Options.TimeFunc().Before(ager.GetPasswordChangedAt().Add(p.Options.MaxAge))
+}
+
+// CheckExpired returns auth.ErrPasswordExpired error if the 'account' password is expired.
+func (p *Policy) CheckExpired(account auth.Account) error {
+	if p.IsExpired(account) {
+		return errors.WrapDetf(auth.ErrPasswordExpired, "account: '%s' password expired", account.GetUsername()).
+			WithDetail("Password has expired and needs to be changed.")
+	}
+	return nil
+}
+
+// validate gets the 'password' violations of the policy minimum length, score and the dictionary.
+func (p *Policy) validate(password *auth.Password) errors.MultiError {
+	if password == nil {
+		return errors.MultiError{errors.WrapDet(auth.ErrPasswordTooShort, "no password provided").
+			WithDetail("Password is required.")}
+	}
+	var violations errors.MultiError
+	if length := utf8.RuneCountInString(password.Password); length < p.Options.MinLength {
+		violations = append(violations, errors.WrapDetf(auth.ErrPasswordTooShort, "password length: '%d' is lower than: '%d'", length, p.Options.MinLength).
+			WithDetailf("Password needs to be at least %d characters long.", p.Options.MinLength))
+	}
+	// The password score is computed again with the policy scorer.
+	scored := *password
+	scored.Score = 0
+	p.Options.Scorer(&scored)
+	if scored.Score < p.Options.MinScore {
+		violations = append(violations, errors.WrapDetf(auth.ErrPasswordTooWeak, "password score: '%d' is lower than: '%d'", scored.Score, p.Options.MinScore).
+			WithDetail("Password is too weak. Use a longer password with numbers, capital letters and special characters."))
+	}
+	if _, ok := p.dictionary[strings.ToLower(password.Password)]; ok {
+		violations = append(violations, errors.WrapDet(auth.ErrPasswordCommon, "password found in the dictionary").
+			WithDetail("Password is too common or was found in a data breach."))
+	}
+	return violations
+}
+
+// reused checks if the 'password' matches the current or one of the recent 'account' passwords.
+func (p *Policy) reused(ctx context.Context, account auth.Account, password string) (bool, error) {
+	if p.Options.HistorySize == 0 {
+		return false, nil
+	}
+	accounts := []auth.Account{account}
+	if !account.IsPrimaryKeyZero() {
+		histories, err := p.histories(ctx, account)
+		if err != nil {
+			return false, err
+		}
+		for i, history := range histories {
+			if i == p.Options.HistorySize-1 {
+				break
+			}
+			accounts = append(accounts, history)
+		}
+	}
+	for _, previous := range accounts {
+		if len(previous.GetPasswordHash()) == 0 {
+			continue
+		}
+		err := p.authenticator.ComparePassword(previous, password)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, auth.ErrInvalidPassword) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// addHistory stores the current 'account' password hash in the history and deletes the ones that exceeds the
+// history size.
+func (p *Policy) addHistory(ctx context.Context, account auth.Account) error {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return err
+	}
+	history := &History{AccountID: accountID, Hash: account.GetPasswordHash()}
+	if salter, ok := account.(auth.SaltGetter); ok {
+		history.Salt = salter.GetSalt()
+	}
+	if err = p.db.Insert(database.CtxWithSkipAuthorization(ctx), p.history, history); err != nil {
+		return err
+	}
+	histories, err := p.histories(ctx, account)
+	if err != nil {
+		return err
+	}
+	if len(histories) < p.Options.HistorySize {
+		return nil
+	}
+	// The current password is a part of the history size.
+	stale := make([]mapping.Model, 0, len(histories)-p.Options.HistorySize+1)
+	for _, h := range histories[p.Options.HistorySize-1:] {
+		stale = append(stale, h)
+	}
+	_, err = p.db.Delete(database.CtxWithSkipAuthorization(ctx), p.history, stale...)
+	return err
+}
+
+// histories gets the 'account' password histories starting from the most recent one.
+func (p *Policy) histories(ctx context.Context, account auth.Account) ([]*History, error) {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return nil, err
+	}
+	models, err := p.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), p.history).
+		Where("AccountID = ?", accountID).
+		OrderBy(query.SortField{StructField: p.history.Primary(), SortOrder: query.DescendingOrder}).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	histories := make([]*History, len(models))
+	for i, model := range models {
+		histories[i] = model.(*History)
+	}
+	return histories, nil
+}
+
+// loadDictionary loads the breached or common passwords from the file at 'path'.
+func (p *Policy) loadDictionary(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.WrapDetf(auth.ErrInitialization, "opening password dictionary: '%s' failed: %v", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.addToDictionary(line)
+	}
+	if err = scanner.Err(); err != nil {
+		return errors.WrapDetf(auth.ErrInitialization, "reading password dictionary: '%s' failed: %v", path, err)
+	}
+	return nil
+}
+
+// addToDictionary adds the 'password' to the dictionary of the rejected passwords.
+func (p *Policy) addToDictionary(password string) {
+	p.dictionary[strings.ToLower(password)] = struct{}{}
+}
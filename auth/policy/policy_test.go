@@ -0,0 +1,146 @@
+package policy
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/auth/authenticator"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+)
+
+func testPolicy(t *testing.T, options ...Option) *Policy {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(&User{}))
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+
+	a, err := authenticator.New(auth.AuthenticatorMethod(auth.SHA256))
+	require.NoError(t, err)
+	p, err := New(db, a, options...)
+	require.NoError(t, err)
+	return p
+}
+
+// violations gets the details of the password policy violations.
+func violations(t *testing.T, err error) []string {
+	t.Helper()
+	multi, ok := err.(errors.MultiError)
+	require.True(t, ok, "%T", err)
+	details := make([]string, len(multi))
+	for i, violation := range multi {
+		detailed, ok := violation.(*errors.DetailedError)
+		require.True(t, ok)
+		assert.True(t, errors.Is(detailed, auth.ErrPasswordPolicy))
+		details[i] = detailed.Details
+	}
+	return details
+}
+
+func TestValidate(t *testing.T) {
+	f, err := ioutil.TempFile("", "dictionary")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("# common passwords\n\nLetmein123!\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	p := testPolicy(t, WithDictionary("Password1!"), WithDictionaryFile(f.Name()))
+	var validator auth.PasswordValidator = p.Validate
+
+	require.NoError(t, validator(auth.NewPassword("Tr0ub4dor&3x")))
+
+	err = validator(auth.NewPassword("aB3$"))
+	assert.True(t, errors.Is(err, auth.ErrPasswordTooShort))
+	assert.Equal(t, []string{"Password needs to be at least 8 characters long."}, violations(t, err))
+
+	err = validator(auth.NewPassword("aaaaaaaaaa"))
+	assert.True(t, errors.Is(err, auth.ErrPasswordTooWeak))
+	assert.False(t, errors.Is(err, auth.ErrPasswordTooShort))
+
+	for _, password := range []string{"password1!", "LETMEIN123!"} {
+		err = validator(auth.NewPassword(password))
+		assert.True(t, errors.Is(err, auth.ErrPasswordCommon), password)
+		assert.Equal(t, []string{"Password is too common or was found in a data breach."}, violations(t, err))
+	}
+
+	err = validator(nil)
+	assert.True(t, errors.Is(err, auth.ErrPasswordTooShort))
+}
+
+func TestSetPassword(t *testing.T) {
+	now := time.Unix(1603443600, 0)
+	p := testPolicy(t, WithHistorySize(3), WithMaxAge(24*time.Hour), WithTimeFunc(func() time.Time { return now }))
+	ctx := context.Background()
+
+	// The password of not stored account is only hashed and set.
+	user := &User{Username: "john"}
+	require.NoError(t, p.SetPassword(ctx, user, auth.NewPassword("Pass-word-1")))
+	assert.Equal(t, now, user.PasswordChangedAt)
+	mStruct := p.db.ModelMap().MustModelStruct(user)
+	require.NoError(t, p.db.Insert(ctx, mStruct, user))
+
+	err := p.ValidateAccount(ctx, user, auth.NewPassword("john"))
+	assert.True(t, errors.Is(err, auth.ErrPasswordContainsUsername))
+	assert.Len(t, violations(t, err), 3)
+	err = p.ValidateAccount(ctx, user, auth.NewPassword("My-JOHN-pass-1"))
+	assert.Equal(t, []string{"Password cannot contain the username."}, violations(t, err))
+
+	err = p.SetPassword(ctx, user, auth.NewPassword("Pass-word-1"))
+	assert.Equal(t, []string{"Password cannot be one of the last 3 passwords."}, violations(t, err))
+
+	require.NoError(t, p.SetPassword(ctx, user, auth.NewPassword("Second-pass-2")))
+	require.NoError(t, p.SetPassword(ctx, user, auth.NewPassword("Third-pass-3")))
+	err = p.SetPassword(ctx, user, auth.NewPassword("Pass-word-1"))
+	assert.True(t, errors.Is(err, auth.ErrPasswordReused))
+
+	now = now.Add(time.Hour)
+	require.NoError(t, p.SetPassword(ctx, user, auth.NewPassword("Fourth-pass-4")))
+	// The first password is no longer within the last 3 passwords.
+	require.NoError(t, p.ValidateAccount(ctx, user, auth.NewPassword("Pass-word-1")))
+	histories, err := p.histories(ctx, user)
+	require.NoError(t, err)
+	assert.Len(t, histories, 2)
+
+	model, err := p.db.QueryCtx(ctx, mStruct).Filter(filter.New(mStruct.Primary(), filter.OpEqual, user.ID)).Get()
+	require.NoError(t, err)
+	stored := model.(*User)
+	assert.True(t, now.Equal(stored.PasswordChangedAt))
+	require.NoError(t, p.authenticator.ComparePassword(stored, "Fourth-pass-4"))
+
+	t.Run("Expired", func(t *testing.T) {
+		assert.False(t, p.IsExpired(stored))
+		require.NoError(t, p.CheckExpired(stored))
+
+		now = now.Add(24 * time.Hour)
+		err := p.CheckExpired(stored)
+		assert.True(t, errors.Is(err, auth.ErrPasswordExpired))
+		assert.False(t, p.IsExpired(&User{Username: "new"}))
+	})
+}
+
+func TestNew(t *testing.T) {
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()))
+	require.NoError(t, err)
+	a, err := authenticator.New(auth.AuthenticatorMethod(auth.SHA256))
+	require.NoError(t, err)
+
+	_, err = New(nil, a)
+	assert.True(t, errors.Is(err, auth.ErrInitialization))
+	for _, option := range []Option{WithMinLength(-1), WithMinScore(-1), WithHistorySize(-1), WithMaxAge(-time.Hour), WithDictionaryFile("not-existing.txt")} {
+		_, err = New(db, a, option)
+		assert.True(t, errors.Is(err, auth.ErrInitialization))
+	}
+}
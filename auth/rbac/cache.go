@@ -0,0 +1,71 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+)
+
+const (
+	accountRolesPrefix = "rbac_account_roles:"
+	roleScopesPrefix   = "rbac_role_scopes:"
+	roleHierarchyKey   = "rbac_role_hierarchy"
+)
+
+func accountRolesKey(accountID string) string {
+	return accountRolesPrefix + accountID
+}
+
+func roleScopesKey(roleName string) string {
+	return roleScopesPrefix + roleName
+}
+
+// cached gets the JSON 'value' stored in the cache at 'key'. If the cache doesn't contain the value, it is loaded
+// and stored in the cache.
+func (r *RBAC) cached(ctx context.Context, key string, value interface{}, load func() error) error {
+	record, err := r.Options.Store.Get(ctx, key)
+	switch {
+	case err == nil:
+		if err = json.Unmarshal(record.Value, value); err == nil {
+			return nil
+		}
+		log.Warningf("RBAC cache record: '%s' is not valid: %v", key, err)
+	case !errors.Is(err, store.ErrRecordNotFound):
+		return err
+	}
+
+	if err = load(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.WrapDetf(errors.ErrInternal, "marshaling cache value failed: %v", err)
+	}
+	return r.Options.Store.Set(ctx, &store.Record{Key: key, Value: data}, store.SetWithTTL(r.Options.CacheExpiration))
+}
+
+// invalidate deletes the cached values stored at 'keys'.
+func (r *RBAC) invalidate(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := r.Options.Store.Delete(ctx, key); err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidatePrefix deletes all the cached values with the keys starting with the 'prefix'.
+func (r *RBAC) invalidatePrefix(ctx context.Context, prefix string) error {
+	records, err := r.Options.Store.Find(ctx, store.FindWithPrefix(prefix))
+	if err != nil {
+		return err
+	}
+	keys := make([]string, len(records))
+	for i, record := range records {
+		keys[i] = record.Key
+	}
+	return r.invalidate(ctx, keys...)
+}
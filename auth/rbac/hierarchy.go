@@ -0,0 +1,182 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// roleNode is the cached role hierarchy node.
+type roleNode struct {
+	Hierarchy int      `json:"hierarchy,omitempty"`
+	Children  []string `json:"children,omitempty"`
+}
+
+// roleHierarchy is the role hierarchy graph mapped by the role names.
+type roleHierarchy map[string]*roleNode
+
+// held gets the names of all the roles held by the 'roles'. A role holds itself, all its children roles and all
+// the roles with lower positive hierarchy value than its own. The relation is transitive.
+func (h roleHierarchy) held(roles []string) []string {
+	visited := make(map[string]struct{}, len(roles))
+	var result []string
+	queue := append([]string{}, roles...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[name]; ok {
+			continue
+		}
+		visited[name] = struct{}{}
+		result = append(result, name)
+
+		node, ok := h[name]
+		if !ok {
+			continue
+		}
+		queue = append(queue, node.Children...)
+		if node.Hierarchy <= 0 {
+			continue
+		}
+		for other, otherNode := range h {
+			if otherNode.Hierarchy > 0 && otherNode.Hierarchy < node.Hierarchy {
+				queue = append(queue, other)
+			}
+		}
+	}
+	return result
+}
+
+// isDescendant checks if the 'role' is reachable from the 'from' role by the children relations.
+func (h roleHierarchy) isDescendant(from, role string) bool {
+	visited := map[string]struct{}{}
+	queue := []string{from}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if name == role {
+			return true
+		}
+		if _, ok := visited[name]; ok {
+			continue
+		}
+		visited[name] = struct{}{}
+		if node, ok := h[name]; ok {
+			queue = append(queue, node.Children...)
+		}
+	}
+	return false
+}
+
+// SetRoleHierarchy sets the hierarchy 'value' of the 'role'. The role with a positive hierarchy value holds all
+// the roles with lower positive values. The zero value removes the role from the ranking.
+func (r *RBAC) SetRoleHierarchy(ctx context.Context, role auth.Role, value int) error {
+	if value < 0 {
+		return errors.WrapDetf(auth.ErrInvalidRole, "role hierarchy value cannot be negative: '%d'", value)
+	}
+	stored, err := r.getRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	stored.Hierarchy = value
+	if _, err = r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roles, stored).Select(r.roles.MustFieldByName("Hierarchy")).Update(); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, roleHierarchyKey)
+}
+
+// AddRoleChild makes the 'parent' role hold the 'child' role with all its permissions and scopes. If the child
+// is already added the function returns auth.ErrRoleAlreadyGranted error. The relation cannot create a cycle.
+func (r *RBAC) AddRoleChild(ctx context.Context, parent, child auth.Role) error {
+	storedParent, err := r.getRole(ctx, parent)
+	if err != nil {
+		return err
+	}
+	storedChild, err := r.getRole(ctx, child)
+	if err != nil {
+		return err
+	}
+	if storedParent.ID == storedChild.ID {
+		return errors.WrapDetf(auth.ErrInvalidRole, "role: '%s' cannot be its own child", storedParent.Name)
+	}
+	exists, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleChildren).
+		Where("ParentID = ?", storedParent.ID).
+		Where("ChildID = ?", storedChild.ID).
+		Exists()
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.WrapDetf(auth.ErrRoleAlreadyGranted, "role: '%s' is already a child of the role: '%s'", storedChild.Name, storedParent.Name)
+	}
+	hierarchy, err := r.roleHierarchy(ctx)
+	if err != nil {
+		return err
+	}
+	if hierarchy.isDescendant(storedChild.Name, storedParent.Name) {
+		return errors.WrapDetf(auth.ErrInvalidRole, "role: '%s' is a descendant of the role: '%s'", storedParent.Name, storedChild.Name)
+	}
+	if err = r.db.Insert(database.CtxWithSkipAuthorization(ctx), r.roleChildren, &RoleChild{ParentID: storedParent.ID, ChildID: storedChild.ID}); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, roleHierarchyKey)
+}
+
+// RemoveRoleChild removes the 'child' role from the 'parent' role. If the child is not added to the parent the
+// function returns auth.ErrInvalidRole error.
+func (r *RBAC) RemoveRoleChild(ctx context.Context, parent, child auth.Role) error {
+	storedParent, err := r.getRole(ctx, parent)
+	if err != nil {
+		return err
+	}
+	storedChild, err := r.getRole(ctx, child)
+	if err != nil {
+		return err
+	}
+	deleted, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleChildren).
+		Where("ParentID = ?", storedParent.ID).
+		Where("ChildID = ?", storedChild.ID).
+		Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.WrapDetf(auth.ErrInvalidRole, "role: '%s' is not a child of the role: '%s'", storedChild.Name, storedParent.Name)
+	}
+	return r.invalidate(ctx, roleHierarchyKey)
+}
+
+// roleHierarchy gets the cached role hierarchy graph.
+func (r *RBAC) roleHierarchy(ctx context.Context) (roleHierarchy, error) {
+	hierarchy := roleHierarchy{}
+	err := r.cached(ctx, roleHierarchyKey, &hierarchy, func() error {
+		models, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roles).Find()
+		if err != nil {
+			return err
+		}
+		names := make(map[int]string, len(models))
+		for _, model := range models {
+			role := model.(*Role)
+			names[role.ID] = role.Name
+			hierarchy[role.Name] = &roleNode{Hierarchy: role.Hierarchy}
+		}
+		models, err = r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleChildren).Find()
+		if err != nil {
+			return err
+		}
+		for _, model := range models {
+			roleChild := model.(*RoleChild)
+			parent, ok := hierarchy[names[roleChild.ParentID]]
+			if !ok {
+				continue
+			}
+			if child, ok := names[roleChild.ChildID]; ok {
+				parent.Children = append(parent.Children, child)
+			}
+		}
+		return nil
+	})
+	return hierarchy, err
+}
@@ -0,0 +1,95 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/query"
+)
+
+func TestRoleHierarchyValues(t *testing.T) {
+	ctx := context.Background()
+	r := testRBAC(t)
+
+	admin, err := r.CreateRole(ctx, "admin")
+	require.NoError(t, err)
+	moderator, err := r.CreateRole(ctx, "moderator")
+	require.NoError(t, err)
+	user, err := r.CreateRole(ctx, "user")
+	require.NoError(t, err)
+	guest, err := r.CreateRole(ctx, "guest")
+	require.NoError(t, err)
+
+	require.NoError(t, r.SetRoleHierarchy(ctx, admin, 30))
+	require.NoError(t, r.SetRoleHierarchy(ctx, moderator, 20))
+	require.NoError(t, r.SetRoleHierarchy(ctx, user, 10))
+	assert.True(t, errors.Is(r.SetRoleHierarchy(ctx, user, -1), auth.ErrInvalidRole))
+	require.NoError(t, r.GrantRoleScope(ctx, user, &Scope{Name: "blogs:read"}))
+	require.NoError(t, r.GrantRoleScope(ctx, moderator, &Scope{Name: "blogs:moderate"}))
+
+	roles, err := r.FindRoles(ctx, auth.ListRoleSortByHierarchy(query.DescendingOrder))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "moderator", "user", "guest"}, roleNames(roles))
+
+	account := &testmodels.User{ID: 1}
+	require.NoError(t, r.GrantRole(ctx, account, moderator))
+
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyAllowedRoles(user)))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyScopes(&Scope{Name: "blogs:read"}, &Scope{Name: "blogs:moderate"})))
+	// The disallowed roles are verified only against the granted roles.
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyDisallowedRoles(user)))
+
+	err = r.Verify(ctx, account, auth.VerifyAllowedRoles(admin))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+	// The unranked roles are not held by the ranked ones.
+	err = r.Verify(ctx, account, auth.VerifyAllowedRoles(guest))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+	// Changing the hierarchy invalidates the cache.
+	require.NoError(t, r.SetRoleHierarchy(ctx, moderator, 5))
+	err = r.Verify(ctx, account, auth.VerifyAllowedRoles(user))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+}
+
+func TestRoleChildren(t *testing.T) {
+	ctx := context.Background()
+	r := testRBAC(t)
+
+	admin, err := r.CreateRole(ctx, "admin")
+	require.NoError(t, err)
+	editor, err := r.CreateRole(ctx, "editor")
+	require.NoError(t, err)
+	writer, err := r.CreateRole(ctx, "writer")
+	require.NoError(t, err)
+	require.NoError(t, r.GrantRoleScope(ctx, writer, &Scope{Name: "blogs:write"}))
+
+	require.NoError(t, r.AddRoleChild(ctx, admin, editor))
+	require.NoError(t, r.AddRoleChild(ctx, editor, writer))
+	assert.True(t, errors.Is(r.AddRoleChild(ctx, admin, editor), auth.ErrRoleAlreadyGranted))
+	assert.True(t, errors.Is(r.AddRoleChild(ctx, writer, admin), auth.ErrInvalidRole))
+	assert.True(t, errors.Is(r.AddRoleChild(ctx, admin, admin), auth.ErrInvalidRole))
+	assert.True(t, errors.Is(r.AddRoleChild(ctx, admin, &Role{Name: "unknown"}), auth.ErrInvalidRole))
+
+	account := &testmodels.User{ID: 1}
+	require.NoError(t, r.GrantRole(ctx, account, admin))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyAllowedRoles(writer)))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyScopes(&Scope{Name: "blogs:write"})))
+
+	require.NoError(t, r.RemoveRoleChild(ctx, editor, writer))
+	assert.True(t, errors.Is(r.RemoveRoleChild(ctx, editor, writer), auth.ErrInvalidRole))
+	err = r.Verify(ctx, account, auth.VerifyScopes(&Scope{Name: "blogs:write"}))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyAllowedRoles(editor)))
+
+	// Deleting the role removes its relations.
+	require.NoError(t, r.DeleteRole(ctx, editor))
+	err = r.Verify(ctx, account, auth.VerifyAllowedRoles(editor))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+	require.NoError(t, r.AddRoleChild(ctx, writer, admin))
+}
@@ -0,0 +1,64 @@
+package rbac
+
+import (
+	"github.com/neuronlabs/neuron/auth"
+)
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=Role,RoleChild,Scope,RoleScope,AccountRole .
+
+// Compile time check for the auth interfaces.
+var (
+	_ auth.HierarchicalRole = &Role{}
+	_ auth.Scope            = &Scope{}
+)
+
+// Role is the model of the role that could be granted to the accounts. The role with positive hierarchy value
+// holds all the roles with lower positive hierarchy values.
+type Role struct {
+	ID        int
+	Name      string `db:";unique"`
+	Hierarchy int
+}
+
+// RoleName implements auth.Role interface.
+func (r *Role) RoleName() string {
+	return r.Name
+}
+
+// HierarchyValue implements auth.HierarchicalRole interface.
+func (r *Role) HierarchyValue() int {
+	return r.Hierarchy
+}
+
+// RoleChild is the join model of the explicit role graph. The parent role holds its child roles.
+type RoleChild struct {
+	ID       int
+	ParentID int `neuron:"type=attr" db:";unique_index=role_child"`
+	ChildID  int `neuron:"type=attr" db:";unique_index=role_child"`
+}
+
+// Scope is the model of the authorization scope that could be granted to the roles.
+type Scope struct {
+	ID   int
+	Name string `db:";unique"`
+}
+
+// ScopeName implements auth.Scope interface.
+func (s *Scope) ScopeName() string {
+	return s.Name
+}
+
+// RoleScope is the join model of the roles and their granted scopes.
+type RoleScope struct {
+	ID      int
+	RoleID  int `neuron:"type=attr" db:";unique_index=role_scope"`
+	ScopeID int `neuron:"type=attr" db:";unique_index=role_scope"`
+}
+
+// AccountRole is the join model of the accounts and their granted roles. The account is identified by its primary
+// key string value.
+type AccountRole struct {
+	ID        int
+	AccountID string `neuron:"type=attr" db:";unique_index=account_role"`
+	RoleID    int    `neuron:"type=attr" db:";unique_index=account_role"`
+}
@@ -0,0 +1,35 @@
+package rbac
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/store"
+)
+
+// DefaultCacheExpiration is the default expiration time of the cached account roles and role scopes.
+const DefaultCacheExpiration = 5 * time.Minute
+
+// Options are the RBAC options.
+type Options struct {
+	// Store is the store used to cache the account roles and the role scopes.
+	Store store.Store
+	// CacheExpiration is the expiration time of the cached values.
+	CacheExpiration time.Duration
+}
+
+// Option is a function that sets the RBAC options.
+type Option func(o *Options)
+
+// WithStore sets the store used to cache the account roles and the role scopes.
+func WithStore(s store.Store) Option {
+	return func(o *Options) {
+		o.Store = s
+	}
+}
+
+// WithCacheExpiration sets the expiration time of the cached values.
+func WithCacheExpiration(expiration time.Duration) Option {
+	return func(o *Options) {
+		o.CacheExpiration = expiration
+	}
+}
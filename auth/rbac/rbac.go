@@ -0,0 +1,99 @@
+// Package rbac implements the database backed role-based access control. The roles, scopes and their grants
+// are stored in the database using the package models. The models are stored in the default repository unless
+// they are registered with other one i.e. using service.WithRepositoryModels(repo, rbac.Neuron_Models...).
+package rbac
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/store/memory"
+)
+
+// Compile time check for the auth interfaces.
+var (
+	_ auth.Roler      = &RBAC{}
+	_ auth.RoleScoper = &RBAC{}
+	_ auth.Verifier   = &RBAC{}
+)
+
+// RBAC is the role-based access control that implements auth.Roler, auth.RoleScoper and auth.Verifier interfaces.
+// The roles granted to the accounts and the scopes granted to the roles are cached in the options store.
+type RBAC struct {
+	Options *Options
+
+	db           database.DB
+	roles        *mapping.ModelStruct
+	roleChildren *mapping.ModelStruct
+	scopes       *mapping.ModelStruct
+	roleScopes   *mapping.ModelStruct
+	accountRoles *mapping.ModelStruct
+}
+
+// New creates new RBAC for the 'db'. The package models not yet registered in the database model map are
+// registered by this function. If no store is defined, the in-memory store is used for the cache.
+func New(db database.DB, options ...Option) (*RBAC, error) {
+	o := &Options{CacheExpiration: DefaultCacheExpiration}
+	for _, option := range options {
+		option(o)
+	}
+	if o.Store == nil {
+		log.Debug("No store defined for the RBAC. Using in-memory store for the cache.")
+		o.Store = memory.New()
+	}
+	r := &RBAC{Options: o, db: db}
+	for _, model := range []struct {
+		model mapping.Model
+		dst   **mapping.ModelStruct
+	}{
+		{&Role{}, &r.roles},
+		{&RoleChild{}, &r.roleChildren},
+		{&Scope{}, &r.scopes},
+		{&RoleScope{}, &r.roleScopes},
+		{&AccountRole{}, &r.accountRoles},
+	} {
+		mStruct, err := db.ModelMap().ModelStruct(model.model)
+		if err != nil {
+			return nil, errors.WrapDetf(auth.ErrInitialization, "registering rbac model: '%T' failed: %v", model.model, err)
+		}
+		*model.dst = mStruct
+	}
+	return r, nil
+}
+
+// getRole gets the stored role with the 'role' name.
+func (r *RBAC) getRole(ctx context.Context, role auth.Role) (*Role, error) {
+	if role == nil || role.RoleName() == "" {
+		return nil, errors.WrapDet(auth.ErrInvalidRole, "no role name provided")
+	}
+	model, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roles).Where("Name = ?", role.RoleName()).Get()
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, errors.WrapDetf(auth.ErrInvalidRole, "role: '%s' not found", role.RoleName())
+		}
+		return nil, err
+	}
+	return model.(*Role), nil
+}
+
+// accountID gets the 'account' identifier.
+func accountID(account auth.Account) (string, error) {
+	if account == nil || account.IsPrimaryKeyZero() {
+		return "", errors.WrapDet(auth.ErrAccountNotValid, "provided account has no primary key value")
+	}
+	return account.GetPrimaryKeyStringValue()
+}
+
+// intValues converts the 'ids' into query arguments.
+func intValues(ids []int) []interface{} {
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	return values
+}
@@ -0,0 +1,232 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+)
+
+func testRBAC(t *testing.T, options ...Option) *RBAC {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(Neuron_Models...))
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+	r, err := New(db, options...)
+	require.NoError(t, err)
+	return r
+}
+
+func roleNames(roles []auth.Role) []string {
+	names := []string{}
+	for _, role := range roles {
+		names = append(names, role.RoleName())
+	}
+	return names
+}
+
+func scopeNames(scopes []auth.Scope) []string {
+	names := []string{}
+	for _, scope := range scopes {
+		names = append(names, scope.ScopeName())
+	}
+	return names
+}
+
+func TestNew(t *testing.T) {
+	m := mapping.New()
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+	r, err := New(db)
+	require.NoError(t, err)
+
+	// The models are registered in the model map.
+	for _, name := range []string{"Role", "Scope", "RoleScope", "AccountRole"} {
+		assert.NotNil(t, m.ModelByName(name), name)
+	}
+	_, err = r.CreateRole(context.Background(), "admin")
+	assert.NoError(t, err)
+}
+
+func TestRoles(t *testing.T) {
+	ctx := context.Background()
+	r := testRBAC(t)
+
+	for _, name := range []string{"user", "admin", "editor"} {
+		_, err := r.CreateRole(ctx, name)
+		require.NoError(t, err)
+	}
+	_, err := r.CreateRole(ctx, "admin")
+	assert.True(t, errors.Is(err, auth.ErrInvalidRole))
+
+	roles, err := r.FindRoles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "editor", "user"}, roleNames(roles))
+
+	roles, err = r.FindRoles(ctx, auth.ListRoleLimit(1), auth.ListRoleOffset(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"editor"}, roleNames(roles))
+
+	account := &testmodels.User{ID: 1}
+	require.NoError(t, r.GrantRole(ctx, account, &Role{Name: "user"}))
+	require.NoError(t, r.GrantRole(ctx, account, &Role{Name: "editor"}))
+	err = r.GrantRole(ctx, account, &Role{Name: "user"})
+	assert.True(t, errors.Is(err, auth.ErrRoleAlreadyGranted))
+	err = r.GrantRole(ctx, account, &Role{Name: "unknown"})
+	assert.True(t, errors.Is(err, auth.ErrInvalidRole))
+	err = r.GrantRole(ctx, &testmodels.User{}, &Role{Name: "user"})
+	assert.True(t, errors.Is(err, auth.ErrAccountNotValid))
+
+	roles, err = r.FindRoles(ctx, auth.ListRoleAccount(account))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"editor", "user"}, roleNames(roles))
+
+	roles, err = r.FindRoles(ctx, auth.ListRoleAccount(&testmodels.User{ID: 2}))
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+
+	require.NoError(t, r.RevokeRole(ctx, account, &Role{Name: "editor"}))
+	err = r.RevokeRole(ctx, account, &Role{Name: "editor"})
+	assert.True(t, errors.Is(err, auth.ErrInvalidRole))
+	roles, err = r.FindRoles(ctx, auth.ListRoleAccount(account))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user"}, roleNames(roles))
+
+	require.NoError(t, r.ClearRoles(ctx, account))
+	roles, err = r.FindRoles(ctx, auth.ListRoleAccount(account))
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+}
+
+func TestRoleScopes(t *testing.T) {
+	ctx := context.Background()
+	r := testRBAC(t)
+
+	admin, err := r.CreateRole(ctx, "admin")
+	require.NoError(t, err)
+	user, err := r.CreateRole(ctx, "user")
+	require.NoError(t, err)
+
+	require.NoError(t, r.GrantRoleScope(ctx, admin, &Scope{Name: "blogs:write"}))
+	require.NoError(t, r.GrantRoleScope(ctx, admin, &Scope{Name: "blogs:read"}))
+	require.NoError(t, r.GrantRoleScope(ctx, user, &Scope{Name: "blogs:read"}))
+	err = r.GrantRoleScope(ctx, user, &Scope{Name: "blogs:read"})
+	assert.True(t, errors.Is(err, auth.ErrRoleAlreadyGranted))
+	err = r.GrantRoleScope(ctx, &Role{Name: "unknown"}, &Scope{Name: "blogs:read"})
+	assert.True(t, errors.Is(err, auth.ErrInvalidRole))
+
+	scopes, err := r.ListRoleScopes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blogs:read", "blogs:write"}, scopeNames(scopes))
+
+	scopes, err = r.ListRoleScopes(ctx, auth.ListScopeRole(admin))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blogs:read", "blogs:write"}, scopeNames(scopes))
+
+	scopes, err = r.ListRoleScopes(ctx, auth.ListScopeRole(admin), auth.ListScopeLimit(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blogs:read"}, scopeNames(scopes))
+
+	require.NoError(t, r.RevokeRoleScope(ctx, admin, &Scope{Name: "blogs:read"}))
+	err = r.RevokeRoleScope(ctx, admin, &Scope{Name: "blogs:read"})
+	assert.True(t, errors.Is(err, auth.ErrAuthorizationScope))
+	scopes, err = r.ListRoleScopes(ctx, auth.ListScopeRole(admin))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blogs:write"}, scopeNames(scopes))
+
+	require.NoError(t, r.ClearRoleScopes(ctx, admin, user))
+	for _, role := range []auth.Role{admin, user} {
+		scopes, err = r.ListRoleScopes(ctx, auth.ListScopeRole(role))
+		require.NoError(t, err)
+		assert.Empty(t, scopes)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	r := testRBAC(t)
+
+	admin, err := r.CreateRole(ctx, "admin")
+	require.NoError(t, err)
+	user, err := r.CreateRole(ctx, "user")
+	require.NoError(t, err)
+	banned, err := r.CreateRole(ctx, "banned")
+	require.NoError(t, err)
+	require.NoError(t, r.GrantRoleScope(ctx, user, &Scope{Name: "blogs:read"}))
+	require.NoError(t, r.GrantRoleScope(ctx, admin, &Scope{Name: "blogs:write"}))
+
+	account := &testmodels.User{ID: 1}
+	require.NoError(t, r.GrantRole(ctx, account, user))
+
+	assert.NoError(t, r.Verify(ctx, account))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyAllowedRoles(admin, user)))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyDisallowedRoles(banned)))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyScopes(&Scope{Name: "blogs:read"})))
+
+	err = r.Verify(ctx, account, auth.VerifyAllowedRoles(admin))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+	err = r.Verify(ctx, account, auth.VerifyScopes(&Scope{Name: "blogs:read"}, &Scope{Name: "blogs:write"}))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+	err = r.Verify(ctx, nil, auth.VerifyAllowedRoles(user))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+	assert.NoError(t, r.Verify(ctx, nil, auth.VerifyDisallowedRoles(banned)))
+
+	// The multi-factor authentication needs to be satisfied by the context claims.
+	err = r.Verify(ctx, account, auth.VerifyRequireMFA())
+	assert.True(t, errors.Is(err, auth.ErrMFARequired))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+	mfaCtx := auth.CtxWithClaims(ctx, testClaims{mfa: true})
+	assert.NoError(t, r.Verify(mfaCtx, account, auth.VerifyRequireMFA(), auth.VerifyAllowedRoles(user)))
+	err = r.Verify(auth.CtxWithClaims(ctx, testClaims{}), account, auth.VerifyRequireMFA())
+	assert.True(t, errors.Is(err, auth.ErrMFARequired))
+
+	// The account roles are cached.
+	_, err = r.Options.Store.Get(ctx, accountRolesKey("1"))
+	require.NoError(t, err)
+
+	// Changing the grants invalidates the cache.
+	require.NoError(t, r.GrantRole(ctx, account, banned))
+	err = r.Verify(ctx, account, auth.VerifyDisallowedRoles(banned))
+	assert.True(t, errors.Is(err, auth.ErrForbidden))
+
+	require.NoError(t, r.GrantRoleScope(ctx, user, &Scope{Name: "blogs:write"}))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyScopes(&Scope{Name: "blogs:read"}, &Scope{Name: "blogs:write"})))
+
+	require.NoError(t, r.DeleteRole(ctx, banned))
+	assert.NoError(t, r.Verify(ctx, account, auth.VerifyDisallowedRoles(banned)))
+	roles, err := r.FindRoles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "user"}, roleNames(roles))
+}
+
+// testClaims are the token claims with the multi-factor authentication state.
+type testClaims struct {
+	mfa bool
+}
+
+func (c testClaims) Subject() string {
+	return ""
+}
+
+func (c testClaims) ExpiresIn() int64 {
+	return -1
+}
+
+func (c testClaims) Valid() error {
+	return nil
+}
+
+func (c testClaims) MFASatisfied() bool {
+	return c.mfa
+}
@@ -0,0 +1,195 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// CreateRole creates new role with given 'name'. If the role already exists the function returns
+// auth.ErrInvalidRole error.
+func (r *RBAC) CreateRole(ctx context.Context, name string) (*Role, error) {
+	if name == "" {
+		return nil, errors.WrapDet(auth.ErrInvalidRole, "no role name provided")
+	}
+	exists, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roles).Where("Name = ?", name).Exists()
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.WrapDetf(auth.ErrInvalidRole, "role: '%s' already exists", name)
+	}
+	role := &Role{Name: name}
+	if err = r.db.Insert(database.CtxWithSkipAuthorization(ctx), r.roles, role); err != nil {
+		return nil, err
+	}
+	if err = r.invalidate(ctx, roleHierarchyKey); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// DeleteRole deletes the 'role' along with all its account and scope grants and its role graph relations.
+func (r *RBAC) DeleteRole(ctx context.Context, role auth.Role) error {
+	stored, err := r.getRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	if _, err = r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.accountRoles).Where("RoleID = ?", stored.ID).Delete(); err != nil {
+		return err
+	}
+	if _, err = r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleScopes).Where("RoleID = ?", stored.ID).Delete(); err != nil {
+		return err
+	}
+	if _, err = r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleChildren).Where("ParentID = ?", stored.ID).Delete(); err != nil {
+		return err
+	}
+	if _, err = r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleChildren).Where("ChildID = ?", stored.ID).Delete(); err != nil {
+		return err
+	}
+	if _, err = r.db.Delete(database.CtxWithSkipAuthorization(ctx), r.roles, stored); err != nil {
+		return err
+	}
+	if err = r.invalidate(ctx, roleScopesKey(stored.Name), roleHierarchyKey); err != nil {
+		return err
+	}
+	return r.invalidatePrefix(ctx, accountRolesPrefix)
+}
+
+// FindRoles implements auth.Roler interface. Lists the roles sorted by their names, or by their hierarchy values
+// if the options requires it. If the options contains an account, only the roles granted to it are listed.
+func (r *RBAC) FindRoles(ctx context.Context, options ...auth.ListRoleOption) ([]auth.Role, error) {
+	o := &auth.ListRoleOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	var sortFields []query.Sort
+	if o.SortByHierarchy {
+		sortFields = append(sortFields, query.SortField{StructField: r.roles.MustFieldByName("Hierarchy"), SortOrder: o.SortOrder})
+	}
+	sortFields = append(sortFields, query.SortField{StructField: r.roles.MustFieldByName("Name"), SortOrder: query.AscendingOrder})
+	q := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roles).OrderBy(sortFields...)
+	if o.Account != nil {
+		roleIDs, err := r.accountRoleIDs(ctx, o.Account)
+		if err != nil {
+			return nil, err
+		}
+		if len(roleIDs) == 0 {
+			return []auth.Role{}, nil
+		}
+		q = q.Where("ID IN ?", intValues(roleIDs)...)
+	}
+	if o.Limit > 0 {
+		q = q.Limit(int64(o.Limit))
+	}
+	if o.Offset > 0 {
+		q = q.Offset(int64(o.Offset))
+	}
+	models, err := q.Find()
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]auth.Role, len(models))
+	for i, model := range models {
+		roles[i] = model.(*Role)
+	}
+	return roles, nil
+}
+
+// ClearRoles implements auth.Roler interface. Revokes all the roles granted to the 'account'.
+func (r *RBAC) ClearRoles(ctx context.Context, account auth.Account) error {
+	id, err := accountID(account)
+	if err != nil {
+		return err
+	}
+	if _, err = r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.accountRoles).Where("AccountID = ?", id).Delete(); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, accountRolesKey(id))
+}
+
+// GrantRole implements auth.Roler interface. Grants the existing 'role' to the 'account'. If the role is already
+// granted the function returns auth.ErrRoleAlreadyGranted error.
+func (r *RBAC) GrantRole(ctx context.Context, account auth.Account, role auth.Role) error {
+	id, err := accountID(account)
+	if err != nil {
+		return err
+	}
+	stored, err := r.getRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	exists, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.accountRoles).Where("AccountID = ?", id).Where("RoleID = ?", stored.ID).Exists()
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.WrapDetf(auth.ErrRoleAlreadyGranted, "role: '%s' is already granted to the account: '%s'", stored.Name, id)
+	}
+	if err = r.db.Insert(database.CtxWithSkipAuthorization(ctx), r.accountRoles, &AccountRole{AccountID: id, RoleID: stored.ID}); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, accountRolesKey(id))
+}
+
+// RevokeRole implements auth.Roler interface. Revokes the 'role' from the 'account'. If the role is not granted
+// the function returns auth.ErrInvalidRole error.
+func (r *RBAC) RevokeRole(ctx context.Context, account auth.Account, role auth.Role) error {
+	id, err := accountID(account)
+	if err != nil {
+		return err
+	}
+	stored, err := r.getRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	deleted, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.accountRoles).Where("AccountID = ?", id).Where("RoleID = ?", stored.ID).Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.WrapDetf(auth.ErrInvalidRole, "role: '%s' is not granted to the account: '%s'", stored.Name, id)
+	}
+	return r.invalidate(ctx, accountRolesKey(id))
+}
+
+// accountRoleIDs gets the identifiers of the roles granted to the 'account'.
+func (r *RBAC) accountRoleIDs(ctx context.Context, account auth.Account) ([]int, error) {
+	id, err := accountID(account)
+	if err != nil {
+		return nil, err
+	}
+	models, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.accountRoles).Where("AccountID = ?", id).Find()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(models))
+	for i, model := range models {
+		ids[i] = model.(*AccountRole).RoleID
+	}
+	return ids, nil
+}
+
+// accountRoleNames gets the cached names of the roles granted to the 'account'.
+func (r *RBAC) accountRoleNames(ctx context.Context, account auth.Account) ([]string, error) {
+	id, err := accountID(account)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	err = r.cached(ctx, accountRolesKey(id), &names, func() error {
+		roles, err := r.FindRoles(ctx, auth.ListRoleAccount(account))
+		if err != nil {
+			return err
+		}
+		names = make([]string, len(roles))
+		for i, role := range roles {
+			names[i] = role.RoleName()
+		}
+		return nil
+	})
+	return names, err
+}
@@ -0,0 +1,155 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// ListRoleScopes implements auth.RoleScoper interface. Lists the scopes sorted by their names. If the options
+// contains a role, only its scopes are listed.
+func (r *RBAC) ListRoleScopes(ctx context.Context, options ...auth.ListScopeOption) ([]auth.Scope, error) {
+	o := &auth.ListScopeOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	q := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.scopes).OrderBy(query.SortField{StructField: r.scopes.MustFieldByName("Name"), SortOrder: query.AscendingOrder})
+	if o.Role != nil {
+		role, err := r.getRole(ctx, o.Role)
+		if err != nil {
+			return nil, err
+		}
+		models, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleScopes).Where("RoleID = ?", role.ID).Find()
+		if err != nil {
+			return nil, err
+		}
+		if len(models) == 0 {
+			return []auth.Scope{}, nil
+		}
+		scopeIDs := make([]int, len(models))
+		for i, model := range models {
+			scopeIDs[i] = model.(*RoleScope).ScopeID
+		}
+		q = q.Where("ID IN ?", intValues(scopeIDs)...)
+	}
+	if o.Limit > 0 {
+		q = q.Limit(int64(o.Limit))
+	}
+	if o.Offset > 0 {
+		q = q.Offset(int64(o.Offset))
+	}
+	models, err := q.Find()
+	if err != nil {
+		return nil, err
+	}
+	scopes := make([]auth.Scope, len(models))
+	for i, model := range models {
+		scopes[i] = model.(*Scope)
+	}
+	return scopes, nil
+}
+
+// ClearRoleScopes implements auth.RoleScoper interface. Revokes all the scopes granted to the 'roles'.
+func (r *RBAC) ClearRoleScopes(ctx context.Context, roles ...auth.Role) error {
+	for _, role := range roles {
+		stored, err := r.getRole(ctx, role)
+		if err != nil {
+			return err
+		}
+		if _, err = r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleScopes).Where("RoleID = ?", stored.ID).Delete(); err != nil {
+			return err
+		}
+		if err = r.invalidate(ctx, roleScopesKey(stored.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GrantRoleScope implements auth.RoleScoper interface. Grants the 'scope' to the existing 'role'. The scope is
+// created if it doesn't exist yet. If the scope is already granted the function returns auth.ErrRoleAlreadyGranted
+// error.
+func (r *RBAC) GrantRoleScope(ctx context.Context, role auth.Role, scope auth.Scope) error {
+	stored, err := r.getRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	storedScope, err := r.getScope(ctx, scope)
+	switch {
+	case errors.Is(err, auth.ErrAuthorizationScope):
+		storedScope = &Scope{Name: scope.ScopeName()}
+		if err = r.db.Insert(database.CtxWithSkipAuthorization(ctx), r.scopes, storedScope); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		exists, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleScopes).Where("RoleID = ?", stored.ID).Where("ScopeID = ?", storedScope.ID).Exists()
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.WrapDetf(auth.ErrRoleAlreadyGranted, "scope: '%s' is already granted to the role: '%s'", storedScope.Name, stored.Name)
+		}
+	}
+	if err = r.db.Insert(database.CtxWithSkipAuthorization(ctx), r.roleScopes, &RoleScope{RoleID: stored.ID, ScopeID: storedScope.ID}); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, roleScopesKey(stored.Name))
+}
+
+// RevokeRoleScope implements auth.RoleScoper interface. Revokes the 'scope' from the 'role'. If the scope is not
+// granted the function returns auth.ErrAuthorizationScope error.
+func (r *RBAC) RevokeRoleScope(ctx context.Context, role auth.Role, scope auth.Scope) error {
+	stored, err := r.getRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	storedScope, err := r.getScope(ctx, scope)
+	if err != nil {
+		return err
+	}
+	deleted, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.roleScopes).Where("RoleID = ?", stored.ID).Where("ScopeID = ?", storedScope.ID).Delete()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.WrapDetf(auth.ErrAuthorizationScope, "scope: '%s' is not granted to the role: '%s'", storedScope.Name, stored.Name)
+	}
+	return r.invalidate(ctx, roleScopesKey(stored.Name))
+}
+
+// getScope gets the stored scope with the 'scope' name.
+func (r *RBAC) getScope(ctx context.Context, scope auth.Scope) (*Scope, error) {
+	if scope == nil || scope.ScopeName() == "" {
+		return nil, errors.WrapDet(auth.ErrAuthorizationScope, "no scope name provided")
+	}
+	model, err := r.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), r.scopes).Where("Name = ?", scope.ScopeName()).Get()
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, errors.WrapDetf(auth.ErrAuthorizationScope, "scope: '%s' not found", scope.ScopeName())
+		}
+		return nil, err
+	}
+	return model.(*Scope), nil
+}
+
+// roleScopeNames gets the cached names of the scopes granted to the role with given 'name'.
+func (r *RBAC) roleScopeNames(ctx context.Context, name string) ([]string, error) {
+	var names []string
+	err := r.cached(ctx, roleScopesKey(name), &names, func() error {
+		scopes, err := r.ListRoleScopes(ctx, auth.ListScopeRole(&Role{Name: name}))
+		if err != nil {
+			return err
+		}
+		names = make([]string, len(scopes))
+		for i, scope := range scopes {
+			names[i] = scope.ScopeName()
+		}
+		return nil
+	})
+	return names, err
+}
@@ -0,0 +1,89 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// Verify implements auth.Verifier interface. The 'account' is forbidden if it has any of the disallowed roles,
+// none of the allowed roles or if its roles are not granted with all of the scopes. The allowed roles and scopes
+// are verified against all the roles held by the account roles within the role hierarchy, whereas the disallowed
+// roles are verified only against the roles granted directly. The account without the primary key has no roles.
+// If the multi-factor authentication is required, the context token claims needs to satisfy it.
+func (r *RBAC) Verify(ctx context.Context, account auth.Account, options ...auth.VerifyOption) error {
+	o := &auth.VerifyOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.RequireMFA && !auth.CtxMFASatisfied(ctx) {
+		return errors.WrapDet(auth.ErrMFARequired, "multi-factor authentication is not satisfied").
+			WithDetail("Multi-factor authentication required.")
+	}
+	if len(o.AllowedRoles) == 0 && len(o.DisallowedRoles) == 0 && len(o.Scopes) == 0 {
+		return nil
+	}
+
+	var roles []string
+	if account != nil && !account.IsPrimaryKeyZero() {
+		var err error
+		if roles, err = r.accountRoleNames(ctx, account); err != nil {
+			return err
+		}
+	}
+	granted := make(map[string]struct{}, len(roles))
+	for _, role := range roles {
+		granted[role] = struct{}{}
+	}
+	if len(roles) > 0 && (len(o.AllowedRoles) > 0 || len(o.Scopes) > 0) {
+		hierarchy, err := r.roleHierarchy(ctx)
+		if err != nil {
+			return err
+		}
+		roles = hierarchy.held(roles)
+	}
+	held := make(map[string]struct{}, len(roles))
+	for _, role := range roles {
+		held[role] = struct{}{}
+	}
+
+	for _, role := range o.DisallowedRoles {
+		if _, ok := granted[role.RoleName()]; ok {
+			return errors.WrapDetf(auth.ErrForbidden, "account has disallowed role: '%s'", role.RoleName()).
+				WithDetail("Access forbidden.")
+		}
+	}
+	if len(o.AllowedRoles) > 0 {
+		var allowed bool
+		for _, role := range o.AllowedRoles {
+			if _, allowed = held[role.RoleName()]; allowed {
+				break
+			}
+		}
+		if !allowed {
+			return errors.WrapDet(auth.ErrForbidden, "account has none of the allowed roles").WithDetail("Access forbidden.")
+		}
+	}
+	if len(o.Scopes) == 0 {
+		return nil
+	}
+
+	scopes := map[string]struct{}{}
+	for _, role := range roles {
+		roleScopes, err := r.roleScopeNames(ctx, role)
+		if err != nil {
+			return err
+		}
+		for _, scope := range roleScopes {
+			scopes[scope] = struct{}{}
+		}
+	}
+	for _, scope := range o.Scopes {
+		if _, ok := scopes[scope.ScopeName()]; !ok {
+			return errors.WrapDetf(auth.ErrForbidden, "account has no scope: '%s'", scope.ScopeName()).
+				WithDetail("Access forbidden.")
+		}
+	}
+	return nil
+}
@@ -34,8 +34,38 @@ type Role interface {
 	RoleName() string
 }
 
-// HierarchicalRole is an interface for the
+// HierarchicalRole is an interface for the roles that have their rank in the roles hierarchy. The role with
+// higher hierarchy value holds the permissions of the roles with lower values.
 type HierarchicalRole interface {
 	Role
 	HierarchyValue() int
 }
+
+// ListRoleAccount sets the account for which the roles would be listed.
+func ListRoleAccount(account Account) ListRoleOption {
+	return func(o *ListRoleOptions) {
+		o.Account = account
+	}
+}
+
+// ListRoleLimit sets the limit of the listed roles.
+func ListRoleLimit(limit int) ListRoleOption {
+	return func(o *ListRoleOptions) {
+		o.Limit = limit
+	}
+}
+
+// ListRoleOffset sets the offset of the listed roles.
+func ListRoleOffset(offset int) ListRoleOption {
+	return func(o *ListRoleOptions) {
+		o.Offset = offset
+	}
+}
+
+// ListRoleSortByHierarchy sets the listed roles to be sorted by their hierarchy values in given 'order'.
+func ListRoleSortByHierarchy(order query.SortOrder) ListRoleOption {
+	return func(o *ListRoleOptions) {
+		o.SortByHierarchy = true
+		o.SortOrder = order
+	}
+}
@@ -29,3 +29,24 @@ type ListScopeOptions struct {
 
 // ListScopeOption is an option function that changes list scope options.
 type ListScopeOption func(o *ListScopeOptions)
+
+// ListScopeRole sets the role for which the scopes would be listed.
+func ListScopeRole(role Role) ListScopeOption {
+	return func(o *ListScopeOptions) {
+		o.Role = role
+	}
+}
+
+// ListScopeLimit sets the limit of the listed scopes.
+func ListScopeLimit(limit int) ListScopeOption {
+	return func(o *ListScopeOptions) {
+		o.Limit = limit
+	}
+}
+
+// ListScopeOffset sets the offset of the listed scopes.
+func ListScopeOffset(offset int) ListScopeOption {
+	return func(o *ListScopeOptions) {
+		o.Offset = offset
+	}
+}
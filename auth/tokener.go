@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"time"
 
@@ -50,6 +51,10 @@ type TokenOptions struct {
 	Issuer string
 	// NotBefore is an option that sets the token to be valid not before provided time.
 	NotBefore time.Time
+	// MFA states that the account satisfied the multi-factor authentication.
+	MFA bool
+	// ClientID is the identifier of the OAuth2 client that the token is issued to.
+	ClientID string
 }
 
 // TokenOption is the token options changer function.
@@ -104,6 +109,20 @@ func TokenWithNotBefore(notBefore time.Time) TokenOption {
 	}
 }
 
+// TokenMFA is the token option that states if the account satisfied the multi-factor authentication.
+func TokenMFA(satisfied bool) TokenOption {
+	return func(o *TokenOptions) {
+		o.MFA = satisfied
+	}
+}
+
+// TokenClientID is the token option that sets the identifier of the client that the token is issued to.
+func TokenClientID(clientID string) TokenOption {
+	return func(o *TokenOptions) {
+		o.ClientID = clientID
+	}
+}
+
 // AccessClaims is an interface used for the access token claims. It should store the whole user account.
 type AccessClaims interface {
 	// GetAccount gets the account stored in given token.
@@ -127,11 +146,21 @@ type NotBeforer interface {
 }
 
 // Scoper is an interface that allows to get Token's authorization scope value. This should return all of the scopes
-// for which the token is authorized, space separated.
+// for which the token is authorized, space separated. An empty scope means that the token has no scope restrictions.
 type Scoper interface {
 	Scope() string
 }
 
+// MFAClaims is an interface that allows to check if the token account satisfied the multi-factor authentication.
+type MFAClaims interface {
+	MFASatisfied() bool
+}
+
+// ClientClaims is an interface that allows to get the identifier of the client that the token was issued to.
+type ClientClaims interface {
+	ClientID() string
+}
+
 // Audiencer is an interface that allows to get token's optional audience value.
 type Audiencer interface {
 	Audience() string
@@ -150,6 +179,20 @@ type SigningMethod interface {
 	Alg() string
 }
 
+// SigningKey is the tokener key identified by its key id. The identifier of the key that signed the token is set
+// in the token 'kid' header.
+type SigningKey struct {
+	// ID is the key identifier (kid).
+	ID string
+	// Key is the HMAC []byte secret or the RSA, ECDSA or Ed25519 private key. The verification keys might also be the public keys.
+	Key interface{}
+	// SigningMethod is the key signing method. If not defined it is chosen with respect to the key.
+	SigningMethod SigningMethod
+	// RetiresAt is the time when the verification key is retired and no longer verifies the tokens.
+	// The zero value never retires.
+	RetiresAt time.Time
+}
+
 // TokenerOptions are the options that defines the settings for the Tokener.
 type TokenerOptions struct {
 	// Model is the account model used by the tokener.
@@ -162,12 +205,23 @@ type TokenerOptions struct {
 	RsaPrivateKey *rsa.PrivateKey
 	// EcdsaPrivateKey is used for encoding the token using ECDSA methods.
 	EcdsaPrivateKey *ecdsa.PrivateKey
+	// Ed25519PrivateKey is used for encoding the token using EdDSA method.
+	Ed25519PrivateKey ed25519.PrivateKey
 	// TokenExpiration is the default token expiration time.
 	TokenExpiration time.Duration
 	// RefreshTokenExpiration is the default refresh token expiration time,.
 	RefreshTokenExpiration time.Duration
 	// SigningMethod is the token signing method.
 	SigningMethod SigningMethod
+	// SigningKey is the primary key used to sign the tokens. If set, the Secret, RsaPrivateKey and EcdsaPrivateKey
+	// are not used.
+	SigningKey *SigningKey
+	// VerificationKeys are the keys used only to verify the tokens, i.e. the previous signing keys.
+	VerificationKeys []*SigningKey
+	// Issuer is the default issuer of the tokens. If set, the inspected tokens are required to have this issuer.
+	Issuer string
+	// Audience is the default audience of the tokens. If set, the inspected tokens are required to have this audience.
+	Audience string
 	// TimeFunc sets the time function for given tokener.
 	TimeFunc func() time.Time
 }
@@ -203,6 +257,27 @@ func TokenerEcdsaPrivateKey(key *ecdsa.PrivateKey) TokenerOption {
 	}
 }
 
+// TokenerEd25519PrivateKey is an option that sets Ed25519PrivateKey in the auth options.
+func TokenerEd25519PrivateKey(key ed25519.PrivateKey) TokenerOption {
+	return func(o *TokenerOptions) {
+		o.Ed25519PrivateKey = key
+	}
+}
+
+// TokenerSigningKey is an option that sets the primary SigningKey in the auth options.
+func TokenerSigningKey(key *SigningKey) TokenerOption {
+	return func(o *TokenerOptions) {
+		o.SigningKey = key
+	}
+}
+
+// TokenerVerificationKeys is an option that adds the VerificationKeys in the auth options.
+func TokenerVerificationKeys(keys ...*SigningKey) TokenerOption {
+	return func(o *TokenerOptions) {
+		o.VerificationKeys = append(o.VerificationKeys, keys...)
+	}
+}
+
 // TokenerTokenExpiration is an option that sets TokenExpiration in the auth options.
 func TokenerTokenExpiration(op time.Duration) TokenerOption {
 	return func(o *TokenerOptions) {
@@ -224,6 +299,20 @@ func TokenerSigningMethod(op SigningMethod) TokenerOption {
 	}
 }
 
+// TokenerIssuer sets the default tokens issuer.
+func TokenerIssuer(issuer string) TokenerOption {
+	return func(o *TokenerOptions) {
+		o.Issuer = issuer
+	}
+}
+
+// TokenerAudience sets the default tokens audience.
+func TokenerAudience(audience string) TokenerOption {
+	return func(o *TokenerOptions) {
+		o.Audience = audience
+	}
+}
+
 // TokenerStore sets the store for the tokener.
 func TokenerStore(s store.Store) TokenerOption {
 	return func(o *TokenerOptions) {
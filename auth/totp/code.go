@@ -0,0 +1,50 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secretEncoding is the base32 encoding of the shared secrets used by the authenticator applications.
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// digitsPower are the modulo of the codes with given number of digits.
+var digitsPower = map[int]uint32{6: 1000000, 7: 10000000, 8: 100000000}
+
+// GenerateCode generates the HOTP code (RFC 4226) for the 'secret' and 'counter' with given number of 'digits'.
+// The TOTP code (RFC 6238) counter is the number of the time steps since the Unix epoch.
+func GenerateCode(secret []byte, counter uint64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+	h := hmac.New(sha1.New, secret)
+	h.Write(msg)
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	code := strconv.FormatUint(uint64(value%digitsPower[digits]), 10)
+	if len(code) < digits {
+		code = strings.Repeat("0", digits-len(code)) + code
+	}
+	return code
+}
+
+// counter gets the time step counter of the time 't'.
+func counter(t time.Time, period time.Duration) uint64 {
+	return uint64(t.Unix() / int64(period/time.Second))
+}
+
+// encodeSecret encodes the 'secret' using base32 encoding without padding.
+func encodeSecret(secret []byte) string {
+	return secretEncoding.EncodeToString(secret)
+}
+
+// decodeSecret decodes the base32 encoded 'secret'.
+func decodeSecret(secret string) ([]byte, error) {
+	return secretEncoding.DecodeString(strings.ToUpper(secret))
+}
@@ -0,0 +1,36 @@
+package totp
+
+import (
+	"time"
+)
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=Factor,RecoveryCode .
+
+// Factor is the model of the account TOTP second factor. Its primary key is the account primary key string value.
+// The factor is not used for the verification until it is confirmed with the valid code.
+type Factor struct {
+	ID string
+	// Secret is the base32 encoded shared secret of the factor. It is never marshaled by the codecs.
+	Secret      string `codec:"-"`
+	CreatedAt   time.Time
+	ConfirmedAt time.Time
+}
+
+// IsConfirmed checks if the factor enrollment is confirmed.
+func (f *Factor) IsConfirmed() bool {
+	return !f.ConfirmedAt.IsZero()
+}
+
+// RecoveryCode is the model of the account one-time recovery code. Only the hash of the code is stored.
+type RecoveryCode struct {
+	ID        int
+	AccountID string `db:";index"`
+	// Hash is the hash of the recovery code. It is never marshaled by the codecs.
+	Hash   string `codec:"-"`
+	UsedAt time.Time
+}
+
+// IsUsed checks if the recovery code was already used.
+func (r *RecoveryCode) IsUsed() bool {
+	return !r.UsedAt.IsZero()
+}
@@ -0,0 +1,98 @@
+package totp
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/store"
+)
+
+// Options are the TOTP manager options.
+type Options struct {
+	// Issuer is the name of the service that issues the factors. It is a part of the otpauth URI.
+	Issuer string
+	// Digits is the number of the code digits.
+	Digits int
+	// Period is the time step of the codes.
+	Period time.Duration
+	// Skew is the number of the time steps before and after the current one, within which the codes are valid.
+	Skew int
+	// SecretLength is the number of the random bytes of the shared secret.
+	SecretLength int
+	// RecoveryCodes is the number of the recovery codes generated for the account.
+	RecoveryCodes int
+	// Store keeps the used codes, so that they could not be replayed. The store needs to implement
+	// store.ConditionalSetter. If not set, the in-memory store is used.
+	Store store.Store
+	// TimeFunc is the time function used to compute the codes.
+	TimeFunc func() time.Time
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		Digits:        6,
+		Period:        30 * time.Second,
+		Skew:          1,
+		SecretLength:  20,
+		RecoveryCodes: 10,
+		TimeFunc:      time.Now,
+	}
+}
+
+// Option is a function that sets the TOTP manager options.
+type Option func(o *Options)
+
+// WithIssuer sets the name of the service that issues the factors.
+func WithIssuer(issuer string) Option {
+	return func(o *Options) {
+		o.Issuer = issuer
+	}
+}
+
+// WithDigits sets the number of the code digits. It must be 6, 7 or 8.
+func WithDigits(digits int) Option {
+	return func(o *Options) {
+		o.Digits = digits
+	}
+}
+
+// WithPeriod sets the time step of the codes.
+func WithPeriod(period time.Duration) Option {
+	return func(o *Options) {
+		o.Period = period
+	}
+}
+
+// WithSkew sets the number of the time steps before and after the current one, within which the codes are valid.
+func WithSkew(skew int) Option {
+	return func(o *Options) {
+		o.Skew = skew
+	}
+}
+
+// WithSecretLength sets the number of the random bytes of the shared secret.
+func WithSecretLength(length int) Option {
+	return func(o *Options) {
+		o.SecretLength = length
+	}
+}
+
+// WithRecoveryCodes sets the number of the recovery codes generated for the account.
+func WithRecoveryCodes(count int) Option {
+	return func(o *Options) {
+		o.RecoveryCodes = count
+	}
+}
+
+// WithStore sets the store of the used codes.
+func WithStore(s store.Store) Option {
+	return func(o *Options) {
+		o.Store = s
+	}
+}
+
+// WithTimeFunc sets the time function of the manager.
+func WithTimeFunc(tf func() time.Time) Option {
+	return func(o *Options) {
+		o.TimeFunc = tf
+	}
+}
@@ -0,0 +1,376 @@
+// Package totp implements the optional TOTP (RFC 6238) second factor of the accounts. The account enrolls the factor
+// by adding the shared secret, usually with the otpauth URI, to its authenticator application and confirming it with
+// the first valid code. On confirmation the account receives the one-time recovery codes, which hashes are stored in
+// the database. The used codes are kept in the store until they expire, so that they could not be replayed.
+// The Manager implements auth.SecondFactor, so that it could be used by the OAuth2 server password grant, which
+// issues the tokens with the auth.TokenMFA option after successful verification.
+package totp
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/store"
+	"github.com/neuronlabs/neuron/store/memory"
+)
+
+const (
+	// usedCodeKeyPrefix is the store key prefix of the used codes.
+	usedCodeKeyPrefix = "neuron_totp_used:"
+	// recoveryCodeLength is the number of the recovery code characters.
+	recoveryCodeLength = 10
+)
+
+// Enrollment is the result of the factor enrollment. The secret and the URI should be presented to the account
+// only once.
+type Enrollment struct {
+	// Secret is the base32 encoded shared secret.
+	Secret string
+	// URI is the otpauth URI of the factor, usually presented as a QR code.
+	URI string
+}
+
+// Compile time check for the auth.SecondFactor interface.
+var _ auth.SecondFactor = &Manager{}
+
+// Manager enrolls, confirms and verifies the accounts TOTP factors and their recovery codes.
+type Manager struct {
+	Options *Options
+
+	db            database.DB
+	factors       *mapping.ModelStruct
+	recoveryCodes *mapping.ModelStruct
+	usedCodes     store.ConditionalSetter
+}
+
+// New creates new TOTP manager for the 'db'. The Factor and RecoveryCode models are registered in the database
+// model map if they are not yet registered. The options store needs to implement store.ConditionalSetter, so that
+// the used codes are marked atomically.
+func New(db database.DB, options ...Option) (*Manager, error) {
+	o := defaultOptions()
+	for _, option := range options {
+		option(o)
+	}
+	if _, ok := digitsPower[o.Digits]; !ok {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "unsupported number of TOTP digits: '%d'", o.Digits)
+	}
+	if o.Period < time.Second || o.Period%time.Second != 0 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "TOTP period: '%s' must be a positive number of seconds", o.Period)
+	}
+	if o.Skew < 0 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "TOTP skew: '%d' cannot be negative", o.Skew)
+	}
+	if o.SecretLength < 16 {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "TOTP secret length: '%d' is too short", o.SecretLength)
+	}
+	if o.Store == nil {
+		o.Store = memory.New()
+	}
+	usedCodes, ok := o.Store.(store.ConditionalSetter)
+	if !ok {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "TOTP store: '%T' doesn't implement store.ConditionalSetter", o.Store)
+	}
+	m := &Manager{Options: o, db: db, usedCodes: usedCodes}
+	var err error
+	if m.factors, err = db.ModelMap().ModelStruct(&Factor{}); err != nil {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "registering TOTP factor model failed: %v", err)
+	}
+	if m.recoveryCodes, err = db.ModelMap().ModelStruct(&RecoveryCode{}); err != nil {
+		return nil, errors.WrapDetf(auth.ErrInitialization, "registering TOTP recovery code model failed: %v", err)
+	}
+	return m, nil
+}
+
+// Enroll creates new shared secret for the 'account' factor. The factor needs to be confirmed before it is used.
+// Enrolling the account again before the confirmation replaces the secret.
+func (m *Manager) Enroll(ctx context.Context, account auth.Account) (*Enrollment, error) {
+	id, err := accountID(account)
+	if err != nil {
+		return nil, err
+	}
+	factor, err := m.factor(ctx, id)
+	switch {
+	case err == nil && factor.IsConfirmed():
+		return nil, errors.WrapDet(auth.ErrMFAAlreadyEnrolled, "account TOTP factor is already confirmed").
+			WithDetail("The second factor is already enrolled.")
+	case err != nil && !errors.Is(err, query.ErrNoResult):
+		return nil, err
+	}
+	secret, err := auth.GenerateSalt(m.Options.SecretLength)
+	if err != nil {
+		return nil, err
+	}
+	if factor != nil {
+		factor.Secret = encodeSecret(secret)
+		err = m.updateFields(ctx, m.factors, factor, "Secret")
+	} else {
+		factor = &Factor{ID: id, Secret: encodeSecret(secret)}
+		err = m.db.Insert(database.CtxWithSkipAuthorization(ctx), m.factors, factor)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Enrollment{Secret: factor.Secret, URI: m.uri(account, factor.Secret)}, nil
+}
+
+// Confirm confirms the 'account' factor enrollment with the 'code' and generates the recovery codes.
+func (m *Manager) Confirm(ctx context.Context, account auth.Account, code string) ([]string, error) {
+	id, err := accountID(account)
+	if err != nil {
+		return nil, err
+	}
+	factor, err := m.enrolledFactor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if factor.IsConfirmed() {
+		return nil, errors.WrapDet(auth.ErrMFAAlreadyEnrolled, "account TOTP factor is already confirmed").
+			WithDetail("The second factor is already enrolled.")
+	}
+	if err = m.verifyCode(ctx, factor, code); err != nil {
+		return nil, err
+	}
+	factor.ConfirmedAt = m.Options.TimeFunc()
+	if err = m.updateFields(ctx, m.factors, factor, "ConfirmedAt"); err != nil {
+		return nil, err
+	}
+	return m.RegenerateRecoveryCodes(ctx, account)
+}
+
+// IsEnrolled checks if the 'account' has confirmed TOTP factor.
+func (m *Manager) IsEnrolled(ctx context.Context, account auth.Account) (bool, error) {
+	id, err := accountID(account)
+	if err != nil {
+		return false, err
+	}
+	factor, err := m.factor(ctx, id)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return false, nil
+		}
+		return false, err
+	}
+	return factor.IsConfirmed(), nil
+}
+
+// Verify verifies the 'code' of the 'account' confirmed factor. The code is valid within the options skew time
+// steps and could be used only once.
+func (m *Manager) Verify(ctx context.Context, account auth.Account, code string) error {
+	id, err := accountID(account)
+	if err != nil {
+		return err
+	}
+	factor, err := m.confirmedFactor(ctx, id)
+	if err != nil {
+		return err
+	}
+	return m.verifyCode(ctx, factor, code)
+}
+
+// Recover verifies the 'account' one-time recovery 'code'. The code is marked as used with the update conditioned
+// on the code not being used yet, so that it could not be redeemed twice by the concurrent requests.
+func (m *Manager) Recover(ctx context.Context, account auth.Account, code string) error {
+	id, err := accountID(account)
+	if err != nil {
+		return err
+	}
+	hash, err := hashRecoveryCode(code)
+	if err != nil {
+		return err
+	}
+	model, err := m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.recoveryCodes).Where("AccountID = ?", id).Where("Hash = ?", hash).Get()
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return errors.WrapDet(auth.ErrInvalidOTP, "invalid recovery code").WithDetail("The recovery code is not valid.")
+		}
+		return err
+	}
+	recoveryCode := model.(*RecoveryCode)
+	if recoveryCode.IsUsed() {
+		return errRecoveryCodeUsed()
+	}
+	affected, err := m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.recoveryCodes, &RecoveryCode{UsedAt: m.Options.TimeFunc()}).
+		Select(m.recoveryCodes.MustFieldByName("UsedAt")).
+		Where("ID = ?", recoveryCode.ID).
+		Where("UsedAt = ?", time.Time{}).
+		Update()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errRecoveryCodeUsed()
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the 'account' confirmed factor. The returned codes are
+// not stored and could not be restored later.
+func (m *Manager) RegenerateRecoveryCodes(ctx context.Context, account auth.Account) ([]string, error) {
+	id, err := accountID(account)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = m.confirmedFactor(ctx, id); err != nil {
+		return nil, err
+	}
+	if _, err = m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.recoveryCodes).Where("AccountID = ?", id).Delete(); err != nil {
+		return nil, err
+	}
+	codes := make([]string, m.Options.RecoveryCodes)
+	models := make([]mapping.Model, m.Options.RecoveryCodes)
+	for i := range codes {
+		random, err := auth.GenerateSalt(recoveryCodeLength)
+		if err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(encodeSecret(random))[:recoveryCodeLength]
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+		hash, err := hashRecoveryCode(code)
+		if err != nil {
+			return nil, err
+		}
+		models[i] = &RecoveryCode{AccountID: id, Hash: hash}
+	}
+	if len(models) > 0 {
+		if err = m.db.Insert(database.CtxWithSkipAuthorization(ctx), m.recoveryCodes, models...); err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
+
+// Disable removes the 'account' factor and its recovery codes.
+func (m *Manager) Disable(ctx context.Context, account auth.Account) error {
+	id, err := accountID(account)
+	if err != nil {
+		return err
+	}
+	if _, err = m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.recoveryCodes).Where("AccountID = ?", id).Delete(); err != nil {
+		return err
+	}
+	_, err = m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.factors).Filter(filter.New(m.factors.Primary(), filter.OpEqual, id)).Delete()
+	return err
+}
+
+// verifyCode checks the 'code' against the 'factor' codes within the skew time steps. The matching code is
+// atomically stored until it expires, so that it could not be used again.
+func (m *Manager) verifyCode(ctx context.Context, factor *Factor, code string) error {
+	if len(code) != m.Options.Digits {
+		return errors.WrapDet(auth.ErrInvalidOTP, "invalid code length").WithDetail("The code is not valid.")
+	}
+	secret, err := decodeSecret(factor.Secret)
+	if err != nil {
+		return errors.WrapDetf(auth.ErrInternalError, "decoding account: '%s' TOTP secret failed: %v", factor.ID, err)
+	}
+	now := m.Options.TimeFunc()
+	current := int64(counter(now, m.Options.Period))
+	for step := current - int64(m.Options.Skew); step <= current+int64(m.Options.Skew); step++ {
+		if step < 0 || !hmac.Equal([]byte(GenerateCode(secret, uint64(step), m.Options.Digits)), []byte(code)) {
+			continue
+		}
+		key := usedCodeKeyPrefix + factor.ID + ":" + strconv.FormatInt(step, 10)
+		// The code is valid until its step leaves the skew window.
+		expiresAt := time.Unix((step+int64(m.Options.Skew)+1)*int64(m.Options.Period/time.Second), 0)
+		err = m.usedCodes.SetIfNotExists(ctx, &store.Record{Key: key}, store.SetWithTTL(expiresAt.Sub(now)))
+		if errors.Is(err, store.ErrPreconditionFailed) {
+			return errors.WrapDet(auth.ErrOTPReused, "code is already used").WithDetail("The code is already used.")
+		}
+		return err
+	}
+	return errors.WrapDet(auth.ErrInvalidOTP, "invalid code").WithDetail("The code is not valid.")
+}
+
+// uri creates the otpauth URI of the 'account' factor with the 'secret'.
+func (m *Manager) uri(account auth.Account, secret string) string {
+	label := account.GetUsername()
+	values := url.Values{}
+	values.Set("secret", secret)
+	if m.Options.Issuer != "" {
+		label = m.Options.Issuer + ":" + label
+		values.Set("issuer", m.Options.Issuer)
+	}
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", strconv.Itoa(m.Options.Digits))
+	values.Set("period", strconv.Itoa(int(m.Options.Period/time.Second)))
+	u := url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + label, RawQuery: values.Encode()}
+	return u.String()
+}
+
+// factor gets the factor of the account with the 'id'.
+func (m *Manager) factor(ctx context.Context, id string) (*Factor, error) {
+	model, err := m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), m.factors).Filter(filter.New(m.factors.Primary(), filter.OpEqual, id)).Get()
+	if err != nil {
+		return nil, err
+	}
+	return model.(*Factor), nil
+}
+
+// enrolledFactor gets the factor of the account with the 'id'. If the account has no factor an error is returned.
+func (m *Manager) enrolledFactor(ctx context.Context, id string) (*Factor, error) {
+	factor, err := m.factor(ctx, id)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, errors.WrapDet(auth.ErrMFANotEnrolled, "account has no TOTP factor").
+				WithDetail("The second factor is not enrolled.")
+		}
+		return nil, err
+	}
+	return factor, nil
+}
+
+// confirmedFactor gets the confirmed factor of the account with the 'id'.
+func (m *Manager) confirmedFactor(ctx context.Context, id string) (*Factor, error) {
+	factor, err := m.enrolledFactor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !factor.IsConfirmed() {
+		return nil, errors.WrapDet(auth.ErrMFANotEnrolled, "account TOTP factor is not confirmed").
+			WithDetail("The second factor is not enrolled.")
+	}
+	return factor, nil
+}
+
+// updateFields updates the 'model' fields with provided names.
+func (m *Manager) updateFields(ctx context.Context, mStruct *mapping.ModelStruct, model mapping.Model, fieldNames ...string) error {
+	fields := make([]*mapping.StructField, len(fieldNames))
+	for i, name := range fieldNames {
+		fields[i] = mStruct.MustFieldByName(name)
+	}
+	_, err := m.db.QueryCtx(database.CtxWithSkipAuthorization(ctx), mStruct, model).Select(fields...).Update()
+	return err
+}
+
+// accountID gets the 'account' primary key string value.
+func accountID(account auth.Account) (string, error) {
+	if account == nil || account.IsPrimaryKeyZero() {
+		return "", errors.WrapDet(auth.ErrAccountNotValid, "the account has no primary key")
+	}
+	return account.GetPrimaryKeyStringValue()
+}
+
+// errRecoveryCodeUsed creates the error of the already used recovery code.
+func errRecoveryCodeUsed() error {
+	return errors.WrapDet(auth.ErrOTPReused, "recovery code is already used").WithDetail("The recovery code is already used.")
+}
+
+// hashRecoveryCode creates the hex encoded SHA256 hash of the normalized recovery 'code'.
+func hashRecoveryCode(code string) (string, error) {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	hash, err := auth.NewPassword(code).SHA256(nil)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash), nil
+}
@@ -0,0 +1,176 @@
+package totp
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	cjson "github.com/neuronlabs/neuron/codec/json"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+	"github.com/neuronlabs/neuron/store"
+	"github.com/neuronlabs/neuron/store/memory"
+)
+
+// TestGenerateCode checks the codes with the RFC 6238 SHA1 test vectors.
+func TestGenerateCode(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+	for unix, expected := range vectors {
+		assert.Equal(t, expected, GenerateCode(secret, counter(time.Unix(unix, 0), 30*time.Second), 8), unix)
+	}
+	assert.Equal(t, "287082", GenerateCode(secret, 1, 6))
+}
+
+func testManager(t *testing.T, options ...Option) (*Manager, *testmodels.User) {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(&testmodels.User{}))
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+
+	manager, err := New(db, options...)
+	require.NoError(t, err)
+	user := &testmodels.User{Username: "user"}
+	require.NoError(t, db.Insert(context.Background(), db.ModelMap().MustModelStruct(user), user))
+	return manager, user
+}
+
+func TestManager(t *testing.T) {
+	now := time.Unix(1603357200, 0)
+	manager, user := testManager(t, WithIssuer("Neuron"), WithTimeFunc(func() time.Time { return now }))
+	ctx := context.Background()
+
+	enrolled, err := manager.IsEnrolled(ctx, user)
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+
+	// The enrollment could be repeated until it is confirmed.
+	_, err = manager.Enroll(ctx, user)
+	require.NoError(t, err)
+	enrollment, err := manager.Enroll(ctx, user)
+	require.NoError(t, err)
+
+	uri, err := url.Parse(enrollment.URI)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Neuron:user", uri.Path)
+	assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+	assert.Equal(t, "Neuron", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+
+	secret, err := decodeSecret(enrollment.Secret)
+	require.NoError(t, err)
+	code := func(t time.Time) string {
+		return GenerateCode(secret, counter(t, 30*time.Second), 6)
+	}
+
+	err = manager.Verify(ctx, user, code(now))
+	assert.True(t, errors.Is(err, auth.ErrMFANotEnrolled))
+
+	_, err = manager.Confirm(ctx, user, "000000")
+	assert.True(t, errors.Is(err, auth.ErrInvalidOTP))
+	recoveryCodes, err := manager.Confirm(ctx, user, code(now))
+	require.NoError(t, err)
+	assert.Len(t, recoveryCodes, 10)
+
+	c := cjson.New(manager.db.ModelMap())
+	marshaled, err := c.MarshalModel(&Factor{ID: "1", Secret: enrollment.Secret})
+	require.NoError(t, err)
+	assert.NotContains(t, string(marshaled), enrollment.Secret)
+	marshaled, err = c.MarshalModel(&RecoveryCode{ID: 1, AccountID: "1", Hash: "hash"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(marshaled), "hash")
+
+	enrolled, err = manager.IsEnrolled(ctx, user)
+	require.NoError(t, err)
+	assert.True(t, enrolled)
+	_, err = manager.Enroll(ctx, user)
+	assert.True(t, errors.Is(err, auth.ErrMFAAlreadyEnrolled))
+
+	t.Run("Verify", func(t *testing.T) {
+		// The confirmation code cannot be replayed.
+		err := manager.Verify(ctx, user, code(now))
+		assert.True(t, errors.Is(err, auth.ErrOTPReused))
+
+		now = now.Add(30 * time.Second)
+		require.NoError(t, manager.Verify(ctx, user, code(now)))
+		assert.True(t, errors.Is(manager.Verify(ctx, user, code(now)), auth.ErrOTPReused))
+
+		// The codes within the skew window are valid.
+		require.NoError(t, manager.Verify(ctx, user, code(now.Add(30*time.Second))))
+		err = manager.Verify(ctx, user, code(now.Add(time.Minute)))
+		assert.True(t, errors.Is(err, auth.ErrInvalidOTP))
+		assert.False(t, errors.Is(err, auth.ErrOTPReused))
+
+		err = manager.Verify(ctx, user, "12345")
+		assert.True(t, errors.Is(err, auth.ErrInvalidOTP))
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		require.NoError(t, manager.Recover(ctx, user, recoveryCodes[0]))
+		err := manager.Recover(ctx, user, recoveryCodes[0])
+		assert.True(t, errors.Is(err, auth.ErrOTPReused))
+
+		// The recovery codes are normalized.
+		require.NoError(t, manager.Recover(ctx, user, " "+recoveryCodes[1][:5]+recoveryCodes[1][6:]))
+		err = manager.Recover(ctx, user, "invalid")
+		assert.True(t, errors.Is(err, auth.ErrInvalidOTP))
+
+		regenerated, err := manager.RegenerateRecoveryCodes(ctx, user)
+		require.NoError(t, err)
+		err = manager.Recover(ctx, user, recoveryCodes[2])
+		assert.True(t, errors.Is(err, auth.ErrInvalidOTP))
+		require.NoError(t, manager.Recover(ctx, user, regenerated[2]))
+
+		// The code redeemed by another request after it was read is not accepted again.
+		timeFunc := manager.Options.TimeFunc
+		var concurrent error
+		manager.Options.TimeFunc = func() time.Time {
+			manager.Options.TimeFunc = timeFunc
+			concurrent = manager.Recover(ctx, user, regenerated[3])
+			return timeFunc()
+		}
+		err = manager.Recover(ctx, user, regenerated[3])
+		require.NoError(t, concurrent)
+		assert.True(t, errors.Is(err, auth.ErrOTPReused))
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		require.NoError(t, manager.Disable(ctx, user))
+		enrolled, err := manager.IsEnrolled(ctx, user)
+		require.NoError(t, err)
+		assert.False(t, enrolled)
+		err = manager.Verify(ctx, user, code(now))
+		assert.True(t, errors.Is(err, auth.ErrMFANotEnrolled))
+	})
+}
+
+func TestNew(t *testing.T) {
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()))
+	require.NoError(t, err)
+	// The store without the conditional set could not mark the used codes atomically.
+	nonConditional := struct{ store.Store }{memory.New()}
+	for _, option := range []Option{WithDigits(5), WithPeriod(1500 * time.Millisecond), WithSkew(-1), WithSecretLength(10), WithStore(nonConditional)} {
+		_, err = New(db, option)
+		assert.True(t, errors.Is(err, auth.ErrInitialization))
+	}
+}
@@ -15,6 +15,8 @@ type VerifyOptions struct {
 	AllowedRoles    []Role
 	DisallowedRoles []Role
 	Scopes          []Scope
+	// RequireMFA requires the context token claims to satisfy the multi-factor authentication.
+	RequireMFA bool
 }
 
 // VerifyOption is an option used for the verification.
@@ -34,6 +36,13 @@ func VerifyDisallowedRoles(disallowedRoles ...Role) VerifyOption {
 	}
 }
 
+// VerifyRequireMFA requires the multi-factor authentication to be satisfied by the context token claims.
+func VerifyRequireMFA() VerifyOption {
+	return func(o *VerifyOptions) {
+		o.RequireMFA = true
+	}
+}
+
 // VerifyScopes sets the verify options scopes.
 func VerifyScopes(scopes ...Scope) VerifyOption {
 	return func(o *VerifyOptions) {
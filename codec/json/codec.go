@@ -0,0 +1,75 @@
+// Package json contains the flat JSON codec implementation. The models are marshaled as plain JSON objects
+// with the fields codec names as the object keys. The relationships are marshaled as the primary key values
+// of the related models or, if the relation was included, as the embedded related objects.
+package json
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// MimeType is the JSON media type.
+const MimeType = "application/json"
+
+// Compile time check for the codec interfaces.
+var (
+	_ codec.Codec              = &Codec{}
+	_ codec.ModelMarshaler     = &Codec{}
+	_ codec.ModelUnmarshaler   = &Codec{}
+	_ codec.PayloadMarshaler   = &Codec{}
+	_ codec.PayloadUnmarshaler = &Codec{}
+)
+
+// Codec is the flat JSON codec implementation.
+type Codec struct {
+	// ModelMap is used to get the model struct for the marshaled models and the models provided
+	// in the unmarshal options.
+	ModelMap *mapping.ModelMap
+}
+
+// New creates new flat JSON codec for provided model map.
+func New(m *mapping.ModelMap) *Codec {
+	return &Codec{ModelMap: m}
+}
+
+// MimeType implements codec.Codec interface.
+func (c *Codec) MimeType() string {
+	return MimeType
+}
+
+// errorsDocument is the document used to marshal the errors.
+type errorsDocument struct {
+	Errors []*codec.Error `json:"errors"`
+}
+
+// MarshalErrors implements codec.Codec interface. The errors are written within the 'errors' object member.
+func (c *Codec) MarshalErrors(w io.Writer, errs ...*codec.Error) error {
+	if err := json.NewEncoder(w).Encode(&errorsDocument{Errors: errs}); err != nil {
+		return errors.WrapDetf(codec.ErrMarshal, "marshaling errors failed: %v", err)
+	}
+	return nil
+}
+
+// UnmarshalErrors implements codec.Codec interface.
+func (c *Codec) UnmarshalErrors(r io.Reader) (codec.MultiError, error) {
+	doc := errorsDocument{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "unmarshaling errors failed: %v", err)
+	}
+	if len(doc.Errors) == 0 {
+		return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "no errors in the document")
+	}
+	return doc.Errors, nil
+}
+
+// modelStruct gets the model struct for provided 'model' from the codec's model map.
+func (c *Codec) modelStruct(model mapping.Model) (*mapping.ModelStruct, error) {
+	if c.ModelMap == nil {
+		return nil, errors.WrapDet(codec.ErrOptions, "no model map defined for the codec")
+	}
+	return c.ModelMap.ModelStruct(model)
+}
@@ -0,0 +1,138 @@
+package json
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+func testCodec(t *testing.T) *Codec {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(testmodels.Neuron_Models...))
+	return New(m)
+}
+
+func relation(t *testing.T, mStruct *mapping.ModelStruct, name string) *mapping.StructField {
+	t.Helper()
+	field, ok := mStruct.RelationByName(name)
+	require.True(t, ok)
+	return field
+}
+
+func TestMarshal(t *testing.T) {
+	c := testCodec(t)
+	mStruct := c.ModelMap.MustModelStruct(&testmodels.Blog{})
+	postStruct := c.ModelMap.MustModelStruct(&testmodels.Post{})
+	createdAt := time.Date(2020, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	post := &testmodels.Post{ID: 3, Title: "post", Comments: []*testmodels.Comment{{ID: 5}}}
+	blog := &testmodels.Blog{ID: 1, Title: "first", CreatedAt: createdAt, Posts: []*testmodels.Post{post}, CurrentPost: post, CurrentPostID: 3}
+
+	t.Run("IDs", func(t *testing.T) {
+		data, err := c.MarshalModel(blog)
+		require.NoError(t, err)
+		expected := `{"id": 1, "title": "first", "current_post_id": 3, "created_at": "2020-10-01T12:30:00Z", "view_count": 0, "posts": [3], "current_post": 3}`
+		assert.JSONEq(t, expected, string(data))
+	})
+
+	t.Run("Included", func(t *testing.T) {
+		payload := &codec.Payload{
+			ModelStruct: mStruct,
+			Data:        []mapping.Model{blog},
+			FieldSets:   []mapping.FieldSet{{mStruct.Primary(), mStruct.MustFieldByName("Title")}},
+			IncludedRelations: []*query.IncludedRelation{{
+				StructField: relation(t, mStruct, "Posts"),
+				Fieldset:    mapping.FieldSet{postStruct.Primary(), postStruct.MustFieldByName("Title"), relation(t, postStruct, "Comments")},
+			}},
+		}
+		buf := &bytes.Buffer{}
+		require.NoError(t, c.MarshalPayload(buf, payload))
+		assert.JSONEq(t, `[{"id": 1, "title": "first", "posts": [{"id": 3, "title": "post", "comments": [5]}]}]`, buf.String())
+
+		data, err := c.MarshalModels([]mapping.Model{blog}, codec.MarshalWithIncludedRelations(&query.IncludedRelation{
+			StructField: relation(t, mStruct, "CurrentPost"),
+			Fieldset:    mapping.FieldSet{postStruct.Primary()},
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"current_post":{"id":3}`)
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		data, err := c.MarshalModel(&testmodels.TestingModel{ID: 2, Nested: &testmodels.FilterNestedModel{Field: "value"}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": 2, "attr": "", "foreign_key": 0, "nested": {"field": "value"}, "relation": null}`, string(data))
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	c := testCodec(t)
+	mStruct := c.ModelMap.MustModelStruct(&testmodels.Blog{})
+
+	t.Run("Models", func(t *testing.T) {
+		input := `[{"id": 1, "title": "first", "created_at": "2020-10-01T12:30:00Z", "posts": [3, {"id": 4, "title": "post"}], "current_post": null, "unknown": 1}, {"view_count": 3}]`
+		payload, err := c.UnmarshalPayload(strings.NewReader(input), codec.UnmarshalWithModelStruct(mStruct))
+		require.NoError(t, err)
+		require.Len(t, payload.Data, 2)
+
+		blog := payload.Data[0].(*testmodels.Blog)
+		assert.Equal(t, 1, blog.ID)
+		assert.Equal(t, time.Date(2020, 10, 1, 12, 30, 0, 0, time.UTC), blog.CreatedAt)
+		require.Len(t, blog.Posts, 2)
+		assert.Equal(t, uint64(3), blog.Posts[0].ID)
+		assert.Equal(t, "post", blog.Posts[1].Title)
+		assert.Len(t, payload.FieldSets[0], 5)
+		assert.Equal(t, mapping.FieldSet{mStruct.MustFieldByName("ViewCount")}, payload.FieldSets[1])
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		model, err := c.UnmarshalModel([]byte(`{"id": 2, "nested": {"field": "value"}, "relation": 3}`),
+			codec.UnmarshalWithModelStruct(c.ModelMap.MustModelStruct(&testmodels.TestingModel{})), codec.UnmarshalStrictly())
+		require.NoError(t, err)
+		tm := model.(*testmodels.TestingModel)
+		require.NotNil(t, tm.Nested)
+		assert.Equal(t, "value", tm.Nested.Field)
+		require.NotNil(t, tm.Relation)
+		assert.Equal(t, 3, tm.Relation.ID)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		_, err := c.UnmarshalModel([]byte(`{"title": "first", "unknown": 1}`), codec.UnmarshalWithModelStruct(mStruct), codec.UnmarshalStrictly())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, codec.ErrUnmarshalFieldName))
+
+		_, err = c.UnmarshalModel([]byte(`{"nested": {"unknown": 1}}`),
+			codec.UnmarshalWithModelStruct(c.ModelMap.MustModelStruct(&testmodels.TestingModel{})), codec.UnmarshalStrictly())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, codec.ErrUnmarshalFieldName))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := c.UnmarshalModel([]byte(`{"title": 1}`), codec.UnmarshalWithModelStruct(mStruct))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, codec.ErrUnmarshalFieldValue))
+
+		_, err = c.UnmarshalModel([]byte(`[{"title": "first"}]`), codec.UnmarshalWithModelStruct(mStruct))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, codec.ErrUnmarshalDocument))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, c.MarshalErrors(buf, &codec.Error{Status: "404", Title: "not found"}))
+		assert.JSONEq(t, `{"errors": [{"status": "404", "title": "not found"}]}`, buf.String())
+		errs, err := c.UnmarshalErrors(buf)
+		require.NoError(t, err)
+		assert.Equal(t, 404, errs.Status())
+	})
+}
@@ -0,0 +1,212 @@
+package json
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+var null = json.RawMessage("null")
+
+// MarshalModels implements codec.ModelMarshaler interface. The models are marshaled as the JSON array.
+func (c *Codec) MarshalModels(models []mapping.Model, options ...codec.MarshalOption) ([]byte, error) {
+	payload := &codec.Payload{Data: models}
+	if len(models) > 0 {
+		mStruct, err := c.modelStruct(models[0])
+		if err != nil {
+			return nil, err
+		}
+		payload.ModelStruct = mStruct
+	}
+	return c.marshalPayload(payload, options)
+}
+
+// MarshalModel implements codec.ModelMarshaler interface. The model is marshaled as the JSON object.
+func (c *Codec) MarshalModel(model mapping.Model, options ...codec.MarshalOption) ([]byte, error) {
+	mStruct, err := c.modelStruct(model)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, codec.MarshalSingleModel())
+	return c.marshalPayload(&codec.Payload{ModelStruct: mStruct, Data: []mapping.Model{model}}, options)
+}
+
+// MarshalPayload implements codec.PayloadMarshaler interface. Only the payload data is marshaled - the flat JSON
+// has no place for the meta and links.
+func (c *Codec) MarshalPayload(w io.Writer, payload *codec.Payload, options ...codec.MarshalOption) error {
+	data, err := c.marshalPayload(payload, options)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(data); err != nil {
+		return errors.WrapDetf(codec.ErrMarshal, "writing payload failed: %v", err)
+	}
+	return nil
+}
+
+func (c *Codec) marshalPayload(payload *codec.Payload, options []codec.MarshalOption) ([]byte, error) {
+	o := &codec.MarshalOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if payload.ModelStruct == nil && len(payload.Data) > 0 {
+		return nil, errors.WrapDet(codec.ErrMarshalPayload, "no model struct defined for the payload")
+	}
+	includes := payload.IncludedRelations
+	if len(includes) == 0 {
+		includes = o.IncludedRelations
+	}
+
+	objects := make([]object, len(payload.Data))
+	for i, model := range payload.Data {
+		var fieldSet mapping.FieldSet
+		switch len(payload.FieldSets) {
+		case 0:
+		case 1:
+			fieldSet = payload.FieldSets[0]
+		case len(payload.Data):
+			fieldSet = payload.FieldSets[i]
+		default:
+			return nil, errors.WrapDetf(codec.ErrMarshalPayload, "payload field sets length: %d doesn't match data length: %d", len(payload.FieldSets), len(payload.Data))
+		}
+		var err error
+		if objects[i], err = marshalModel(payload.ModelStruct, model, fieldSet, includes); err != nil {
+			return nil, err
+		}
+	}
+
+	var value interface{} = objects
+	if o.SingleResult {
+		switch len(objects) {
+		case 0:
+			return null, nil
+		case 1:
+			value = objects[0]
+		default:
+			return nil, errors.WrapDetf(codec.ErrMarshalPayload, "single result payload contains: %d models", len(objects))
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.WrapDetf(codec.ErrMarshalPayload, "marshaling payload failed: %v", err)
+	}
+	return data, nil
+}
+
+// marshalModel creates the JSON object for the 'model'. If the 'fieldSet' is empty all the fields and relationships
+// are marshaled. The relations from the 'includes' are always marshaled as the embedded objects.
+func marshalModel(mStruct *mapping.ModelStruct, model mapping.Model, fieldSet mapping.FieldSet, includes []*query.IncludedRelation) (object, error) {
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement mapping.Fielder interface", mStruct)
+	}
+	if len(fieldSet) == 0 {
+		fieldSet = append(append(mapping.FieldSet{}, mStruct.Fields()...), mStruct.RelationFields()...)
+	}
+	included := map[*mapping.StructField]*query.IncludedRelation{}
+	for _, include := range includes {
+		included[include.StructField] = include
+		if !fieldSet.Contains(include.StructField) {
+			fieldSet = append(fieldSet[:len(fieldSet):len(fieldSet)], include.StructField)
+		}
+	}
+
+	obj := make(object, 0, len(fieldSet))
+	for _, field := range fieldSet {
+		if field.CodecSkip() {
+			continue
+		}
+		var (
+			value interface{}
+			err   error
+		)
+		switch field.Kind() {
+		case mapping.KindPrimary, mapping.KindAttribute, mapping.KindForeignKey:
+			if field.CodecOmitEmpty() {
+				zero, err := fielder.IsFieldZero(field)
+				if err != nil {
+					return nil, err
+				}
+				if zero {
+					continue
+				}
+			}
+			if value, err = fielder.GetFieldValue(field); err != nil {
+				return nil, err
+			}
+			if field.Nested() != nil {
+				value, err = marshalNested(field.Nested(), reflect.ValueOf(value))
+			} else {
+				value = fieldValue(field, value)
+			}
+		case mapping.KindRelationshipSingle, mapping.KindRelationshipMultiple:
+			value, err = marshalRelation(model, field, included[field])
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj = append(obj, member{key: field.CodecName(), value: value})
+	}
+	return obj, nil
+}
+
+// marshalRelation gets the relation value. If the relation is 'included' the related models are marshaled
+// as the objects, otherwise only their primary key values are marshaled.
+func marshalRelation(model mapping.Model, field *mapping.StructField, included *query.IncludedRelation) (interface{}, error) {
+	related, err := relationModels(model, field)
+	if err != nil {
+		return nil, err
+	}
+	relatedStruct := field.Relationship().RelatedModelStruct()
+	values := make([]interface{}, len(related))
+	for i, relatedModel := range related {
+		if included == nil {
+			values[i] = relatedModel.GetPrimaryKeyValue()
+			continue
+		}
+		if values[i], err = marshalModel(relatedStruct, relatedModel, included.Fieldset, included.IncludedRelations); err != nil {
+			return nil, err
+		}
+	}
+	if field.Kind() == mapping.KindRelationshipMultiple {
+		return values, nil
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
+
+// relationModels gets the models stored in the 'relation' field of provided 'model'.
+func relationModels(model mapping.Model, relation *mapping.StructField) ([]mapping.Model, error) {
+	switch relation.Kind() {
+	case mapping.KindRelationshipSingle:
+		relationer, ok := model.(mapping.SingleRelationer)
+		if !ok {
+			return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.SingleRelationer interface", model)
+		}
+		related, err := relationer.GetRelationModel(relation)
+		if err != nil {
+			return nil, err
+		}
+		if related == nil {
+			return nil, nil
+		}
+		return []mapping.Model{related}, nil
+	case mapping.KindRelationshipMultiple:
+		relationer, ok := model.(mapping.MultiRelationer)
+		if !ok {
+			return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.MultiRelationer interface", model)
+		}
+		return relationer.GetRelationModels(relation)
+	default:
+		return nil, errors.WrapDetf(mapping.ErrInvalidRelationField, "field: '%s' is not a relationship", relation)
+	}
+}
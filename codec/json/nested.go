@@ -0,0 +1,190 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// nestedFields gets the nested struct fields sorted in the order of the struct definition.
+func nestedFields(nested *mapping.NestedStruct) []*mapping.StructField {
+	fields := make([]*mapping.StructField, 0, len(nested.Fields()))
+	for _, field := range nested.Fields() {
+		fields = append(fields, field.StructField())
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].ReflectField().Index[0] < fields[j].ReflectField().Index[0]
+	})
+	return fields
+}
+
+// marshalNested gets the JSON marshalable value of the nested struct 'v'. The 'v' might also be the pointer,
+// slice, array or a map of the nested structs.
+func marshalNested(nested *mapping.NestedStruct, v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return marshalNested(nested, v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		values := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if values[i], err = marshalNested(nested, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		values := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, err := marshalNested(nested, iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			values[fmt.Sprint(iter.Key().Interface())] = value
+		}
+		return values, nil
+	case reflect.Struct:
+		obj := object{}
+		for _, field := range nestedFields(nested) {
+			if field.CodecSkip() {
+				continue
+			}
+			fv := v.FieldByIndex(field.ReflectField().Index)
+			if field.CodecOmitEmpty() && fv.IsZero() {
+				continue
+			}
+			var value interface{}
+			if field.Nested() != nil {
+				var err error
+				if value, err = marshalNested(field.Nested(), fv); err != nil {
+					return nil, err
+				}
+			} else {
+				value = fieldValue(field, fv.Interface())
+			}
+			obj = append(obj, member{key: field.CodecName(), value: value})
+		}
+		return obj, nil
+	default:
+		return nil, errors.WrapDetf(codec.ErrMarshal, "invalid nested struct: '%s' value type: '%s'", nested.Type(), v.Type())
+	}
+}
+
+// fieldValue gets the marshal value of the 'field'. The ISO8601 time fields are formatted.
+func fieldValue(field *mapping.StructField, value interface{}) interface{} {
+	if !field.CodecISO8601() {
+		return value
+	}
+	switch t := value.(type) {
+	case time.Time:
+		return t.UTC().Format(codec.ISO8601TimeFormat)
+	case *time.Time:
+		if t != nil {
+			return t.UTC().Format(codec.ISO8601TimeFormat)
+		}
+	}
+	return value
+}
+
+// unmarshalNested unmarshals the 'data' into settable 'v' value of the nested struct. The 'v' might also be
+// the pointer, slice, array or a map of the nested structs.
+func unmarshalNested(nested *mapping.NestedStruct, data json.RawMessage, v reflect.Value, strict bool) error {
+	if bytes.Equal(bytes.TrimSpace(data), null) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return unmarshalNested(nested, data, v.Elem(), strict)
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if err := decode(data, &items, strict); err != nil {
+			return err
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(items), len(items)))
+		} else if len(items) > v.Len() {
+			return errors.WrapDetf(codec.ErrUnmarshalFieldValue, "too many values for the array of length: %d", v.Len())
+		}
+		for i, item := range items {
+			if err := unmarshalNested(nested, item, v.Index(i), strict); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		var items map[string]json.RawMessage
+		if err := decode(data, &items, strict); err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(items))
+		for k, item := range items {
+			key := reflect.New(v.Type().Key())
+			if v.Type().Key().Kind() == reflect.String {
+				key.Elem().SetString(k)
+			} else if err := json.Unmarshal([]byte(k), key.Interface()); err != nil {
+				return err
+			}
+			value := reflect.New(v.Type().Elem())
+			if err := unmarshalNested(nested, item, value.Elem(), strict); err != nil {
+				return err
+			}
+			m.SetMapIndex(key.Elem(), value.Elem())
+		}
+		v.Set(m)
+		return nil
+	case reflect.Struct:
+		var members map[string]json.RawMessage
+		if err := decode(data, &members, strict); err != nil {
+			return err
+		}
+		fields := map[string]*mapping.StructField{}
+		for _, field := range nestedFields(nested) {
+			if !field.CodecSkip() {
+				fields[field.CodecName()] = field
+			}
+		}
+		for name, value := range members {
+			field, ok := fields[name]
+			if !ok {
+				if strict {
+					return errors.WrapDetf(codec.ErrUnmarshalFieldName, "unknown field: '%s' in the nested struct: '%s'", name, nested.Type())
+				}
+				continue
+			}
+			fv := v.FieldByIndex(field.ReflectField().Index)
+			if field.Nested() != nil {
+				if err := unmarshalNested(field.Nested(), value, fv, strict); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decode(value, fv.Addr().Interface(), strict); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return decode(data, v.Addr().Interface(), strict)
+	}
+}
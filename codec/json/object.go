@@ -0,0 +1,39 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// object is the JSON object that keeps the order of its members.
+type object []member
+
+// member is a single JSON object member.
+type member struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (o object) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,232 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// UnmarshalModels implements codec.ModelUnmarshaler interface. The data might be a JSON array or a single object.
+func (c *Codec) UnmarshalModels(data []byte, options ...codec.UnmarshalOption) ([]mapping.Model, error) {
+	payload, err := c.unmarshalPayload(data, options)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Data, nil
+}
+
+// UnmarshalModel implements codec.ModelUnmarshaler interface. If the model unmarshal option is provided, the data
+// is unmarshaled into given model.
+func (c *Codec) UnmarshalModel(data []byte, options ...codec.UnmarshalOption) (mapping.Model, error) {
+	options = append(options, codec.UnmarshalWithSingleExpectation())
+	payload, err := c.unmarshalPayload(data, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload.Data) == 0 {
+		return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "no model in the input")
+	}
+	return payload.Data[0], nil
+}
+
+// UnmarshalPayload implements codec.PayloadUnmarshaler interface. The payload field sets contains all the fields
+// and relations that were defined in the input objects. If the StrictUnmarshal option is set, unknown object
+// members results in an error. Relations might be defined either as the related model primary key values or
+// as the related model objects.
+func (c *Codec) UnmarshalPayload(r io.Reader, options ...codec.UnmarshalOption) (*codec.Payload, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.WrapDetf(codec.ErrUnmarshal, "reading input failed: %v", err)
+	}
+	return c.unmarshalPayload(data, options)
+}
+
+func (c *Codec) unmarshalPayload(data []byte, options []codec.UnmarshalOption) (*codec.Payload, error) {
+	o := &codec.UnmarshalOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.ModelStruct == nil {
+		if o.Model == nil {
+			return nil, errors.WrapDet(codec.ErrOptions, "no model or model struct provided for the unmarshal process")
+		}
+		var err error
+		if o.ModelStruct, err = c.modelStruct(o.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	var objects []json.RawMessage
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0:
+		return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "empty input")
+	case bytes.Equal(data, null):
+	case data[0] == '[':
+		if o.ExpectSingle {
+			return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "expected single object")
+		}
+		if err := json.Unmarshal(data, &objects); err != nil {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "invalid input: %v", err)
+		}
+	default:
+		objects = append(objects, data)
+	}
+	if o.Model != nil && len(objects) > 1 {
+		return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "cannot unmarshal %d objects into single model", len(objects))
+	}
+
+	payload := &codec.Payload{ModelStruct: o.ModelStruct}
+	for _, obj := range objects {
+		model := o.Model
+		if model == nil {
+			model = mapping.NewModel(o.ModelStruct)
+		}
+		fieldSet, err := unmarshalModel(o.ModelStruct, obj, model, o.StrictUnmarshal)
+		if err != nil {
+			return nil, err
+		}
+		payload.Data = append(payload.Data, model)
+		payload.FieldSets = append(payload.FieldSets, fieldSet)
+	}
+	return payload, nil
+}
+
+// unmarshalModel unmarshals the JSON object 'data' into provided 'model'. Returns the field set of unmarshaled fields.
+func unmarshalModel(mStruct *mapping.ModelStruct, data json.RawMessage, model mapping.Model, strict bool) (mapping.FieldSet, error) {
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement mapping.Fielder interface", mStruct)
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "invalid model: '%s' object: %v", mStruct, err)
+	}
+
+	fields := codecFields(mStruct)
+	var fieldSet mapping.FieldSet
+	// Iterate over the fields so that the field set is in the model's fields order.
+	for _, field := range fields {
+		value, ok := members[field.CodecName()]
+		if !ok {
+			continue
+		}
+		delete(members, field.CodecName())
+
+		var err error
+		switch field.Kind() {
+		case mapping.KindRelationshipSingle, mapping.KindRelationshipMultiple:
+			err = unmarshalRelation(model, field, value, strict)
+		default:
+			var address interface{}
+			if address, err = fielder.GetFieldsAddress(field); err != nil {
+				return nil, err
+			}
+			if field.Nested() != nil {
+				err = unmarshalNested(field.Nested(), value, reflect.ValueOf(address).Elem(), strict)
+			} else {
+				err = decode(value, address, strict)
+			}
+		}
+		if err != nil {
+			if errors.Is(err, codec.ErrCodec) || errors.Is(err, mapping.ErrMapping) {
+				return nil, err
+			}
+			return nil, errors.WrapDetf(codec.ErrUnmarshalFieldValue, "invalid field: '%s' value: %v", field.CodecName(), err)
+		}
+		fieldSet = append(fieldSet, field)
+	}
+	for name := range members {
+		if strict {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalFieldName, "unknown field: '%s' for the model: '%s'", name, mStruct)
+		}
+		log.Debug2f("Unknown field: '%s' for the model: '%s'", name, mStruct)
+	}
+	return fieldSet, nil
+}
+
+// unmarshalRelation sets the relation models from the 'data'. The related models might be defined either by
+// their primary key values or as the objects.
+func unmarshalRelation(model mapping.Model, field *mapping.StructField, data json.RawMessage, strict bool) error {
+	relatedStruct := field.Relationship().RelatedModelStruct()
+	newRelated := func(data json.RawMessage) (mapping.Model, error) {
+		related := mapping.NewModel(relatedStruct)
+		if len(data) > 0 && data[0] == '{' {
+			if _, err := unmarshalModel(relatedStruct, data, related, strict); err != nil {
+				return nil, err
+			}
+			return related, nil
+		}
+		if err := decode(data, related.GetPrimaryKeyAddress(), strict); err != nil {
+			return nil, err
+		}
+		return related, nil
+	}
+
+	switch field.Kind() {
+	case mapping.KindRelationshipSingle:
+		relationer, ok := model.(mapping.SingleRelationer)
+		if !ok {
+			return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.SingleRelationer interface", model)
+		}
+		data = bytes.TrimSpace(data)
+		if bytes.Equal(data, null) {
+			return relationer.SetRelationModel(field, nil)
+		}
+		related, err := newRelated(data)
+		if err != nil {
+			return err
+		}
+		return relationer.SetRelationModel(field, related)
+	default:
+		relationer, ok := model.(mapping.MultiRelationer)
+		if !ok {
+			return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.MultiRelationer interface", model)
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		models := make([]mapping.Model, len(items))
+		for i, item := range items {
+			var err error
+			if models[i], err = newRelated(bytes.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+		return relationer.SetRelationModels(field, models...)
+	}
+}
+
+// codecFields gets the model's fields and relationships that are not skipped by the codec.
+func codecFields(mStruct *mapping.ModelStruct) []*mapping.StructField {
+	var fields []*mapping.StructField
+	for _, field := range mStruct.StructFields() {
+		if field.CodecSkip() {
+			continue
+		}
+		switch field.Kind() {
+		case mapping.KindPrimary, mapping.KindAttribute, mapping.KindForeignKey,
+			mapping.KindRelationshipSingle, mapping.KindRelationshipMultiple:
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// decode unmarshals the 'data' into 'dst'. If the 'strict' flag is set the unknown fields are not allowed.
+func decode(data []byte, dst interface{}, strict bool) error {
+	if !strict {
+		return json.Unmarshal(data, dst)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(dst)
+}
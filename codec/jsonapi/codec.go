@@ -0,0 +1,62 @@
+// Package jsonapi contains the JSON:API 1.0 codec implementation. More info about the specification
+// could be found at: 'https://jsonapi.org/format/1.0'.
+package jsonapi
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// MimeType is the JSON:API media type.
+const MimeType = "application/vnd.api+json"
+
+// Compile time check for the codec interfaces.
+var (
+	_ codec.Codec              = &Codec{}
+	_ codec.ModelMarshaler     = &Codec{}
+	_ codec.ModelUnmarshaler   = &Codec{}
+	_ codec.PayloadMarshaler   = &Codec{}
+	_ codec.PayloadUnmarshaler = &Codec{}
+)
+
+// Codec is the JSON:API codec implementation. It marshals the models as the resource objects using
+// the model struct collection as the resource type and the fields codec names as the member names.
+// The foreign key fields are not marshaled - they are represented by the relationships.
+type Codec struct {
+	// ModelMap is used to get the model struct for the models provided in the unmarshal options.
+	ModelMap *mapping.ModelMap
+}
+
+// New creates new JSON:API codec for provided model map.
+func New(m *mapping.ModelMap) *Codec {
+	return &Codec{ModelMap: m}
+}
+
+// MimeType implements codec.Codec interface.
+func (c *Codec) MimeType() string {
+	return MimeType
+}
+
+// MarshalErrors implements codec.Codec interface. It writes the document with the 'errors' top level member.
+func (c *Codec) MarshalErrors(w io.Writer, errs ...*codec.Error) error {
+	if err := json.NewEncoder(w).Encode(&document{Errors: errs}); err != nil {
+		return errors.WrapDetf(codec.ErrMarshal, "marshaling errors failed: %v", err)
+	}
+	return nil
+}
+
+// UnmarshalErrors implements codec.Codec interface.
+func (c *Codec) UnmarshalErrors(r io.Reader) (codec.MultiError, error) {
+	doc := document{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "unmarshaling errors document failed: %v", err)
+	}
+	if len(doc.Errors) == 0 {
+		return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "no errors in the document")
+	}
+	return doc.Errors, nil
+}
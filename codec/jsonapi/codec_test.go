@@ -0,0 +1,248 @@
+package jsonapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+func relation(t *testing.T, mStruct *mapping.ModelStruct, name string) *mapping.StructField {
+	t.Helper()
+	field, ok := mStruct.RelationByName(name)
+	require.True(t, ok)
+	return field
+}
+
+func testCodec(t *testing.T) *Codec {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(testmodels.Neuron_Models...))
+	return New(m)
+}
+
+func TestMarshalPayload(t *testing.T) {
+	c := testCodec(t)
+	mStruct := c.ModelMap.MustModelStruct(&testmodels.Blog{})
+	postStruct := c.ModelMap.MustModelStruct(&testmodels.Post{})
+	createdAt := time.Date(2020, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	comment := &testmodels.Comment{ID: 5, Body: "comment"}
+	post := &testmodels.Post{ID: 3, Title: "post", Body: "body", Comments: []*testmodels.Comment{comment}}
+	blogs := []mapping.Model{
+		&testmodels.Blog{ID: 1, Title: "first", CreatedAt: createdAt, Posts: []*testmodels.Post{post}, CurrentPost: post},
+		&testmodels.Blog{ID: 2, Title: "second", CreatedAt: createdAt, CurrentPostID: 4},
+	}
+	payload := &codec.Payload{
+		ModelStruct: mStruct,
+		Data:        blogs,
+		FieldSets:   []mapping.FieldSet{{mStruct.MustFieldByName("Title"), mStruct.MustFieldByName("CreatedAt"), relation(t, mStruct, "CurrentPost")}},
+		IncludedRelations: []*query.IncludedRelation{{
+			StructField: relation(t, mStruct, "Posts"),
+			Fieldset:    mapping.FieldSet{postStruct.MustFieldByName("Title")},
+			IncludedRelations: []*query.IncludedRelation{{
+				StructField: relation(t, postStruct, "Comments"),
+			}},
+		}},
+		Meta:            codec.Meta{"key": "value"},
+		PaginationLinks: &codec.PaginationLinks{Self: "http://localhost/blogs?page[size]=2", Total: 10},
+	}
+
+	buf := &bytes.Buffer{}
+	err := c.MarshalPayload(buf, payload, codec.MarshalWithLinks(codec.LinkOptions{Type: codec.ResourceLink, BaseURL: "http://localhost", Collection: "blogs"}))
+	require.NoError(t, err)
+
+	expected := `{
+		"data": [{
+			"type": "blogs", "id": "1",
+			"attributes": {"created_at": "2020-10-01T12:30:00Z", "title": "first"},
+			"relationships": {
+				"current_post": {"data": {"type": "posts", "id": "3"}, "links": {"self": "http://localhost/blogs/1/relationships/current_post", "related": "http://localhost/blogs/1/current_post"}},
+				"posts": {"data": [{"type": "posts", "id": "3"}], "links": {"self": "http://localhost/blogs/1/relationships/posts", "related": "http://localhost/blogs/1/posts"}}
+			},
+			"links": {"self": "http://localhost/blogs/1"}
+		}, {
+			"type": "blogs", "id": "2",
+			"attributes": {"created_at": "2020-10-01T12:30:00Z", "title": "second"},
+			"relationships": {
+				"current_post": {"data": {"type": "posts", "id": "4"}, "links": {"self": "http://localhost/blogs/2/relationships/current_post", "related": "http://localhost/blogs/2/current_post"}},
+				"posts": {"data": [], "links": {"self": "http://localhost/blogs/2/relationships/posts", "related": "http://localhost/blogs/2/posts"}}
+			},
+			"links": {"self": "http://localhost/blogs/2"}
+		}],
+		"included": [{
+			"type": "posts", "id": "3",
+			"attributes": {"title": "post"},
+			"relationships": {
+				"comments": {"data": [{"type": "comments", "id": "5"}], "links": {"self": "http://localhost/posts/3/relationships/comments", "related": "http://localhost/posts/3/comments"}}
+			},
+			"links": {"self": "http://localhost/posts/3"}
+		}, {
+			"type": "comments", "id": "5",
+			"attributes": {"body": "comment"},
+			"relationships": {
+				"post": {"data": null, "links": {"self": "http://localhost/comments/5/relationships/post", "related": "http://localhost/comments/5/post"}}
+			},
+			"links": {"self": "http://localhost/comments/5"}
+		}],
+		"links": {"self": "http://localhost/blogs?page[size]=2"},
+		"meta": {"key": "value", "total": 10}
+	}`
+	assert.JSONEq(t, expected, buf.String())
+}
+
+func TestMarshalModel(t *testing.T) {
+	c := testCodec(t)
+
+	data, err := c.MarshalModel(&testmodels.Comment{ID: 1, Body: "body"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": {"type": "comments", "id": "1", "attributes": {"body": "body"}, "relationships": {"post": {"data": null}}}}`, string(data))
+
+	data, err = c.MarshalModels(nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": []}`, string(data))
+
+	data, err = c.MarshalModels([]mapping.Model{&testmodels.Comment{ID: 1}, &testmodels.Comment{ID: 2}},
+		codec.MarshalWithLinks(codec.LinkOptions{Type: codec.RelationshipLink, BaseURL: "/api", Collection: "posts", RootID: "3", RelationField: "comments"}))
+	require.NoError(t, err)
+	expected := `{
+		"data": [{"type": "comments", "id": "1"}, {"type": "comments", "id": "2"}],
+		"links": {"self": "/api/posts/3/relationships/comments", "related": "/api/posts/3/comments"}
+	}`
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestMarshalErrors(t *testing.T) {
+	c := testCodec(t)
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.MarshalErrors(buf, &codec.Error{Status: "400", Title: "bad request"}))
+	assert.JSONEq(t, `{"errors": [{"status": "400", "title": "bad request"}]}`, buf.String())
+
+	errs, err := c.UnmarshalErrors(buf)
+	require.NoError(t, err)
+	require.Len(t, errs, 1)
+	assert.Equal(t, 400, errs.Status())
+}
+
+func TestUnmarshal(t *testing.T) {
+	c := testCodec(t)
+	mStruct := c.ModelMap.MustModelStruct(&testmodels.Blog{})
+
+	t.Run("Payload", func(t *testing.T) {
+		input := `{
+			"data": [{
+				"type": "blogs", "id": "1",
+				"attributes": {"title": "first", "created_at": "2020-10-01T12:30:00Z", "unknown": 1},
+				"relationships": {
+					"posts": {"data": [{"type": "posts", "id": "3"}, {"type": "posts", "id": "4"}]},
+					"current_post": {"data": null},
+					"unknown": {"data": null}
+				}
+			}, {
+				"type": "blogs",
+				"attributes": {"view_count": 3},
+				"relationships": {"current_post": {"links": {"self": "/blogs/1/relationships/current_post"}}}
+			}],
+			"meta": {"key": "value"}
+		}`
+		payload, err := c.UnmarshalPayload(strings.NewReader(input), codec.UnmarshalWithModelStruct(mStruct))
+		require.NoError(t, err)
+		require.Len(t, payload.Data, 2)
+		assert.Equal(t, codec.Meta{"key": "value"}, payload.Meta)
+
+		blog := payload.Data[0].(*testmodels.Blog)
+		assert.Equal(t, 1, blog.ID)
+		assert.Equal(t, "first", blog.Title)
+		assert.Equal(t, time.Date(2020, 10, 1, 12, 30, 0, 0, time.UTC), blog.CreatedAt)
+		require.Len(t, blog.Posts, 2)
+		assert.Equal(t, uint64(4), blog.Posts[1].ID)
+		require.Len(t, payload.FieldSets, 2)
+		assert.Len(t, payload.FieldSets[0], 5)
+		assert.True(t, payload.FieldSets[0].Contains(relation(t, mStruct, "CurrentPost")))
+
+		blog = payload.Data[1].(*testmodels.Blog)
+		assert.Zero(t, blog.ID)
+		assert.Equal(t, 3, blog.ViewCount)
+		assert.Equal(t, mapping.FieldSet{mStruct.MustFieldByName("ViewCount")}, payload.FieldSets[1])
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		tests := map[string]string{
+			"Attribute":    `{"data": {"type": "blogs", "attributes": {"unknown": 1}}}`,
+			"Relationship": `{"data": {"type": "blogs", "relationships": {"unknown": {"data": null}}}}`,
+			"Member":       `{"data": {"type": "blogs"}, "unknown": 1}`,
+		}
+		for name, input := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := c.UnmarshalModel([]byte(input), codec.UnmarshalWithModelStruct(mStruct), codec.UnmarshalStrictly())
+				require.Error(t, err)
+			})
+		}
+		_, err := c.UnmarshalModel([]byte(tests["Attribute"]), codec.UnmarshalWithModelStruct(mStruct), codec.UnmarshalStrictly())
+		assert.True(t, errors.Is(err, codec.ErrUnmarshalFieldName))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		tests := map[string]string{
+			"Type":             `{"data": {"type": "posts"}}`,
+			"RelationshipType": `{"data": {"type": "blogs", "relationships": {"current_post": {"data": {"type": "blogs", "id": "1"}}}}}`,
+			"Multiple":         `{"data": [{"type": "blogs"}]}`,
+			"NoData":           `{"meta": {}}`,
+			"Value":            `{"data": {"type": "blogs", "attributes": {"title": 1}}}`,
+		}
+		for name, input := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := c.UnmarshalModel([]byte(input), codec.UnmarshalWithModelStruct(mStruct))
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, codec.ErrUnmarshal))
+			})
+		}
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		tests := map[string]string{
+			"Resource":     `{"data": [null]}`,
+			"Relationship": `{"data": [{"type": "blogs", "relationships": {"posts": {"data": [null]}}}]}`,
+		}
+		for name, input := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := c.UnmarshalPayload(strings.NewReader(input), codec.UnmarshalWithModelStruct(mStruct))
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, codec.ErrUnmarshalDocument))
+			})
+		}
+	})
+
+	t.Run("IntoModel", func(t *testing.T) {
+		blog := &testmodels.Blog{ID: 1, Title: "title"}
+		model, err := c.UnmarshalModel([]byte(`{"data": {"type": "blogs", "id": "1", "attributes": {"view_count": 10}}}`), codec.UnmarshalWithModel(blog))
+		require.NoError(t, err)
+		assert.Equal(t, blog, model)
+		assert.Equal(t, "title", blog.Title)
+		assert.Equal(t, 10, blog.ViewCount)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		data, err := c.MarshalModel(&testmodels.Blog{ID: 3, Title: "title", CurrentPostID: 5})
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &raw))
+
+		model, err := c.UnmarshalModel(data, codec.UnmarshalWithModelStruct(mStruct), codec.UnmarshalStrictly())
+		require.NoError(t, err)
+		blog := model.(*testmodels.Blog)
+		assert.Equal(t, "title", blog.Title)
+		require.NotNil(t, blog.CurrentPost)
+		assert.Equal(t, uint64(5), blog.CurrentPost.ID)
+	})
+}
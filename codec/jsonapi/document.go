@@ -0,0 +1,50 @@
+package jsonapi
+
+import (
+	"encoding/json"
+
+	"github.com/neuronlabs/neuron/codec"
+)
+
+// document is the JSON:API top level document.
+type document struct {
+	Data     json.RawMessage `json:"data,omitempty"`
+	Errors   []*codec.Error  `json:"errors,omitempty"`
+	Included []*resource     `json:"included,omitempty"`
+	Links    *links          `json:"links,omitempty"`
+	Meta     codec.Meta      `json:"meta,omitempty"`
+}
+
+// resource is the JSON:API resource object.
+type resource struct {
+	Type          string                     `json:"type"`
+	ID            string                     `json:"id,omitempty"`
+	Attributes    map[string]json.RawMessage `json:"attributes,omitempty"`
+	Relationships map[string]*relationship   `json:"relationships,omitempty"`
+	Links         *links                     `json:"links,omitempty"`
+	Meta          codec.Meta                 `json:"meta,omitempty"`
+}
+
+// relationship is the JSON:API relationship object. The Data is the raw message so that it is possible
+// to distinguish the null and empty linkage from the relationship without the data member.
+type relationship struct {
+	Data  json.RawMessage `json:"data,omitempty"`
+	Links *links          `json:"links,omitempty"`
+	Meta  codec.Meta      `json:"meta,omitempty"`
+}
+
+// identifier is the JSON:API resource identifier object.
+type identifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// links is the JSON:API links object used by the document, resources and relationships.
+type links struct {
+	Self    string `json:"self,omitempty"`
+	Related string `json:"related,omitempty"`
+	First   string `json:"first,omitempty"`
+	Prev    string `json:"prev,omitempty"`
+	Next    string `json:"next,omitempty"`
+	Last    string `json:"last,omitempty"`
+}
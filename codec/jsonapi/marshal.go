@@ -0,0 +1,456 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+var (
+	nullData       = json.RawMessage("null")
+	emptyArrayData = json.RawMessage("[]")
+)
+
+// MarshalModels implements codec.ModelMarshaler interface. It marshals the document with the 'data' member only.
+func (c *Codec) MarshalModels(models []mapping.Model, options ...codec.MarshalOption) ([]byte, error) {
+	payload := &codec.Payload{Data: models}
+	if len(models) > 0 {
+		mStruct, err := c.modelStruct(models[0])
+		if err != nil {
+			return nil, err
+		}
+		payload.ModelStruct = mStruct
+	}
+	return c.marshalPayload(payload, options)
+}
+
+// MarshalModel implements codec.ModelMarshaler interface. It marshals the document with single resource 'data'.
+func (c *Codec) MarshalModel(model mapping.Model, options ...codec.MarshalOption) ([]byte, error) {
+	mStruct, err := c.modelStruct(model)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, codec.MarshalSingleModel())
+	return c.marshalPayload(&codec.Payload{ModelStruct: mStruct, Data: []mapping.Model{model}}, options)
+}
+
+// MarshalPayload implements codec.PayloadMarshaler interface. The payload included relations are marshaled as
+// the compound document 'included' resources.
+func (c *Codec) MarshalPayload(w io.Writer, payload *codec.Payload, options ...codec.MarshalOption) error {
+	data, err := c.marshalPayload(payload, options)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(data); err != nil {
+		return errors.WrapDetf(codec.ErrMarshal, "writing payload failed: %v", err)
+	}
+	return nil
+}
+
+func (c *Codec) marshalPayload(payload *codec.Payload, options []codec.MarshalOption) ([]byte, error) {
+	o := &codec.MarshalOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	m := &marshaler{options: o, visited: map[resourceKey]struct{}{}}
+	doc, err := m.document(payload)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.WrapDetf(codec.ErrMarshalPayload, "marshaling document failed: %v", err)
+	}
+	return data, nil
+}
+
+// modelStruct gets the model struct for provided 'model' from the codec's model map.
+func (c *Codec) modelStruct(model mapping.Model) (*mapping.ModelStruct, error) {
+	if c.ModelMap == nil {
+		return nil, errors.WrapDet(codec.ErrOptions, "no model map defined for the codec")
+	}
+	return c.ModelMap.ModelStruct(model)
+}
+
+// resourceKey is the unique resource identifier within the document.
+type resourceKey struct {
+	collection, id string
+}
+
+// marshaler is the marshal process state.
+type marshaler struct {
+	options  *codec.MarshalOptions
+	visited  map[resourceKey]struct{}
+	included []*resource
+}
+
+func (m *marshaler) document(payload *codec.Payload) (*document, error) {
+	if payload.ModelStruct == nil && len(payload.Data) > 0 {
+		return nil, errors.WrapDet(codec.ErrMarshalPayload, "no model struct defined for the payload")
+	}
+	switch len(payload.FieldSets) {
+	case 0, 1, len(payload.Data):
+	default:
+		return nil, errors.WrapDetf(codec.ErrMarshalPayload, "payload field sets length: %d doesn't match data length: %d", len(payload.FieldSets), len(payload.Data))
+	}
+
+	includes := payload.IncludedRelations
+	if len(includes) == 0 {
+		includes = m.options.IncludedRelations
+	}
+
+	// Mark all primary data resources as visited so that they would not be included.
+	for _, model := range payload.Data {
+		if model.IsPrimaryKeyZero() {
+			continue
+		}
+		id, err := model.GetPrimaryKeyStringValue()
+		if err != nil {
+			return nil, err
+		}
+		m.visited[resourceKey{collection: payload.ModelStruct.Collection(), id: id}] = struct{}{}
+	}
+
+	var data interface{}
+	if m.options.Link.Type == codec.RelationshipLink {
+		identifiers := make([]*identifier, len(payload.Data))
+		for i, model := range payload.Data {
+			id, err := model.GetPrimaryKeyStringValue()
+			if err != nil {
+				return nil, err
+			}
+			identifiers[i] = &identifier{Type: payload.ModelStruct.Collection(), ID: id}
+		}
+		data = identifiers
+	} else {
+		resources := make([]*resource, len(payload.Data))
+		for i, model := range payload.Data {
+			var fieldSet mapping.FieldSet
+			switch len(payload.FieldSets) {
+			case 0:
+			case 1:
+				fieldSet = payload.FieldSets[0]
+			default:
+				fieldSet = payload.FieldSets[i]
+			}
+			var err error
+			if resources[i], err = m.resource(payload.ModelStruct, model, fieldSet, includes); err != nil {
+				return nil, err
+			}
+		}
+		data = resources
+		if err := m.include(payload.Data, includes); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := &document{Included: m.included, Meta: payload.Meta}
+	var err error
+	if doc.Data, err = m.data(data, len(payload.Data)); err != nil {
+		return nil, err
+	}
+	doc.Links = m.documentLinks(payload.PaginationLinks)
+	if payload.PaginationLinks != nil {
+		if _, ok := doc.Meta["total"]; !ok {
+			meta := codec.Meta{"total": payload.PaginationLinks.Total}
+			for k, v := range payload.Meta {
+				meta[k] = v
+			}
+			doc.Meta = meta
+		}
+	}
+	return doc, nil
+}
+
+// data marshals the primary 'data' slice with respect to the single result option.
+func (m *marshaler) data(data interface{}, length int) (json.RawMessage, error) {
+	var (
+		raw []byte
+		err error
+	)
+	if m.options.SingleResult {
+		switch length {
+		case 0:
+			return nullData, nil
+		case 1:
+			switch d := data.(type) {
+			case []*resource:
+				raw, err = json.Marshal(d[0])
+			case []*identifier:
+				raw, err = json.Marshal(d[0])
+			}
+		default:
+			return nil, errors.WrapDetf(codec.ErrMarshalPayload, "single result payload contains: %d models", length)
+		}
+	} else {
+		if length == 0 {
+			return emptyArrayData, nil
+		}
+		raw, err = json.Marshal(data)
+	}
+	if err != nil {
+		return nil, errors.WrapDetf(codec.ErrMarshalPayload, "marshaling data failed: %v", err)
+	}
+	return raw, nil
+}
+
+// resource creates the resource object for the 'model'. If the 'fieldSet' is empty all the attributes and
+// relationships are marshaled. The relations from the 'includes' are always marshaled as the relationships.
+func (m *marshaler) resource(mStruct *mapping.ModelStruct, model mapping.Model, fieldSet mapping.FieldSet, includes []*query.IncludedRelation) (*resource, error) {
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement mapping.Fielder interface", mStruct)
+	}
+	r := &resource{Type: mStruct.Collection()}
+	if !model.IsPrimaryKeyZero() {
+		var err error
+		if r.ID, err = model.GetPrimaryKeyStringValue(); err != nil {
+			return nil, err
+		}
+	}
+	if len(fieldSet) == 0 {
+		fieldSet = append(mStruct.Attributes(), mStruct.RelationFields()...)
+	}
+	for _, include := range includes {
+		if !fieldSet.Contains(include.StructField) {
+			fieldSet = append(fieldSet[:len(fieldSet):len(fieldSet)], include.StructField)
+		}
+	}
+
+	for _, field := range fieldSet {
+		if field.CodecSkip() {
+			continue
+		}
+		switch field.Kind() {
+		case mapping.KindAttribute:
+			value, ok, err := marshalAttribute(fielder, field)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if r.Attributes == nil {
+				r.Attributes = map[string]json.RawMessage{}
+			}
+			r.Attributes[field.CodecName()] = value
+		case mapping.KindRelationshipSingle, mapping.KindRelationshipMultiple:
+			rel, err := m.relationship(fielder, model, field, r.ID)
+			if err != nil {
+				return nil, err
+			}
+			if r.Relationships == nil {
+				r.Relationships = map[string]*relationship{}
+			}
+			r.Relationships[field.CodecName()] = rel
+		}
+	}
+	if m.options.Link.Type != codec.NoLink && r.ID != "" {
+		r.Links = &links{Self: m.link(mStruct.Collection(), r.ID)}
+	}
+	return r, nil
+}
+
+// marshalAttribute marshals the 'field' value of the model. If the field should be omitted the function
+// returns false.
+func marshalAttribute(fielder mapping.Fielder, field *mapping.StructField) (json.RawMessage, bool, error) {
+	if field.CodecOmitEmpty() {
+		zero, err := fielder.IsFieldZero(field)
+		if err != nil {
+			return nil, false, err
+		}
+		if zero {
+			return nil, false, nil
+		}
+	}
+	value, err := fielder.GetFieldValue(field)
+	if err != nil {
+		return nil, false, err
+	}
+	if field.CodecISO8601() {
+		switch t := value.(type) {
+		case time.Time:
+			value = t.UTC().Format(codec.ISO8601TimeFormat)
+		case *time.Time:
+			if t != nil {
+				value = t.UTC().Format(codec.ISO8601TimeFormat)
+			}
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false, errors.WrapDetf(codec.ErrMarshal, "marshaling field: '%s' value failed: %v", field, err)
+	}
+	return data, true, nil
+}
+
+// relationship creates the relationship object with the resource linkage of the 'field' for the 'model'.
+func (m *marshaler) relationship(fielder mapping.Fielder, model mapping.Model, field *mapping.StructField, id string) (*relationship, error) {
+	relatedStruct := field.Relationship().RelatedModelStruct()
+	related, err := relationModels(model, field)
+	if err != nil {
+		return nil, err
+	}
+	// The belongs to relationship linkage could be taken from the foreign key, even if the relation is not set.
+	if len(related) == 0 && field.Relationship().Kind() == mapping.RelBelongsTo {
+		foreignKey := field.Relationship().ForeignKey()
+		zero, err := fielder.IsFieldZero(foreignKey)
+		if err != nil {
+			return nil, err
+		}
+		if !zero {
+			value, err := fielder.GetFieldValue(foreignKey)
+			if err != nil {
+				return nil, err
+			}
+			relatedModel := mapping.NewModel(relatedStruct)
+			if err = relatedModel.SetPrimaryKeyValue(value); err != nil {
+				return nil, err
+			}
+			related = append(related, relatedModel)
+		}
+	}
+
+	identifiers := make([]*identifier, len(related))
+	for i, relatedModel := range related {
+		if relatedModel.IsPrimaryKeyZero() {
+			return nil, errors.WrapDetf(codec.ErrMarshal, "related model in the relation: '%s' has zero value primary key", field)
+		}
+		relatedID, err := relatedModel.GetPrimaryKeyStringValue()
+		if err != nil {
+			return nil, err
+		}
+		identifiers[i] = &identifier{Type: relatedStruct.Collection(), ID: relatedID}
+	}
+
+	rel := &relationship{}
+	switch {
+	case field.Kind() == mapping.KindRelationshipMultiple && len(identifiers) == 0:
+		rel.Data = emptyArrayData
+	case field.Kind() == mapping.KindRelationshipMultiple:
+		rel.Data, err = json.Marshal(identifiers)
+	case len(identifiers) == 0:
+		rel.Data = nullData
+	default:
+		rel.Data, err = json.Marshal(identifiers[0])
+	}
+	if err != nil {
+		return nil, errors.WrapDetf(codec.ErrMarshal, "marshaling relationship: '%s' failed: %v", field, err)
+	}
+	if m.options.Link.Type != codec.NoLink && id != "" {
+		collection := field.Struct().Collection()
+		rel.Links = &links{
+			Self:    m.link(collection, id, "relationships", field.NeuronName()),
+			Related: m.link(collection, id, field.NeuronName()),
+		}
+	}
+	return rel, nil
+}
+
+// include adds the included resources for provided 'models' to the marshaler.
+func (m *marshaler) include(models []mapping.Model, includes []*query.IncludedRelation) error {
+	for _, included := range includes {
+		relatedStruct := included.StructField.Relationship().RelatedModelStruct()
+		var relatedModels []mapping.Model
+		for _, model := range models {
+			related, err := relationModels(model, included.StructField)
+			if err != nil {
+				return err
+			}
+			relatedModels = append(relatedModels, related...)
+		}
+		for _, related := range relatedModels {
+			if related.IsPrimaryKeyZero() {
+				continue
+			}
+			id, err := related.GetPrimaryKeyStringValue()
+			if err != nil {
+				return err
+			}
+			key := resourceKey{collection: relatedStruct.Collection(), id: id}
+			if _, ok := m.visited[key]; ok {
+				continue
+			}
+			m.visited[key] = struct{}{}
+			r, err := m.resource(relatedStruct, related, included.Fieldset, included.IncludedRelations)
+			if err != nil {
+				return err
+			}
+			m.included = append(m.included, r)
+		}
+		if err := m.include(relatedModels, included.IncludedRelations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// documentLinks creates the top level links for the link options and provided pagination links.
+func (m *marshaler) documentLinks(pagination *codec.PaginationLinks) *links {
+	l := &links{}
+	o := m.options.Link
+	switch o.Type {
+	case codec.ResourceLink:
+		if o.RootID != "" {
+			l.Self = m.link(o.Collection, o.RootID)
+		} else {
+			l.Self = m.link(o.Collection)
+		}
+	case codec.RelatedLink:
+		l.Self = m.link(o.Collection, o.RootID, o.RelationField)
+	case codec.RelationshipLink:
+		l.Self = m.link(o.Collection, o.RootID, "relationships", o.RelationField)
+		l.Related = m.link(o.Collection, o.RootID, o.RelationField)
+	}
+	if pagination != nil {
+		if pagination.Self != "" {
+			l.Self = pagination.Self
+		}
+		l.First = pagination.First
+		l.Prev = pagination.Prev
+		l.Next = pagination.Next
+		l.Last = pagination.Last
+	}
+	if *l == (links{}) {
+		return nil
+	}
+	return l
+}
+
+// link creates the link with the base url and provided path 'segments'.
+func (m *marshaler) link(segments ...string) string {
+	return strings.TrimSuffix(m.options.Link.BaseURL, "/") + "/" + strings.Join(segments, "/")
+}
+
+// relationModels gets the models stored in the 'relation' field of provided 'model'.
+func relationModels(model mapping.Model, relation *mapping.StructField) ([]mapping.Model, error) {
+	switch relation.Kind() {
+	case mapping.KindRelationshipSingle:
+		relationer, ok := model.(mapping.SingleRelationer)
+		if !ok {
+			return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.SingleRelationer interface", model)
+		}
+		related, err := relationer.GetRelationModel(relation)
+		if err != nil {
+			return nil, err
+		}
+		if related == nil {
+			return nil, nil
+		}
+		return []mapping.Model{related}, nil
+	case mapping.KindRelationshipMultiple:
+		relationer, ok := model.(mapping.MultiRelationer)
+		if !ok {
+			return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.MultiRelationer interface", model)
+		}
+		return relationer.GetRelationModels(relation)
+	default:
+		return nil, errors.WrapDetf(mapping.ErrInvalidRelationField, "field: '%s' is not a relationship", relation)
+	}
+}
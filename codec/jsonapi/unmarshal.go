@@ -0,0 +1,254 @@
+package jsonapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// UnmarshalModels implements codec.ModelUnmarshaler interface.
+func (c *Codec) UnmarshalModels(data []byte, options ...codec.UnmarshalOption) ([]mapping.Model, error) {
+	payload, err := c.unmarshalPayload(data, options)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Data, nil
+}
+
+// UnmarshalModel implements codec.ModelUnmarshaler interface. If the model unmarshal option is provided, the data
+// is unmarshaled into given model.
+func (c *Codec) UnmarshalModel(data []byte, options ...codec.UnmarshalOption) (mapping.Model, error) {
+	options = append(options, codec.UnmarshalWithSingleExpectation())
+	payload, err := c.unmarshalPayload(data, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload.Data) == 0 {
+		return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "no data in the document")
+	}
+	return payload.Data[0], nil
+}
+
+// UnmarshalPayload implements codec.PayloadUnmarshaler interface. The payload field sets contains the primary key
+// if the resource 'id' was provided, and all the attributes and relationships with the data member that were
+// defined in the resource. If the StrictUnmarshal option is set, unknown document members, attributes and
+// relationships results in an error. The 'included' resources are not unmarshaled.
+func (c *Codec) UnmarshalPayload(r io.Reader, options ...codec.UnmarshalOption) (*codec.Payload, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.WrapDetf(codec.ErrUnmarshal, "reading input failed: %v", err)
+	}
+	return c.unmarshalPayload(data, options)
+}
+
+func (c *Codec) unmarshalPayload(data []byte, options []codec.UnmarshalOption) (*codec.Payload, error) {
+	o := &codec.UnmarshalOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.ModelStruct == nil {
+		if o.Model == nil {
+			return nil, errors.WrapDet(codec.ErrOptions, "no model or model struct provided for the unmarshal process")
+		}
+		var err error
+		if o.ModelStruct, err = c.modelStruct(o.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := document{}
+	if err := decode(data, &doc, o.StrictUnmarshal); err != nil {
+		return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "invalid document: %v", err)
+	}
+	if len(doc.Errors) > 0 {
+		return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "document contains errors")
+	}
+
+	var resources []*resource
+	data = bytes.TrimSpace(doc.Data)
+	switch {
+	case len(data) == 0:
+		return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "no data member in the document")
+	case bytes.Equal(data, nullData):
+	case data[0] == '[':
+		if o.ExpectSingle {
+			return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "expected single resource in the document data")
+		}
+		if err := decode(data, &resources, o.StrictUnmarshal); err != nil {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "invalid document data: %v", err)
+		}
+	default:
+		single := &resource{}
+		if err := decode(data, single, o.StrictUnmarshal); err != nil {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "invalid document data: %v", err)
+		}
+		resources = append(resources, single)
+	}
+	if o.Model != nil && len(resources) > 1 {
+		return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "cannot unmarshal %d resources into single model", len(resources))
+	}
+
+	payload := &codec.Payload{ModelStruct: o.ModelStruct, Meta: doc.Meta}
+	for _, res := range resources {
+		model := o.Model
+		if model == nil {
+			model = mapping.NewModel(o.ModelStruct)
+		}
+		fieldSet, err := unmarshalResource(o, res, model)
+		if err != nil {
+			return nil, err
+		}
+		payload.Data = append(payload.Data, model)
+		payload.FieldSets = append(payload.FieldSets, fieldSet)
+	}
+	return payload, nil
+}
+
+// unmarshalResource sets the 'res' values into provided 'model'. Returns the field set of unmarshaled fields.
+func unmarshalResource(o *codec.UnmarshalOptions, res *resource, model mapping.Model) (mapping.FieldSet, error) {
+	mStruct := o.ModelStruct
+	if res == nil {
+		return nil, errors.WrapDet(codec.ErrUnmarshalDocument, "null resource in the document data")
+	}
+	if res.Type != mStruct.Collection() {
+		return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "resource type: '%s' doesn't match collection: '%s'", res.Type, mStruct.Collection())
+	}
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement mapping.Fielder interface", mStruct)
+	}
+
+	var fieldSet mapping.FieldSet
+	if res.ID != "" {
+		if err := model.SetPrimaryKeyStringValue(res.ID); err != nil {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalFieldValue, "invalid resource id: '%s'", res.ID)
+		}
+		fieldSet = append(fieldSet, mStruct.Primary())
+	}
+
+	fields := codecFields(mStruct)
+	for name, value := range res.Attributes {
+		field, ok := fields[name]
+		if !ok || field.Kind() != mapping.KindAttribute {
+			if o.StrictUnmarshal {
+				return nil, errors.WrapDetf(codec.ErrUnmarshalFieldName, "unknown attribute: '%s' for the resource: '%s'", name, res.Type)
+			}
+			log.Debug2f("Unknown attribute: '%s' for the resource: '%s'", name, res.Type)
+			continue
+		}
+		address, err := fielder.GetFieldsAddress(field)
+		if err != nil {
+			return nil, err
+		}
+		if err = decode(value, address, o.StrictUnmarshal); err != nil {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalFieldValue, "invalid attribute: '%s' value: %v", name, err)
+		}
+		fieldSet = append(fieldSet, field)
+	}
+
+	for name, rel := range res.Relationships {
+		field, ok := fields[name]
+		if !ok || !field.IsRelationship() {
+			if o.StrictUnmarshal {
+				return nil, errors.WrapDetf(codec.ErrUnmarshalFieldName, "unknown relationship: '%s' for the resource: '%s'", name, res.Type)
+			}
+			log.Debug2f("Unknown relationship: '%s' for the resource: '%s'", name, res.Type)
+			continue
+		}
+		if len(rel.Data) == 0 {
+			// The relationship without the resource linkage doesn't change the relation.
+			continue
+		}
+		if err := unmarshalRelationship(o, model, field, rel.Data); err != nil {
+			return nil, err
+		}
+		fieldSet = append(fieldSet, field)
+	}
+	return fieldSet, nil
+}
+
+// unmarshalRelationship sets the relation models from the resource linkage 'data'.
+func unmarshalRelationship(o *codec.UnmarshalOptions, model mapping.Model, field *mapping.StructField, data json.RawMessage) error {
+	relatedStruct := field.Relationship().RelatedModelStruct()
+	newRelated := func(id *identifier) (mapping.Model, error) {
+		if id == nil {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "relationship: '%s' contains null resource identifier", field.CodecName())
+		}
+		if id.Type != relatedStruct.Collection() {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalDocument, "relationship: '%s' resource type: '%s' doesn't match collection: '%s'", field.CodecName(), id.Type, relatedStruct.Collection())
+		}
+		related := mapping.NewModel(relatedStruct)
+		if err := related.SetPrimaryKeyStringValue(id.ID); err != nil {
+			return nil, errors.WrapDetf(codec.ErrUnmarshalFieldValue, "invalid relationship: '%s' resource id: '%s'", field.CodecName(), id.ID)
+		}
+		return related, nil
+	}
+
+	switch field.Kind() {
+	case mapping.KindRelationshipSingle:
+		relationer, ok := model.(mapping.SingleRelationer)
+		if !ok {
+			return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.SingleRelationer interface", model)
+		}
+		var id *identifier
+		if err := decode(data, &id, o.StrictUnmarshal); err != nil {
+			return errors.WrapDetf(codec.ErrUnmarshalFieldValue, "invalid relationship: '%s' data: %v", field.CodecName(), err)
+		}
+		if id == nil {
+			return relationer.SetRelationModel(field, nil)
+		}
+		related, err := newRelated(id)
+		if err != nil {
+			return err
+		}
+		return relationer.SetRelationModel(field, related)
+	default:
+		relationer, ok := model.(mapping.MultiRelationer)
+		if !ok {
+			return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.MultiRelationer interface", model)
+		}
+		var ids []*identifier
+		if err := decode(data, &ids, o.StrictUnmarshal); err != nil {
+			return errors.WrapDetf(codec.ErrUnmarshalFieldValue, "invalid relationship: '%s' data: %v", field.CodecName(), err)
+		}
+		models := make([]mapping.Model, len(ids))
+		for i, id := range ids {
+			var err error
+			if models[i], err = newRelated(id); err != nil {
+				return err
+			}
+		}
+		return relationer.SetRelationModels(field, models...)
+	}
+}
+
+// codecFields maps the model's attributes and relationships by their codec names.
+func codecFields(mStruct *mapping.ModelStruct) map[string]*mapping.StructField {
+	fields := map[string]*mapping.StructField{}
+	for _, field := range mStruct.StructFields() {
+		if field.CodecSkip() {
+			continue
+		}
+		switch field.Kind() {
+		case mapping.KindAttribute, mapping.KindRelationshipSingle, mapping.KindRelationshipMultiple:
+			fields[field.CodecName()] = field
+		}
+	}
+	return fields
+}
+
+// decode unmarshals the 'data' into 'dst'. If the 'strict' flag is set the unknown fields are not allowed.
+func decode(data []byte, dst interface{}, strict bool) error {
+	if !strict {
+		return json.Unmarshal(data, dst)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(dst)
+}
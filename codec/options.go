@@ -2,6 +2,7 @@ package codec
 
 import (
 	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
 )
 
 // ISO8601TimeFormat is the time formatting for the ISO 8601.
@@ -51,8 +52,9 @@ type LinkOptions struct {
 
 // MarshalOptions is a structure that contains marshaling information.
 type MarshalOptions struct {
-	Link         LinkOptions
-	SingleResult bool
+	Link              LinkOptions
+	SingleResult      bool
+	IncludedRelations []*query.IncludedRelation
 }
 
 // MarshalOption is the option function that sets up the marshal options.
@@ -72,6 +74,13 @@ func MarshalSingleModel() MarshalOption {
 	}
 }
 
+// MarshalWithIncludedRelations marshals the output with provided included relations.
+func MarshalWithIncludedRelations(included ...*query.IncludedRelation) MarshalOption {
+	return func(o *MarshalOptions) {
+		o.IncludedRelations = included
+	}
+}
+
 // Meta is used to represent a `meta` object.
 // http://jsonapi.org/format/#document-meta
 type Meta map[string]interface{}
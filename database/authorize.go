@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/query"
+)
+
+// QueryAuthorizer is the interface used to authorize the queries before they are executed in the repositories.
+// The authorizer might deny the query by returning an error, or narrow it by adding the filters to the scope.
+// The relation queries are not authorized directly - instead the queries executed on the related models are.
+type QueryAuthorizer interface {
+	AuthorizeQuery(ctx context.Context, db DB, method query.Method, s *query.Scope) error
+}
+
+type skipAuthorizationKey struct{}
+
+// CtxWithSkipAuthorization creates the context for the queries that are not authorized by the query authorizer.
+// It should be used only for the internal, trusted queries - i.e. the auth packages queries on their own models.
+func CtxWithSkipAuthorization(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipAuthorizationKey{}, true)
+}
+
+// CtxSkipAuthorization checks if the queries with the context 'ctx' skip the query authorization.
+func CtxSkipAuthorization(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipAuthorizationKey{}).(bool)
+	return skip
+}
+
+type queryAuthorizerGetter interface {
+	// queryAuthorizer gets the query authorizer if set.
+	queryAuthorizer() QueryAuthorizer
+}
+
+// queryAuthorizer implements queryAuthorizerGetter interface.
+func (b *Database) queryAuthorizer() QueryAuthorizer {
+	return b.options.QueryAuthorizer
+}
+
+// queryAuthorizer implements queryAuthorizerGetter interface.
+func (t *Tx) queryAuthorizer() QueryAuthorizer {
+	return t.options.QueryAuthorizer
+}
+
+// authorizeQuery authorizes the query scope 's' for given 'method' using the 'db' query authorizer.
+func authorizeQuery(ctx context.Context, db DB, method query.Method, s *query.Scope) error {
+	getter, ok := db.(queryAuthorizerGetter)
+	if !ok {
+		return nil
+	}
+	authorizer := getter.queryAuthorizer()
+	if authorizer == nil || CtxSkipAuthorization(ctx) {
+		return nil
+	}
+	return authorizer.AuthorizeQuery(ctx, db, method, s)
+}
+
+// scopeMethod gets the 'single' method if the scope 's' affects a single model, otherwise the 'many' method.
+func scopeMethod(s *query.Scope, single, many query.Method) query.Method {
+	if len(s.Filters) > 0 || len(s.Models) > 1 {
+		return many
+	}
+	return single
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+)
+
+var errTestForbidden = errors.New("test forbidden")
+
+type testAuthorizer struct {
+	methods []query.Method
+	deny    query.Method
+	narrow  filter.Filter
+}
+
+func (a *testAuthorizer) AuthorizeQuery(_ context.Context, _ DB, method query.Method, s *query.Scope) error {
+	a.methods = append(a.methods, method)
+	if method == a.deny {
+		return errTestForbidden
+	}
+	if a.narrow != nil && len(s.Models) == 0 {
+		s.Filter(a.narrow)
+	}
+	return nil
+}
+
+func TestQueryAuthorizer(t *testing.T) {
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(testmodels.Neuron_Models...))
+	authorizer := &testAuthorizer{}
+	db, err := New(WithDefaultRepository(memrepo.New()), WithModelMap(m), WithQueryAuthorizer(authorizer))
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, db.Dial(ctx))
+
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	require.NoError(t, db.Insert(ctx, mStruct, &testmodels.Blog{Title: "first"}, &testmodels.Blog{Title: "second"}))
+	_, err = db.QueryCtx(ctx, mStruct).Find()
+	require.NoError(t, err)
+	_, err = db.QueryCtx(ctx, mStruct).Where("ID = ?", 1).Get()
+	require.NoError(t, err)
+	_, err = db.QueryCtx(ctx, mStruct).Count()
+	require.NoError(t, err)
+	_, err = db.Update(ctx, mStruct, &testmodels.Blog{ID: 1, Title: "changed"})
+	require.NoError(t, err)
+	_, err = db.QueryCtx(ctx, mStruct).Where("ID = ?", 2).Delete()
+	require.NoError(t, err)
+	assert.Equal(t, []query.Method{query.InsertMany, query.List, query.Get, query.List, query.Update, query.DeleteMany}, authorizer.methods)
+
+	authorizer.deny = query.Insert
+	err = db.Insert(ctx, mStruct, &testmodels.Blog{Title: "denied"})
+	assert.True(t, errors.Is(err, errTestForbidden))
+	require.NoError(t, db.Insert(CtxWithSkipAuthorization(ctx), mStruct, &testmodels.Blog{Title: "trusted"}))
+
+	authorizer.narrow = filter.New(mStruct.MustFieldByName("Title"), filter.OpEqual, "other")
+	models, err := db.QueryCtx(ctx, mStruct).Find()
+	require.NoError(t, err)
+	assert.Empty(t, models)
+}
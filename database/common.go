@@ -12,6 +12,9 @@ import (
 
 // Count gets given scope models count.
 func Count(ctx context.Context, db DB, s *query.Scope) (int64, error) {
+	if err := authorizeQuery(ctx, db, query.List, s); err != nil {
+		return 0, err
+	}
 	filterSoftDeleted(s)
 	return getRepository(db, s).Count(ctx, s)
 }
@@ -22,6 +25,9 @@ func Exists(ctx context.Context, db DB, s *query.Scope) (bool, error) {
 	if !isExister {
 		return false, errors.Wrapf(repository.ErrNotImplements, "repository for model: '%s' doesn't implement Exister interface", s.ModelStruct)
 	}
+	if err := authorizeQuery(ctx, db, query.List, s); err != nil {
+		return false, err
+	}
 	filterSoftDeleted(s)
 	return exister.Exists(ctx, s)
 }
@@ -13,6 +13,9 @@ import (
 
 // deleteQuery deletes the values provided in the query's scope.
 func deleteQuery(ctx context.Context, db DB, s *query.Scope) (int64, error) {
+	if err := authorizeQuery(ctx, db, scopeMethod(s, query.Delete, query.DeleteMany), s); err != nil {
+		return 0, err
+	}
 	if len(s.Filters) > 0 {
 		return deleteFiltered(ctx, db, s)
 	}
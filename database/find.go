@@ -13,6 +13,16 @@ import (
 // queryFind gets the values from the repository with respect to the query filters, sorts, pagination and included values.
 // Provided 'ctx' context.Context would be used while querying the repositories.
 func queryFind(ctx context.Context, db DB, s *query.Scope) ([]mapping.Model, error) {
+	if len(s.Models) == 0 {
+		if err := authorizeQuery(ctx, db, query.List, s); err != nil {
+			return nil, err
+		}
+	}
+	return find(ctx, db, s)
+}
+
+// find gets the values from the repository without the query authorization.
+func find(ctx context.Context, db DB, s *query.Scope) ([]mapping.Model, error) {
 	if len(s.Models) > 0 {
 		if err := refreshQuery(ctx, db, s); err != nil {
 			return nil, err
@@ -76,9 +86,12 @@ func queryGet(ctx context.Context, db DB, s *query.Scope) (mapping.Model, error)
 	if s.Pagination != nil && (s.Pagination.Limit != 1 || s.Pagination.Offset != 0) {
 		return nil, errors.Wrapf(query.ErrInvalidField, "cannot get single model with custom pagination")
 	}
+	if err = authorizeQuery(ctx, db, query.Get, s); err != nil {
+		return nil, err
+	}
 	// Assure that the result would be only a single value.
 	s.Limit(1)
-	results, err := queryFind(ctx, db, s)
+	results, err := find(ctx, db, s)
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,9 @@ func queryInsert(ctx context.Context, db DB, s *query.Scope) (err error) {
 		log.Debug(logFormat(s, "provided empty models slice to insert"))
 		return errors.Wrap(query.ErrInvalidModels, "nothing to insert")
 	}
+	if err = authorizeQuery(ctx, db, scopeMethod(s, query.Insert, query.InsertMany), s); err != nil {
+		return err
+	}
 
 	// Check if models repository implements Inserter interface.
 	// Execute BeforeInsert hook if model implements BeforeInserter interface.
@@ -20,6 +20,8 @@ type Options struct {
 	SynchronousConnections bool
 	// MigrateModels are the models set up for database migration.
 	MigrateModels []mapping.Model
+	// QueryAuthorizer authorizes the queries before they are executed in the repositories.
+	QueryAuthorizer QueryAuthorizer
 }
 
 // Option is an option function for the database settings.
@@ -66,3 +68,10 @@ func WithMigrateModels(models ...mapping.Model) Option {
 		o.MigrateModels = append(o.MigrateModels, models...)
 	}
 }
+
+// WithQueryAuthorizer sets the query authorizer that authorizes all the queries before they are executed.
+func WithQueryAuthorizer(authorizer QueryAuthorizer) Option {
+	return func(o *Options) {
+		o.QueryAuthorizer = authorizer
+	}
+}
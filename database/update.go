@@ -16,6 +16,9 @@ func queryUpdate(ctx context.Context, db DB, s *query.Scope) (modelsAffected int
 	if log.CurrentLevel().IsAllowed(log.LevelDebug2) {
 		log.Debug2f(logFormat(s, "update %s begins."), s.ModelStruct.Collection())
 	}
+	if err = authorizeQuery(ctx, db, scopeMethod(s, query.Update, query.UpdateMany), s); err != nil {
+		return 0, err
+	}
 
 	// If any filter is applied use it as update query.
 	if len(s.Filters) != 0 {
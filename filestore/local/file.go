@@ -0,0 +1,170 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/filestore"
+)
+
+// Compile time check for the filestore interfaces.
+var (
+	_ filestore.File      = &File{}
+	_ filestore.Metadater = &File{}
+)
+
+// File is the local file store file. The file content written with Write is stored on the store PutFile.
+type File struct {
+	name, bucket, directory, version string
+	modifiedAt                       time.Time
+	size                             int64
+	metadata                         map[string]interface{}
+
+	// path is the system path of the file content, empty for the files that were never stored.
+	path    string
+	reader  *os.File
+	written *bytes.Buffer
+}
+
+// Name implements filestore.File interface.
+func (f *File) Name() string {
+	return f.name
+}
+
+// Bucket implements filestore.File interface.
+func (f *File) Bucket() string {
+	return f.bucket
+}
+
+// Directory implements filestore.File interface.
+func (f *File) Directory() string {
+	return f.directory
+}
+
+// Version implements filestore.File interface.
+func (f *File) Version() string {
+	return f.version
+}
+
+// ModifiedAt implements filestore.File interface.
+func (f *File) ModifiedAt() time.Time {
+	return f.modifiedAt
+}
+
+// Size implements filestore.File interface.
+func (f *File) Size() int64 {
+	return f.size
+}
+
+// Open implements filestore.File interface.
+func (f *File) Open(_ context.Context) error {
+	if f.reader != nil {
+		return errors.WrapDetf(filestore.ErrAlreadyOpened, "file: '%s' is already opened", f.name)
+	}
+	if f.path == "" {
+		return errors.WrapDetf(filestore.ErrNotExists, "file: '%s' is not stored yet", f.name)
+	}
+	reader, err := os.Open(f.path)
+	if err != nil {
+		return fileError(err, f.name)
+	}
+	f.reader = reader
+	return nil
+}
+
+// Close implements filestore.File interface.
+func (f *File) Close(_ context.Context) error {
+	if f.reader == nil {
+		return errors.WrapDetf(filestore.ErrNotOpened, "file: '%s' is not opened", f.name)
+	}
+	err := f.reader.Close()
+	f.reader = nil
+	if err != nil {
+		return errors.WrapDetf(filestore.ErrInternal, "closing file: '%s' failed: %v", f.name, err)
+	}
+	return nil
+}
+
+// Read implements filestore.File interface.
+func (f *File) Read(data []byte) (int, error) {
+	if f.reader == nil {
+		return 0, errors.WrapDetf(filestore.ErrNotOpened, "file: '%s' is not opened", f.name)
+	}
+	return f.reader.Read(data)
+}
+
+// Write implements filestore.File interface. The written content replaces the file content on PutFile.
+func (f *File) Write(data []byte) (int, error) {
+	if f.written == nil {
+		f.written = &bytes.Buffer{}
+	}
+	return f.written.Write(data)
+}
+
+// Metadata implements filestore.Metadater interface.
+func (f *File) Metadata() map[string]interface{} {
+	return f.metadata
+}
+
+// GetMeta implements filestore.Metadater interface. The metadata values read from the store are decoded from
+// the JSON, i.e. all numbers are float64.
+func (f *File) GetMeta(key string) (interface{}, bool) {
+	value, ok := f.metadata[key]
+	return value, ok
+}
+
+// SetMeta implements filestore.Metadater interface.
+func (f *File) SetMeta(key string, value interface{}) {
+	if f.metadata == nil {
+		f.metadata = map[string]interface{}{}
+	}
+	f.metadata[key] = value
+}
+
+// content gets the file content to store. If no data were written, the stored content is taken.
+func (f *File) content() ([]byte, error) {
+	if f.written != nil {
+		return f.written.Bytes(), nil
+	}
+	if f.path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, fileError(err, f.name)
+	}
+	return data, nil
+}
+
+// setStat sets the file size and modification time from the file at 'path'.
+func (f *File) setStat(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileError(err, f.name)
+	}
+	if info.IsDir() {
+		return errors.WrapDetf(filestore.ErrFileIsDir, "file: '%s' is a directory", f.name)
+	}
+	f.path = path
+	f.size = info.Size()
+	f.modifiedAt = info.ModTime()
+	return nil
+}
+
+// fileError converts the os error into the file store error.
+func fileError(err error, name string) error {
+	switch {
+	case os.IsNotExist(err):
+		return errors.WrapDetf(filestore.ErrNotExists, "file: '%s' doesn't exists", name)
+	case os.IsPermission(err):
+		return errors.WrapDetf(filestore.ErrPermission, "file: '%s' permission denied", name)
+	case os.IsExist(err):
+		return errors.WrapDetf(filestore.ErrExists, "file: '%s' already exists", name)
+	}
+	return errors.WrapDetf(filestore.ErrInternal, "file: '%s' - %v", filepath.Base(name), err)
+}
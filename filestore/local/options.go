@@ -0,0 +1,48 @@
+package local
+
+import (
+	"os"
+)
+
+// Options are the local file store settings.
+type Options struct {
+	// RootDirectory is the directory where all the buckets and files are stored. Required.
+	RootDirectory string
+	// Versions enables the file version history. Each put of the file creates its new version.
+	Versions bool
+	// DirectoryPermissions are the permissions used for created directories. By default set to 0755.
+	DirectoryPermissions os.FileMode
+	// FilePermissions are the permissions used for created files. By default set to 0644.
+	FilePermissions os.FileMode
+}
+
+// Option is a function that changes the local store options.
+type Option func(o *Options)
+
+// WithRootDirectory sets the root directory of the store.
+func WithRootDirectory(root string) Option {
+	return func(o *Options) {
+		o.RootDirectory = root
+	}
+}
+
+// WithVersions enables the file versions history.
+func WithVersions() Option {
+	return func(o *Options) {
+		o.Versions = true
+	}
+}
+
+// WithDirectoryPermissions sets the permissions for the directories created by the store.
+func WithDirectoryPermissions(perm os.FileMode) Option {
+	return func(o *Options) {
+		o.DirectoryPermissions = perm
+	}
+}
+
+// WithFilePermissions sets the permissions for the files created by the store.
+func WithFilePermissions(perm os.FileMode) Option {
+	return func(o *Options) {
+		o.FilePermissions = perm
+	}
+}
@@ -0,0 +1,94 @@
+package local
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/filestore"
+)
+
+const (
+	// hiddenPrefix is the prefix of the files and directories used internally by the store.
+	hiddenPrefix = "."
+	// metaSuffix is the suffix of the file metadata.
+	metaSuffix = ".meta"
+	// versionsSuffix is the suffix of the file versions directory.
+	versionsSuffix = ".versions"
+)
+
+// validateName checks if the file 'name' is a single path element that is not reserved by the store.
+func validateName(name string) error {
+	switch {
+	case name == "":
+		return errors.WrapDet(filestore.ErrFileName, "empty file name")
+	case strings.ContainsAny(name, `/\`) || name != filepath.Base(name):
+		return errors.WrapDetf(filestore.ErrFileName, "file name: '%s' contains path separator", name)
+	case strings.HasPrefix(name, hiddenPrefix):
+		return errors.WrapDetf(filestore.ErrFileName, "file name: '%s' cannot start with: '%s'", name, hiddenPrefix)
+	}
+	return nil
+}
+
+// validateVersion checks if the 'version' is valid store file version.
+func validateVersion(version string) error {
+	if _, err := strconv.ParseUint(version, 10, 64); err != nil {
+		return errors.WrapDetf(filestore.ErrFileName, "invalid file version: '%s'", version)
+	}
+	return nil
+}
+
+// cleanPath validates and cleans the slash separated bucket or directory 'p'. The path cannot be absolute, nor
+// contain any parent directory or hidden elements.
+func cleanPath(p string) (string, error) {
+	if p == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(p, "/") || strings.Contains(p, `\`) || filepath.IsAbs(p) {
+		return "", errors.WrapDetf(filestore.ErrFileName, "path: '%s' cannot be absolute", p)
+	}
+	for _, element := range strings.Split(p, "/") {
+		if element == ".." || (strings.HasPrefix(element, hiddenPrefix) && element != ".") {
+			return "", errors.WrapDetf(filestore.ErrFileName, "path: '%s' contains forbidden element: '%s'", p, element)
+		}
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(p))
+	if cleaned == "." {
+		return "", nil
+	}
+	return cleaned, nil
+}
+
+// directoryPath gets the system path of the 'bucket' 'directory'. The result is always within the root directory.
+func (s *Store) directoryPath(bucket, directory string) (string, error) {
+	cleanBucket, err := cleanPath(bucket)
+	if err != nil {
+		return "", err
+	}
+	cleanDirectory, err := cleanPath(directory)
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(s.root, cleanBucket, cleanDirectory)
+	rel, err := filepath.Rel(s.root, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.WrapDetf(filestore.ErrFileName, "path: '%s/%s' is outside of the store root", bucket, directory)
+	}
+	return p, nil
+}
+
+// metaPath gets the metadata path of the current file 'name' in the 'dir'.
+func metaPath(dir, name string) string {
+	return filepath.Join(dir, hiddenPrefix+name+metaSuffix)
+}
+
+// versionsPath gets the versions directory of the file 'name' in the 'dir'.
+func versionsPath(dir, name string) string {
+	return filepath.Join(dir, hiddenPrefix+name+versionsSuffix)
+}
+
+// versionPath gets the path of the file 'name' 'version' in the 'dir'.
+func versionPath(dir, name, version string) string {
+	return filepath.Join(versionsPath(dir, name), version)
+}
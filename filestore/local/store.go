@@ -0,0 +1,471 @@
+// Package local implements the filestore.Store that keeps the files in the local filesystem.
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/filestore"
+)
+
+// StoreType is the type name of the local file store.
+const StoreType = "local"
+
+// Compile time check for the filestore.Store interface.
+var _ filestore.Store = &Store{}
+
+// Store is the filestore.Store implementation that maps the buckets and directories to the folders within
+// the options root directory. The metadata of the file is stored next to it in the hidden '.<name>.meta' file.
+// If the versions are enabled, each put stores new version of the file in the hidden '.<name>.versions' directory,
+// and the latest version is also available as the file itself. The file names, buckets and directories cannot
+// point outside of the root directory nor refer to the hidden store files.
+type Store struct {
+	Options *Options
+
+	root string
+	lock sync.Mutex
+}
+
+// New creates new local file store. The root directory is created if it doesn't exist.
+func New(options ...Option) (*Store, error) {
+	o := &Options{
+		DirectoryPermissions: 0755,
+		FilePermissions:      0644,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	if o.RootDirectory == "" {
+		return nil, errors.WrapDet(filestore.ErrStore, "no root directory defined for the local file store")
+	}
+	root, err := filepath.Abs(o.RootDirectory)
+	if err != nil {
+		return nil, errors.WrapDetf(filestore.ErrStore, "invalid root directory: '%s': %v", o.RootDirectory, err)
+	}
+	if err = os.MkdirAll(root, o.DirectoryPermissions); err != nil {
+		return nil, errors.WrapDetf(filestore.ErrStore, "creating root directory: '%s' failed: %v", root, err)
+	}
+	return &Store{Options: o, root: root}, nil
+}
+
+// Type implements filestore.Store interface.
+func (s *Store) Type() string {
+	return StoreType
+}
+
+// NewFile implements filestore.Store interface.
+func (s *Store) NewFile(_ context.Context, name string, options ...filestore.FileOption) (filestore.File, error) {
+	o := &filestore.FileOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	if _, err := s.directoryPath(o.Bucket, o.Directory); err != nil {
+		return nil, err
+	}
+	return &File{name: name, bucket: o.Bucket, directory: o.Directory}, nil
+}
+
+// PutFile implements filestore.Store interface. If the versions are disabled, an existing file is replaced only
+// with the PutWithOverwrite option. Otherwise a new version of the file is created, unless the file has a version
+// and the PutWithOverwrite option is set - then that version is replaced. If no content were written to the file,
+// it keeps its stored content.
+func (s *Store) PutFile(_ context.Context, file filestore.File, options ...filestore.PutOption) error {
+	o := &filestore.PutOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	f, ok := file.(*File)
+	if !ok {
+		return errors.WrapDetf(filestore.ErrStore, "file: '%T' doesn't belong to the local file store", file)
+	}
+	if err := validateName(f.name); err != nil {
+		return err
+	}
+	dir, err := s.directoryPath(f.bucket, f.directory)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	content, err := f.content()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, s.Options.DirectoryPermissions); err != nil {
+		return fileError(err, f.name)
+	}
+	currentPath := filepath.Join(dir, f.name)
+	info, err := os.Stat(currentPath)
+	exists := err == nil
+	if exists && info.IsDir() {
+		return errors.WrapDetf(filestore.ErrFileIsDir, "file: '%s' is a directory", f.name)
+	}
+
+	if !s.Options.Versions {
+		if f.version != "" {
+			return errors.WrapDetf(filestore.ErrVersionsNotAllowed, "file: '%s' version: '%s' is not allowed", f.name, f.version)
+		}
+		if exists && !o.Overwrite {
+			return errors.WrapDetf(filestore.ErrExists, "file: '%s' already exists", f.name)
+		}
+		if err = s.writeFile(currentPath, content); err != nil {
+			return err
+		}
+		if err = s.writeMeta(metaPath(dir, f.name), &meta{Metadata: f.metadata}); err != nil {
+			return err
+		}
+		f.written = nil
+		return f.setStat(currentPath)
+	}
+
+	version := f.version
+	if o.Overwrite && version != "" {
+		if err = validateVersion(version); err != nil {
+			return err
+		}
+		if _, err = os.Stat(versionPath(dir, f.name, version)); err != nil {
+			return fileError(err, f.name)
+		}
+	} else if version, err = s.newVersion(dir, f.name); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(versionsPath(dir, f.name), s.Options.DirectoryPermissions); err != nil {
+		return fileError(err, f.name)
+	}
+	p := versionPath(dir, f.name, version)
+	if err = s.writeFile(p, content); err != nil {
+		return err
+	}
+	if err = s.writeMeta(p+metaSuffix, &meta{Version: version, Metadata: f.metadata}); err != nil {
+		return err
+	}
+	latest, err := latestVersion(dir, f.name)
+	if err != nil {
+		return err
+	}
+	if version == latest {
+		if err = s.setCurrent(dir, f.name, version); err != nil {
+			return err
+		}
+	}
+	f.version = version
+	f.written = nil
+	return f.setStat(p)
+}
+
+// GetFile implements filestore.Store interface. If the GetWithVersion option is set, given version of the file is
+// taken. Otherwise the latest version is returned.
+func (s *Store) GetFile(_ context.Context, name string, options ...filestore.GetOption) (filestore.File, error) {
+	o := &filestore.GetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	dir, err := s.directoryPath(o.Bucket, o.Directory)
+	if err != nil {
+		return nil, err
+	}
+	f := &File{name: name, bucket: o.Bucket, directory: o.Directory}
+	if o.Version == "" {
+		if err = s.readFile(f, filepath.Join(dir, name), metaPath(dir, name)); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	if !s.Options.Versions {
+		return nil, errors.WrapDetf(filestore.ErrVersionsNotAllowed, "file: '%s' version: '%s' is not allowed", name, o.Version)
+	}
+	if err = validateVersion(o.Version); err != nil {
+		return nil, err
+	}
+	p := versionPath(dir, name, o.Version)
+	if err = s.readFile(f, p, p+metaSuffix); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ListVersions lists all versions of the file with given 'name' starting from the oldest. The GetWithVersion
+// option is not used.
+func (s *Store) ListVersions(_ context.Context, name string, options ...filestore.GetOption) ([]filestore.File, error) {
+	if !s.Options.Versions {
+		return nil, errors.WrapDet(filestore.ErrVersionsNotAllowed, "file versions are not enabled for the store")
+	}
+	o := &filestore.GetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	dir, err := s.directoryPath(o.Bucket, o.Directory)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := listVersions(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]filestore.File, len(versions))
+	for i, version := range versions {
+		f := &File{name: name, bucket: o.Bucket, directory: o.Directory}
+		p := versionPath(dir, name, version)
+		if err = s.readFile(f, p, p+metaSuffix); err != nil {
+			return nil, err
+		}
+		files[i] = f
+	}
+	return files, nil
+}
+
+// ListFiles implements filestore.Store interface. The files are sorted by their names.
+func (s *Store) ListFiles(_ context.Context, directory string, options ...filestore.ListOption) ([]filestore.File, error) {
+	o := &filestore.ListOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.Limit < 0 || o.Offset < 0 {
+		return nil, errors.WrapDetf(filestore.ErrStore, "invalid list limit: %d or offset: %d", o.Limit, o.Offset)
+	}
+	dir, err := s.directoryPath(o.Bucket, directory)
+	if err != nil {
+		return nil, err
+	}
+	extension := o.Extension
+	if extension != "" && !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []filestore.File{}, nil
+		}
+		return nil, fileError(err, directory)
+	}
+	var names []string
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), hiddenPrefix) {
+			continue
+		}
+		if extension != "" && filepath.Ext(info.Name()) != extension {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	if o.Offset >= len(names) {
+		return []filestore.File{}, nil
+	}
+	names = names[o.Offset:]
+	if o.Limit > 0 && o.Limit < len(names) {
+		names = names[:o.Limit]
+	}
+
+	files := make([]filestore.File, len(names))
+	for i, name := range names {
+		f := &File{name: name, bucket: o.Bucket, directory: directory}
+		if err = s.readFile(f, filepath.Join(dir, name), metaPath(dir, name)); err != nil {
+			return nil, err
+		}
+		files[i] = f
+	}
+	return files, nil
+}
+
+// DeleteFile implements filestore.Store interface. If the versions are enabled and the file has a version, only
+// that version is deleted and the previous one becomes the latest. Otherwise the file is deleted along with its
+// metadata and all versions.
+func (s *Store) DeleteFile(_ context.Context, file filestore.File) error {
+	if err := validateName(file.Name()); err != nil {
+		return err
+	}
+	dir, err := s.directoryPath(file.Bucket(), file.Directory())
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	name := file.Name()
+	if !s.Options.Versions || file.Version() == "" {
+		if err = os.Remove(filepath.Join(dir, name)); err != nil {
+			return fileError(err, name)
+		}
+		return s.removeAll(name, metaPath(dir, name), versionsPath(dir, name))
+	}
+
+	version := file.Version()
+	if err = validateVersion(version); err != nil {
+		return err
+	}
+	p := versionPath(dir, name, version)
+	if err = os.Remove(p); err != nil {
+		return fileError(err, name)
+	}
+	if err = s.removeAll(name, p+metaSuffix); err != nil {
+		return err
+	}
+	latest, err := latestVersion(dir, name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case latest == "":
+		return s.removeAll(name, filepath.Join(dir, name), metaPath(dir, name), versionsPath(dir, name))
+	case version > latest:
+		return s.setCurrent(dir, name, latest)
+	}
+	return nil
+}
+
+// meta is the stored file metadata.
+type meta struct {
+	Version  string                 `json:"version,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// readFile sets the file 'f' stats from the file at 'path' and its metadata from the 'metaPath'.
+func (s *Store) readFile(f *File, path, metaPath string) error {
+	if err := f.setStat(path); err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fileError(err, f.name)
+	}
+	m := &meta{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return errors.WrapDetf(filestore.ErrInternal, "file: '%s' invalid metadata: %v", f.name, err)
+	}
+	f.version = m.Version
+	f.metadata = m.Metadata
+	return nil
+}
+
+// setCurrent copies the file 'version' with its metadata as the current file.
+func (s *Store) setCurrent(dir, name, version string) error {
+	p := versionPath(dir, name, version)
+	content, err := ioutil.ReadFile(p)
+	if err != nil {
+		return fileError(err, name)
+	}
+	if err = s.writeFile(filepath.Join(dir, name), content); err != nil {
+		return err
+	}
+	metadata, err := ioutil.ReadFile(p + metaSuffix)
+	if err != nil {
+		return fileError(err, name)
+	}
+	return s.writeFile(metaPath(dir, name), metadata)
+}
+
+// writeMeta writes the metadata 'm' into the file at 'path'.
+func (s *Store) writeMeta(path string, m *meta) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return errors.WrapDetf(filestore.ErrInternal, "marshaling file metadata failed: %v", err)
+	}
+	return s.writeFile(path, data)
+}
+
+// writeFile writes the 'content' into the temporary file which is then renamed to 'path'. This way the file
+// is never partially written.
+func (s *Store) writeFile(path string, content []byte) error {
+	temp, err := ioutil.TempFile(filepath.Dir(path), hiddenPrefix+"tmp-")
+	if err != nil {
+		return fileError(err, path)
+	}
+	_, err = temp.Write(content)
+	if err == nil {
+		err = temp.Sync()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(temp.Name(), s.Options.FilePermissions)
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(temp.Name())
+		return fileError(err, path)
+	}
+	return nil
+}
+
+// removeAll removes all 'paths' of the file 'name'.
+func (s *Store) removeAll(name string, paths ...string) error {
+	for _, p := range paths {
+		if err := os.RemoveAll(p); err != nil {
+			return fileError(err, name)
+		}
+	}
+	return nil
+}
+
+// newVersion creates new time based version of the file that is greater than all its existing versions.
+func (s *Store) newVersion(dir, name string) (string, error) {
+	latest, err := latestVersion(dir, name)
+	if err != nil {
+		return "", err
+	}
+	next := time.Now().UnixNano()
+	if latest != "" {
+		if last, err := strconv.ParseInt(latest, 10, 64); err == nil && next <= last {
+			next = last + 1
+		}
+	}
+	return fmt.Sprintf("%019d", next), nil
+}
+
+// latestVersion gets the latest version of the file 'name'. Returns an empty string if the file has no versions.
+func latestVersion(dir, name string) (string, error) {
+	versions, err := listVersions(dir, name)
+	if err != nil || len(versions) == 0 {
+		return "", err
+	}
+	return versions[len(versions)-1], nil
+}
+
+// listVersions lists all the sorted versions of the file 'name'.
+func listVersions(dir, name string) ([]string, error) {
+	infos, err := ioutil.ReadDir(versionsPath(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fileError(err, name)
+	}
+	var versions []string
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), hiddenPrefix) || strings.HasSuffix(info.Name(), metaSuffix) {
+			continue
+		}
+		versions = append(versions, info.Name())
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
@@ -0,0 +1,207 @@
+package local
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/filestore"
+)
+
+func testStore(t *testing.T, options ...Option) (*Store, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "neuron-filestore")
+	require.NoError(t, err)
+	s, err := New(append([]Option{WithRootDirectory(dir)}, options...)...)
+	require.NoError(t, err)
+	return s, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func putFile(t *testing.T, s *Store, name, content string, options ...filestore.FileOption) filestore.File {
+	t.Helper()
+	ctx := context.Background()
+	file, err := s.NewFile(ctx, name, options...)
+	require.NoError(t, err)
+	_, err = file.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, s.PutFile(ctx, file))
+	return file
+}
+
+func readFile(t *testing.T, file filestore.File) string {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, file.Open(ctx))
+	data, err := ioutil.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close(ctx))
+	return string(data)
+}
+
+func TestPutGet(t *testing.T) {
+	s, cleanup := testStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	file, err := s.NewFile(ctx, "avatar.png", filestore.FileWithBucket("users"), filestore.FileWithDirectory("1/images"))
+	require.NoError(t, err)
+	_, err = file.Write([]byte("content"))
+	require.NoError(t, err)
+	file.(filestore.Metadater).SetMeta("owner", "user")
+	require.NoError(t, s.PutFile(ctx, file))
+
+	_, err = os.Stat(filepath.Join(s.Options.RootDirectory, "users", "1", "images", "avatar.png"))
+	require.NoError(t, err)
+
+	got, err := s.GetFile(ctx, "avatar.png", filestore.GetWithBucket("users"), filestore.GetWithDirectory("1/images"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), got.Size())
+	assert.False(t, got.ModifiedAt().IsZero())
+	assert.Equal(t, "", got.Version())
+	assert.Equal(t, "content", readFile(t, got))
+	owner, ok := got.(filestore.Metadater).GetMeta("owner")
+	require.True(t, ok)
+	assert.Equal(t, "user", owner)
+
+	_, err = s.GetFile(ctx, "avatar.png")
+	assert.True(t, errors.Is(err, filestore.ErrNotExists))
+
+	// The file exists and cannot be stored without the overwrite option.
+	file, err = s.NewFile(ctx, "avatar.png", filestore.FileWithBucket("users"), filestore.FileWithDirectory("1/images"))
+	require.NoError(t, err)
+	_, err = file.Write([]byte("changed"))
+	require.NoError(t, err)
+	err = s.PutFile(ctx, file)
+	assert.True(t, errors.Is(err, filestore.ErrExists))
+	require.NoError(t, s.PutFile(ctx, file, filestore.PutWithOverwrite()))
+
+	got, err = s.GetFile(ctx, "avatar.png", filestore.GetWithBucket("users"), filestore.GetWithDirectory("1/images"))
+	require.NoError(t, err)
+	assert.Equal(t, "changed", readFile(t, got))
+
+	_, err = s.GetFile(ctx, "avatar.png", filestore.GetWithVersion("1"))
+	assert.True(t, errors.Is(err, filestore.ErrVersionsNotAllowed))
+
+	require.NoError(t, s.DeleteFile(ctx, got))
+	_, err = s.GetFile(ctx, "avatar.png", filestore.GetWithBucket("users"), filestore.GetWithDirectory("1/images"))
+	assert.True(t, errors.Is(err, filestore.ErrNotExists))
+	assert.True(t, errors.Is(s.DeleteFile(ctx, got), filestore.ErrNotExists))
+}
+
+func TestVersions(t *testing.T) {
+	s, cleanup := testStore(t, WithVersions())
+	defer cleanup()
+	ctx := context.Background()
+
+	first := putFile(t, s, "doc.txt", "first")
+	second := putFile(t, s, "doc.txt", "second")
+	require.NotEqual(t, first.Version(), second.Version())
+	assert.True(t, first.Version() < second.Version())
+
+	latest, err := s.GetFile(ctx, "doc.txt")
+	require.NoError(t, err)
+	assert.Equal(t, second.Version(), latest.Version())
+	assert.Equal(t, "second", readFile(t, latest))
+
+	old, err := s.GetFile(ctx, "doc.txt", filestore.GetWithVersion(first.Version()))
+	require.NoError(t, err)
+	assert.Equal(t, "first", readFile(t, old))
+
+	// Put of the fetched file without overwrite creates new version.
+	_, err = latest.Write([]byte("third"))
+	require.NoError(t, err)
+	require.NoError(t, s.PutFile(ctx, latest))
+	versions, err := s.ListVersions(ctx, "doc.txt")
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, latest.Version(), versions[2].Version())
+
+	// Overwrite replaces given version.
+	_, err = old.Write([]byte("first changed"))
+	require.NoError(t, err)
+	require.NoError(t, s.PutFile(ctx, old, filestore.PutWithOverwrite()))
+	old, err = s.GetFile(ctx, "doc.txt", filestore.GetWithVersion(first.Version()))
+	require.NoError(t, err)
+	assert.Equal(t, "first changed", readFile(t, old))
+
+	// Deleting the latest version makes the previous one current.
+	require.NoError(t, s.DeleteFile(ctx, latest))
+	current, err := s.GetFile(ctx, "doc.txt")
+	require.NoError(t, err)
+	assert.Equal(t, second.Version(), current.Version())
+	assert.Equal(t, "second", readFile(t, current))
+
+	_, err = s.GetFile(ctx, "doc.txt", filestore.GetWithVersion("../../doc.txt"))
+	assert.True(t, errors.Is(err, filestore.ErrFileName))
+
+	// Deleting the file without version deletes all versions.
+	unversioned, err := s.NewFile(ctx, "doc.txt")
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteFile(ctx, unversioned))
+	versions, err = s.ListVersions(ctx, "doc.txt")
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestListFiles(t *testing.T) {
+	s, cleanup := testStore(t, WithVersions())
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, name := range []string{"c.txt", "a.txt", "b.png", "d.txt"} {
+		putFile(t, s, name, name, filestore.FileWithDirectory("docs"))
+	}
+	putFile(t, s, "other.txt", "other", filestore.FileWithBucket("bucket"), filestore.FileWithDirectory("docs"))
+
+	names := func(files []filestore.File) []string {
+		var result []string
+		for _, file := range files {
+			result = append(result, file.Name())
+		}
+		return result
+	}
+
+	files, err := s.ListFiles(ctx, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "b.png", "c.txt", "d.txt"}, names(files))
+
+	files, err = s.ListFiles(ctx, "docs", filestore.ListWithExtension("txt"), filestore.ListWithOffset(1), filestore.ListWithLimit(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c.txt"}, names(files))
+	assert.NotEmpty(t, files[0].Version())
+
+	files, err = s.ListFiles(ctx, "docs", filestore.ListWithBucket("bucket"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other.txt"}, names(files))
+
+	files, err = s.ListFiles(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestPathTraversal(t *testing.T) {
+	s, cleanup := testStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, name := range []string{"", "..", ".hidden", "../file", "dir/file", `dir\file`} {
+		_, err := s.NewFile(ctx, name)
+		assert.True(t, errors.Is(err, filestore.ErrFileName), name)
+	}
+	for _, dir := range []string{"..", "../other", "a/../../b", "/etc", "a/.file.versions"} {
+		_, err := s.NewFile(ctx, "file", filestore.FileWithDirectory(dir))
+		assert.True(t, errors.Is(err, filestore.ErrFileName), dir)
+		_, err = s.GetFile(ctx, "file", filestore.GetWithBucket(dir))
+		assert.True(t, errors.Is(err, filestore.ErrFileName), dir)
+		_, err = s.ListFiles(ctx, dir)
+		assert.True(t, errors.Is(err, filestore.ErrFileName), dir)
+	}
+}
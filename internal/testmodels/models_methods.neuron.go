@@ -30,6 +30,7 @@ var Neuron_Models = []mapping.Model{
 	&Post{},
 	&RelatedModel{},
 	&TestingModel{},
+	&User{},
 }
 
 // Compile time check if Blog implements mapping.Model interface.
@@ -4561,3 +4562,267 @@ func (t *TestingModel) SetRelationModel(relation *mapping.StructField, model map
 		return errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, t)
 	}
 }
+
+// Compile time check if User implements mapping.Model interface.
+var _ mapping.Model = &User{}
+
+// NeuronCollectionName implements mapping.Model interface method.
+// Returns the name of the collection for the 'User'.
+func (u *User) NeuronCollectionName() string {
+	return "users"
+}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (u *User) IsPrimaryKeyZero() bool {
+	return u.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyValue() interface{} {
+	return u.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(u.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyAddress() interface{} {
+	return &u.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyHashableValue() interface{} {
+	return u.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (u *User) SetPrimaryKeyValue(value interface{}) error {
+	if v, ok := value.(int); ok {
+		u.ID = v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch valueType := value.(type) {
+	case int8:
+		u.ID = int(valueType)
+	case int16:
+		u.ID = int(valueType)
+	case int32:
+		u.ID = int(valueType)
+	case int64:
+		u.ID = int(valueType)
+	case uint:
+		u.ID = int(valueType)
+	case uint8:
+		u.ID = int(valueType)
+	case uint16:
+		u.ID = int(valueType)
+	case uint32:
+		u.ID = int(valueType)
+	case uint64:
+		u.ID = int(valueType)
+	case float32:
+		u.ID = int(valueType)
+	case float64:
+		u.ID = int(valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'User'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	u.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (u *User) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(query.ErrInvalidInput, "provided nil model to set from")
+	}
+	from, ok := model.(*User)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*u = *from
+	return nil
+}
+
+// Compile time check if User implements mapping.Fielder interface.
+var _ mapping.Fielder = &User{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (u *User) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &u.ID, nil
+	case 1: // Username
+		return &u.Username, nil
+	case 2: // PasswordHash
+		return &u.PasswordHash, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (u *User) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // Username
+		return "", nil
+	case 2: // PasswordHash
+		return nil, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (u *User) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID == 0, nil
+	case 1: // Username
+		return u.Username == "", nil
+	case 2: // PasswordHash
+		return len(u.PasswordHash) == 0, nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (u *User) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		u.ID = 0
+	case 1: // Username
+		u.Username = ""
+	case 2: // PasswordHash
+		u.PasswordHash = nil
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (u *User) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID, nil
+	case 1: // Username
+		return u.Username, nil
+	case 2: // PasswordHash
+		return string(u.PasswordHash), nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'User'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (u *User) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID, nil
+	case 1: // Username
+		return u.Username, nil
+	case 2: // PasswordHash
+		return u.PasswordHash, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (u *User) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if v, ok := value.(int); ok {
+			u.ID = v
+			return nil
+		}
+
+		switch v := value.(type) {
+		case int8:
+			u.ID = int(v)
+		case int16:
+			u.ID = int(v)
+		case int32:
+			u.ID = int(v)
+		case int64:
+			u.ID = int(v)
+		case uint:
+			u.ID = int(v)
+		case uint8:
+			u.ID = int(v)
+		case uint16:
+			u.ID = int(v)
+		case uint32:
+			u.ID = int(v)
+		case uint64:
+			u.ID = int(v)
+		case float32:
+			u.ID = int(v)
+		case float64:
+			u.ID = int(v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // Username
+		if v, ok := value.(string); ok {
+			u.Username = v
+			return nil
+		}
+
+		// Check alternate types for the Username.
+		if v, ok := value.([]byte); ok {
+			u.Username = string(v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // PasswordHash
+		if v, ok := value.([]byte); ok {
+			u.PasswordHash = v
+			return nil
+		}
+		if value == nil {
+			u.PasswordHash = nil
+			return nil
+		}
+
+		// Check alternate types for the PasswordHash.
+		if v, ok := value.(string); ok {
+			u.PasswordHash = []byte(v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'User'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // Username
+		return value, nil
+	case 2: // PasswordHash
+		return []byte(value), nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
@@ -0,0 +1,38 @@
+package testmodels
+
+// User is the account model for the auth tests.
+type User struct {
+	ID           int
+	Username     string
+	PasswordHash []byte
+}
+
+// GetUsername implements auth.Account interface.
+func (u *User) GetUsername() string {
+	return u.Username
+}
+
+// SetUsername implements auth.Account interface.
+func (u *User) SetUsername(username string) {
+	u.Username = username
+}
+
+// GetPasswordHash implements auth.Account interface.
+func (u *User) GetPasswordHash() []byte {
+	return u.PasswordHash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (u *User) SetPasswordHash(hash []byte) {
+	u.PasswordHash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (u *User) UsernameField() string {
+	return "Username"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (u *User) PasswordHashField() string {
+	return "PasswordHash"
+}
@@ -18,8 +18,8 @@ const (
 	fNoFilter
 	// fLanguage is the language field flag.
 	fLanguage
-	// fSortable is a flag used for sortable fields.
-	fSortable
+	// fNoSort is the 'no sort' field flag.
+	fNoSort
 	// fClientID is flag used to mark field as allowable to set ClientID.
 	fClientID
 	// fTime  is a flag used to mark field type as a Time.
@@ -175,9 +175,9 @@ func (s *StructField) IsSlice() bool {
 	return s.isSlice()
 }
 
-// IsSortable checks if the field has a sortable flag.
+// IsSortable checks if the field is not marked with the 'nosort' flag.
 func (s *StructField) IsSortable() bool {
-	return s.isSortable()
+	return !s.isNoSort()
 }
 
 // IsField checks if given struct field is a primary key, attribute or foreign key field.
@@ -508,8 +508,8 @@ func (s *StructField) isSlice() bool {
 	return s.fieldFlags&fSlice != 0
 }
 
-func (s *StructField) isSortable() bool {
-	return s.fieldFlags&fSortable != 0
+func (s *StructField) isNoSort() bool {
+	return s.fieldFlags&fNoSort != 0
 }
 
 func (s *StructField) isTime() bool {
@@ -605,7 +605,7 @@ func (s *StructField) setFlags(flags ...string) error {
 		case AnnotationNoFilter:
 			s.setFlag(fNoFilter)
 		case AnnotationNotSortable:
-			s.setFlag(fSortable)
+			s.setFlag(fNoSort)
 		case AnnotationI18n:
 			s.setFlag(fI18n)
 		case AnnotationDeletedAt:
@@ -28,6 +28,7 @@ var Neuron_Models = []mapping.Model{
 	&ManyToManyModel{},
 	&Post{},
 	&RelatedModel{},
+	&RestrictedModel{},
 	&TestingModel{},
 }
 
@@ -4214,6 +4215,266 @@ func (r *RelatedModel) ParseFieldsStringValue(field *mapping.StructField, value
 	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: RelatedModel'", field.Name())
 }
 
+// Compile time check if RestrictedModel implements mapping.Model interface.
+var _ mapping.Model = &RestrictedModel{}
+
+// NeuronCollectionName implements mapping.Model interface method.
+// Returns the name of the collection for the 'RestrictedModel'.
+func (r *RestrictedModel) NeuronCollectionName() string {
+	return "restricted_models"
+}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (r *RestrictedModel) IsPrimaryKeyZero() bool {
+	return r.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (r *RestrictedModel) GetPrimaryKeyValue() interface{} {
+	return r.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (r *RestrictedModel) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(r.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (r *RestrictedModel) GetPrimaryKeyAddress() interface{} {
+	return &r.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (r *RestrictedModel) GetPrimaryKeyHashableValue() interface{} {
+	return r.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (r *RestrictedModel) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (r *RestrictedModel) SetPrimaryKeyValue(value interface{}) error {
+	if v, ok := value.(int); ok {
+		r.ID = v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch valueType := value.(type) {
+	case int8:
+		r.ID = int(valueType)
+	case int16:
+		r.ID = int(valueType)
+	case int32:
+		r.ID = int(valueType)
+	case int64:
+		r.ID = int(valueType)
+	case uint:
+		r.ID = int(valueType)
+	case uint8:
+		r.ID = int(valueType)
+	case uint16:
+		r.ID = int(valueType)
+	case uint32:
+		r.ID = int(valueType)
+	case uint64:
+		r.ID = int(valueType)
+	case float32:
+		r.ID = int(valueType)
+	case float64:
+		r.ID = int(valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'RestrictedModel'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (r *RestrictedModel) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	r.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (r *RestrictedModel) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(ErrInvalidInput, "provided nil model to set from")
+	}
+	from, ok := model.(*RestrictedModel)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*r = *from
+	return nil
+}
+
+// Compile time check if RestrictedModel implements mapping.Fielder interface.
+var _ mapping.Fielder = &RestrictedModel{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (r *RestrictedModel) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &r.ID, nil
+	case 1: // Title
+		return &r.Title, nil
+	case 2: // Body
+		return &r.Body, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: RestrictedModel'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (r *RestrictedModel) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // Title
+		return "", nil
+	case 2: // Body
+		return "", nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (r *RestrictedModel) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return r.ID == 0, nil
+	case 1: // Title
+		return r.Title == "", nil
+	case 2: // Body
+		return r.Body == "", nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (r *RestrictedModel) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		r.ID = 0
+	case 1: // Title
+		r.Title = ""
+	case 2: // Body
+		r.Body = ""
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (r *RestrictedModel) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return r.ID, nil
+	case 1: // Title
+		return r.Title, nil
+	case 2: // Body
+		return r.Body, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'RestrictedModel'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (r *RestrictedModel) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return r.ID, nil
+	case 1: // Title
+		return r.Title, nil
+	case 2: // Body
+		return r.Body, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: RestrictedModel'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (r *RestrictedModel) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if v, ok := value.(int); ok {
+			r.ID = v
+			return nil
+		}
+
+		switch v := value.(type) {
+		case int8:
+			r.ID = int(v)
+		case int16:
+			r.ID = int(v)
+		case int32:
+			r.ID = int(v)
+		case int64:
+			r.ID = int(v)
+		case uint:
+			r.ID = int(v)
+		case uint8:
+			r.ID = int(v)
+		case uint16:
+			r.ID = int(v)
+		case uint32:
+			r.ID = int(v)
+		case uint64:
+			r.ID = int(v)
+		case float32:
+			r.ID = int(v)
+		case float64:
+			r.ID = int(v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // Title
+		if v, ok := value.(string); ok {
+			r.Title = v
+			return nil
+		}
+
+		// Check alternate types for the Title.
+		if v, ok := value.([]byte); ok {
+			r.Title = string(v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // Body
+		if v, ok := value.(string); ok {
+			r.Body = v
+			return nil
+		}
+
+		// Check alternate types for the Body.
+		if v, ok := value.([]byte); ok {
+			r.Body = string(v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'RestrictedModel'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (r *RestrictedModel) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // Title
+		return value, nil
+	case 2: // Body
+		return value, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: RestrictedModel'", field.Name())
+}
+
 // Compile time check if TestingModel implements mapping.Model interface.
 var _ mapping.Model = &TestingModel{}
 
@@ -0,0 +1,356 @@
+package query
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// ParamFilter is the url query parameter name for the filters.
+const ParamFilter = "filter"
+
+// ParseQuery creates new scope for the model 'mStruct' from provided url query 'values'. The supported parameters are:
+// 'filter[field][$op]', 'filter[relation][field][$op]', 'fields[collection]', 'include', 'sort', 'page[limit]' and
+// 'page[offset]'. The filter operator is the operator's url alias i.e.: 'filter[title][$ne]=neuron' - if it is not
+// provided the '$eq' operator is used. The values of the '$in' and '$not_in' operators, fields, includes and sorts
+// are comma separated. The nested included relations are separated with a dot i.e.: 'include=posts.comments'.
+// The fields marked with 'nofilter' or 'nosort' flags are not allowed to be used for the filters and sorts.
+// The query parameters that doesn't belong to any of above are omitted. Returned errors are the detailed errors
+// with the details that could be shown to the client.
+func ParseQuery(mStruct *mapping.ModelStruct, values url.Values) (*Scope, error) {
+	s := newScope(mStruct)
+	p := &queryParser{scope: s, fieldSets: map[string][]string{}}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var includes []string
+	for _, key := range keys {
+		var err error
+		switch {
+		case key == ParamInclude:
+			includes = append(includes, splitValues(values[key])...)
+		case key == ParamSort:
+			err = p.parseSort(splitValues(values[key]))
+		case key == ParamPageLimit || key == ParamPageOffset:
+			err = p.parsePagination(key, values.Get(key))
+		case strings.HasPrefix(key, ParamFields+"["):
+			err = p.parseFieldSetKey(key, values[key])
+		case strings.HasPrefix(key, ParamFilter+"["):
+			err = p.parseFilter(key, values[key])
+		case strings.HasPrefix(key, "page["):
+			err = errors.WrapDetf(ErrInvalidParameter, "unsupported pagination parameter: '%s'", key).
+				WithDetailf("The pagination parameter: '%s' is not supported. Use '%s' and '%s'.", key, ParamPageLimit, ParamPageOffset)
+		default:
+			log.Debug2f("Query parameter: '%s' is not supported by the parser", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.parseIncludes(includes); err != nil {
+		return nil, err
+	}
+	if err := p.applyFieldSets(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// queryParser is the url query parser that sets the scope values.
+type queryParser struct {
+	scope *Scope
+	// fieldSets are the parsed field names mapped by their collection.
+	fieldSets map[string][]string
+	// includedCollections are the included relations mapped by their collection.
+	includedCollections map[string][]*IncludedRelation
+}
+
+func (p *queryParser) parseSort(fields []string) error {
+	if len(fields) == 0 {
+		return errors.WrapDet(ErrInvalidSort, "empty sort parameter").
+			WithDetail("The sort parameter doesn't contain any field.")
+	}
+	for _, field := range fields {
+		if field == "" || field == "-" {
+			return errors.WrapDet(ErrInvalidSort, "empty sort field").
+				WithDetailf("The sort parameter: '%s' contains empty field.", strings.Join(fields, ","))
+		}
+	}
+	sortFields, err := newUniqueSortFields(p.scope.ModelStruct, fields...)
+	if err != nil {
+		return err
+	}
+	for _, sortField := range sortFields {
+		field := sortField.Field()
+		if relationSort, ok := sortField.(RelationSort); ok && len(relationSort.RelationFields) > 0 {
+			field = relationSort.RelationFields[len(relationSort.RelationFields)-1]
+		}
+		if !field.IsSortable() {
+			return errors.WrapDetf(ErrInvalidSort, "field: '%s' is not sortable", field).
+				WithDetailf("The field: '%s' cannot be used for sorting.", field.NeuronName())
+		}
+	}
+	p.scope.SortingOrder = append(p.scope.SortingOrder, sortFields...)
+	return nil
+}
+
+func (p *queryParser) parsePagination(key, value string) error {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || v < 0 {
+		return errors.WrapDetf(ErrInvalidParameter, "invalid pagination parameter: '%s' value: '%s'", key, value).
+			WithDetailf("The pagination parameter: '%s' must be a non negative integer.", key)
+	}
+	if key == ParamPageLimit {
+		p.scope.Limit(v)
+	} else {
+		p.scope.Offset(v)
+	}
+	return nil
+}
+
+func (p *queryParser) parseFieldSetKey(key string, values []string) error {
+	split, err := SplitBracketParameter(key[len(ParamFields):])
+	if err != nil {
+		return err
+	}
+	if len(split) != 1 || split[0] == "" {
+		return errors.WrapDetf(ErrInvalidParameter, "invalid fields parameter: '%s'", key).
+			WithDetailf("The fields parameter: '%s' should be of form: 'fields[collection]'.", key)
+	}
+	p.fieldSets[split[0]] = append(p.fieldSets[split[0]], splitValues(values)...)
+	return nil
+}
+
+func (p *queryParser) parseIncludes(includes []string) error {
+	p.includedCollections = map[string][]*IncludedRelation{}
+	for _, include := range includes {
+		if include == "" {
+			return errors.WrapDet(ErrInvalidParameter, "empty include field").
+				WithDetail("The include parameter contains empty relation name.")
+		}
+		mStruct := p.scope.ModelStruct
+		included := &p.scope.IncludedRelations
+		for _, name := range strings.Split(include, mapping.AnnotationNestedSeparator) {
+			relation, ok := mStruct.RelationByName(name)
+			if !ok {
+				return errors.WrapDetf(ErrInvalidParameter, "included relation: '%s' not found", include).
+					WithDetailf("The collection: '%s' doesn't have the relation: '%s'.", mStruct.Collection(), name)
+			}
+			var current *IncludedRelation
+			for _, relationIncluded := range *included {
+				if relationIncluded.StructField == relation {
+					current = relationIncluded
+					break
+				}
+			}
+			mStruct = relation.Relationship().RelatedModelStruct()
+			if current == nil {
+				current = &IncludedRelation{StructField: relation}
+				*included = append(*included, current)
+				p.includedCollections[mStruct.Collection()] = append(p.includedCollections[mStruct.Collection()], current)
+			}
+			included = &current.IncludedRelations
+		}
+	}
+	return nil
+}
+
+// applyFieldSets sets the parsed field sets for the scope and all included relations. The field sets of the
+// collections that are neither the root nor the included are not allowed.
+func (p *queryParser) applyFieldSets() error {
+	for collection, names := range p.fieldSets {
+		included, isIncluded := p.includedCollections[collection]
+		if collection != p.scope.ModelStruct.Collection() && !isIncluded {
+			return errors.WrapDetf(ErrInvalidParameter, "fields collection: '%s' is not included", collection).
+				WithDetailf("The fields parameter for the collection: '%s' requires it to be the root or included collection.", collection)
+		}
+		if collection == p.scope.ModelStruct.Collection() {
+			fieldSet, err := parseFieldSet(p.scope.ModelStruct, names, true)
+			if err != nil {
+				return err
+			}
+			p.scope.FieldSets = []mapping.FieldSet{fieldSet}
+		}
+		if isIncluded {
+			fieldSet, err := parseFieldSet(included[0].StructField.Relationship().RelatedModelStruct(), names, false)
+			if err != nil {
+				return err
+			}
+			for _, relation := range included {
+				relation.Fieldset = append(mapping.FieldSet{}, fieldSet...)
+			}
+		}
+	}
+	// All included relations without the field set takes all the model fields.
+	for _, relations := range p.includedCollections {
+		for _, relation := range relations {
+			if relation.Fieldset == nil {
+				relation.Fieldset = append(mapping.FieldSet{}, relation.StructField.Relationship().RelatedModelStruct().Fields()...)
+			}
+		}
+	}
+	return nil
+}
+
+// parseFieldSet creates the field set for the model 'mStruct' with provided field 'names'. The primary key is always
+// a part of the field set. If 'withRelations' is set to true, the field set might contain relation fields.
+func parseFieldSet(mStruct *mapping.ModelStruct, names []string, withRelations bool) (mapping.FieldSet, error) {
+	fieldSet := mapping.FieldSet{mStruct.Primary()}
+	for _, name := range names {
+		field, ok := mStruct.FieldByName(name)
+		if !ok && withRelations {
+			field, ok = mStruct.RelationByName(name)
+		}
+		if !ok {
+			return nil, errors.WrapDetf(ErrInvalidField, "field: '%s' not found in the model: '%s'", name, mStruct).
+				WithDetailf("The collection: '%s' doesn't have the field: '%s'.", mStruct.Collection(), name)
+		}
+		if field == mStruct.Primary() {
+			continue
+		}
+		if fieldSet.Contains(field) {
+			return nil, errors.WrapDetf(ErrInvalidFieldSet, "duplicated field: '%s' in the field set", name).
+				WithDetailf("The field: '%s' is used more than once in the fields of the collection: '%s'.", name, mStruct.Collection())
+		}
+		fieldSet = append(fieldSet, field)
+	}
+	return fieldSet, nil
+}
+
+func (p *queryParser) parseFilter(key string, values []string) error {
+	split, err := SplitBracketParameter(key[len(ParamFilter):])
+	if err != nil {
+		return err
+	}
+	invalidFormat := func() error {
+		return errors.WrapDetf(ErrInvalidParameter, "invalid filter parameter: '%s'", key).
+			WithDetailf("The filter parameter: '%s' should be of form: 'filter[field][$operator]' or 'filter[relation][field][$operator]'.", key)
+	}
+	if len(split) == 0 || len(split) > 3 {
+		return invalidFormat()
+	}
+
+	// The last bracket might be the operator.
+	op := filter.OpEqual
+	if last := split[len(split)-1]; strings.HasPrefix(last, "$") {
+		var ok bool
+		if op, ok = filter.Operators.Get(last); !ok {
+			return errors.WrapDetf(ErrInvalidParameter, "unsupported filter operator: '%s'", last).
+				WithDetailf("The filter operator: '%s' is not supported.", last)
+		}
+		split = split[:len(split)-1]
+	}
+
+	mStruct := p.scope.ModelStruct
+	var relation *mapping.StructField
+	switch len(split) {
+	case 1:
+	case 2:
+		var ok bool
+		if relation, ok = mStruct.RelationByName(split[0]); !ok {
+			return errors.WrapDetf(ErrInvalidParameter, "filter relation: '%s' not found", split[0]).
+				WithDetailf("The collection: '%s' doesn't have the relation: '%s'.", mStruct.Collection(), split[0])
+		}
+		if relation.IsNoFilter() {
+			return errNoFilter(relation)
+		}
+		mStruct = relation.Relationship().RelatedModelStruct()
+		split = split[1:]
+	default:
+		return invalidFormat()
+	}
+
+	field, ok := mStruct.FieldByName(split[0])
+	if !ok {
+		return errors.WrapDetf(ErrInvalidParameter, "filter field: '%s' not found", split[0]).
+			WithDetailf("The collection: '%s' doesn't have the field: '%s'.", mStruct.Collection(), split[0])
+	}
+	if field.IsNoFilter() {
+		return errNoFilter(field)
+	}
+	filterValues, err := parseFilterValues(field, op, values)
+	if err != nil {
+		return err
+	}
+
+	simple := filter.New(field, op, filterValues...)
+	if relation == nil {
+		p.scope.Filters = append(p.scope.Filters, simple)
+		return nil
+	}
+	for i, f := range p.scope.Filters {
+		if relationFilter, ok := f.(filter.Relation); ok && relationFilter.StructField == relation {
+			relationFilter.Nested = append(relationFilter.Nested, simple)
+			p.scope.Filters[i] = relationFilter
+			return nil
+		}
+	}
+	p.scope.Filters = append(p.scope.Filters, filter.NewRelation(relation, simple))
+	return nil
+}
+
+// parseFilterValues parses the string 'values' into the 'field' values for the filter operator 'op'.
+func parseFilterValues(field *mapping.StructField, op *filter.Operator, values []string) ([]interface{}, error) {
+	switch op {
+	case filter.OpIsNull, filter.OpNotNull:
+		return nil, nil
+	case filter.OpIn, filter.OpNotIn:
+		values = splitValues(values)
+	default:
+		if len(values) != 1 {
+			return nil, errors.WrapDetf(ErrInvalidParameter, "too many filter values for the field: '%s'", field).
+				WithDetailf("The filter operator: '%s' for the field: '%s' requires single value.", op.URLAlias, field.NeuronName())
+		}
+	}
+	if op.IsStringOnly() && field.GetDereferencedType().Kind() != reflect.String {
+		return nil, errors.WrapDetf(ErrInvalidParameter, "string filter operator for non string field: '%s'", field).
+			WithDetailf("The filter operator: '%s' requires the field: '%s' to be a string.", op.URLAlias, field.NeuronName())
+	}
+
+	fielder, ok := mapping.NewModel(field.Struct()).(mapping.Fielder)
+	if !ok {
+		return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement mapping.Fielder interface", field.Struct())
+	}
+	filterValues := make([]interface{}, len(values))
+	for i, value := range values {
+		var err error
+		if field.IsTime() || field.IsTimePointer() {
+			filterValues[i], err = time.Parse(time.RFC3339, value)
+		} else {
+			filterValues[i], err = fielder.ParseFieldsStringValue(field, value)
+		}
+		if err != nil {
+			return nil, errors.WrapDetf(ErrInvalidParameter, "invalid filter value: '%s' for the field: '%s'", value, field).
+				WithDetailf("The value: '%s' is not valid for the field: '%s'.", value, field.NeuronName())
+		}
+	}
+	return filterValues, nil
+}
+
+func errNoFilter(field *mapping.StructField) error {
+	return errors.WrapDetf(ErrInvalidParameter, "field: '%s' doesn't allow filtering", field).
+		WithDetailf("The field: '%s' cannot be used for filtering.", field.NeuronName())
+}
+
+// splitValues splits the comma separated 'values' and trims their white spaces.
+func splitValues(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			split = append(split, strings.TrimSpace(v))
+		}
+	}
+	return split
+}
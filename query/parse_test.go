@@ -0,0 +1,172 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// RestrictedModel is the model with the field that could not be filtered nor sorted.
+type RestrictedModel struct {
+	ID    int
+	Title string
+	Body  string `neuron:"flags=nofilter,nosort"`
+}
+
+// TestParseQuery tests the ParseQuery function.
+func TestParseQuery(t *testing.T) {
+	ms := mapping.New(mapping.WithNamingConvention(mapping.SnakeCase))
+
+	err := ms.RegisterModels(&Blog{}, &Post{}, &Comment{})
+	require.NoError(t, err)
+
+	mStruct, err := ms.ModelStruct(&Blog{})
+	require.NoError(t, err)
+	postStruct, err := ms.ModelStruct(&Post{})
+	require.NoError(t, err)
+	commentStruct, err := ms.ModelStruct(&Comment{})
+	require.NoError(t, err)
+
+	parse := func(t *testing.T, query string) (*Scope, error) {
+		t.Helper()
+		values, err := url.ParseQuery(query)
+		require.NoError(t, err)
+		return ParseQuery(mStruct, values)
+	}
+
+	t.Run("Filters", func(t *testing.T) {
+		s, err := parse(t, "filter[title]=neuron&filter[id][$in]=1,2&filter[posts][title][$contains]=go&filter[posts][id][$gt]=3")
+		require.NoError(t, err)
+		require.Len(t, s.Filters, 3)
+
+		var simples []filter.Simple
+		var relations []filter.Relation
+		for _, f := range s.Filters {
+			switch ft := f.(type) {
+			case filter.Simple:
+				simples = append(simples, ft)
+			case filter.Relation:
+				relations = append(relations, ft)
+			}
+		}
+		require.Len(t, simples, 2)
+		for _, simple := range simples {
+			switch simple.StructField {
+			case mStruct.Primary():
+				assert.Equal(t, filter.OpIn, simple.Operator)
+				assert.Equal(t, []interface{}{int64(1), int64(2)}, simple.Values)
+			default:
+				assert.Equal(t, "title", simple.StructField.NeuronName())
+				assert.Equal(t, filter.OpEqual, simple.Operator)
+				assert.Equal(t, []interface{}{"neuron"}, simple.Values)
+			}
+		}
+
+		require.Len(t, relations, 1)
+		assert.Equal(t, "posts", relations[0].StructField.NeuronName())
+		assert.Len(t, relations[0].Nested, 2)
+	})
+
+	t.Run("FieldSets", func(t *testing.T) {
+		s, err := parse(t, "fields[blogs]=title,posts&fields[posts]=title&include=posts.comments,current_post")
+		require.NoError(t, err)
+
+		require.Len(t, s.FieldSets, 1)
+		title, ok := mStruct.FieldByName("title")
+		require.True(t, ok)
+		posts, ok := mStruct.RelationByName("posts")
+		require.True(t, ok)
+		assert.Equal(t, mapping.FieldSet{mStruct.Primary(), title, posts}, s.FieldSets[0])
+
+		require.Len(t, s.IncludedRelations, 2)
+		postTitle, ok := postStruct.FieldByName("title")
+		require.True(t, ok)
+		for _, included := range s.IncludedRelations {
+			assert.Equal(t, mapping.FieldSet{postStruct.Primary(), postTitle}, included.Fieldset)
+			if included.StructField == posts {
+				require.Len(t, included.IncludedRelations, 1)
+				assert.Equal(t, "comments", included.IncludedRelations[0].StructField.NeuronName())
+				assert.Len(t, included.IncludedRelations[0].Fieldset, len(commentStruct.Fields()))
+			} else {
+				assert.Empty(t, included.IncludedRelations)
+			}
+		}
+	})
+
+	t.Run("SortAndPagination", func(t *testing.T) {
+		s, err := parse(t, "sort=-title,posts.title&page[limit]=10&page[offset]=20")
+		require.NoError(t, err)
+
+		require.Len(t, s.SortingOrder, 2)
+		assert.Equal(t, "title", s.SortingOrder[0].Field().NeuronName())
+		assert.Equal(t, DescendingOrder, s.SortingOrder[0].Order())
+		require.NotNil(t, s.Pagination)
+		assert.Equal(t, int64(10), s.Pagination.Limit)
+		assert.Equal(t, int64(20), s.Pagination.Offset)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := map[string]struct {
+			Query string
+			Class error
+		}{
+			"UnknownField":       {"filter[unknown]=1", ErrInvalidParameter},
+			"UnknownOperator":    {"filter[title][$unknown]=1", ErrInvalidParameter},
+			"InvalidValue":       {"filter[id]=abc", ErrInvalidParameter},
+			"StringOperator":     {"filter[id][$contains]=1", ErrInvalidParameter},
+			"TooManyValues":      {"filter[title]=a&filter[title]=b", ErrInvalidParameter},
+			"NestedTooDeep":      {"filter[a][b][c][d]=1", ErrInvalidParameter},
+			"UnknownSort":        {"sort=unknown", ErrInvalidSort},
+			"EmptySort":          {"sort=title,", ErrInvalidSort},
+			"NegativeLimit":      {"page[limit]=-1", ErrInvalidParameter},
+			"UnsupportedPage":    {"page[number]=1", ErrInvalidParameter},
+			"UnknownInclude":     {"include=unknown", ErrInvalidParameter},
+			"NotIncludedFields":  {"fields[posts]=title", ErrInvalidParameter},
+			"UnknownFieldSet":    {"fields[blogs]=unknown", ErrInvalidField},
+			"DuplicatedFieldSet": {"fields[blogs]=title,title", ErrInvalidFieldSet},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := parse(t, test.Query)
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, test.Class), err.Error())
+
+				detailed, ok := err.(*errors.DetailedError)
+				require.True(t, ok)
+				assert.NotEmpty(t, detailed.Details)
+			})
+		}
+	})
+
+	t.Run("Restricted", func(t *testing.T) {
+		restricted, err := ms.ModelStruct(&RestrictedModel{})
+		require.NoError(t, err)
+
+		tests := map[string]struct {
+			Query string
+			Class error
+		}{
+			"NoFilter": {"filter[body]=1", ErrInvalidParameter},
+			"NoSort":   {"sort=body", ErrInvalidSort},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				values, err := url.ParseQuery(test.Query)
+				require.NoError(t, err)
+				_, err = ParseQuery(restricted, values)
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, test.Class), err.Error())
+			})
+		}
+		values, err := url.ParseQuery("filter[title]=neuron&sort=-title")
+		require.NoError(t, err)
+		_, err = ParseQuery(restricted, values)
+		require.NoError(t, err)
+	})
+}
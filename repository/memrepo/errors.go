@@ -0,0 +1,14 @@
+package memrepo
+
+import (
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+)
+
+var (
+	// ErrSerialization is the error classification for the transactions that could not be committed,
+	// because the records they've changed were modified by another transaction in the meantime.
+	ErrSerialization = errors.Wrap(query.ErrTransaction, "serialization failure")
+	// ErrReadOnly is the error classification for the write queries executed within read only transactions.
+	ErrReadOnly = errors.Wrap(query.ErrTransaction, "read only")
+)
@@ -0,0 +1,141 @@
+package memrepo
+
+import (
+	"strings"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/repository"
+)
+
+// matchFilters checks if provided 'model' matches all the 'filters'.
+func matchFilters(model mapping.Fielder, filters filter.Filters) (bool, error) {
+	for _, f := range filters {
+		matched, err := matchFilter(model, f)
+		if err != nil {
+			return false, err
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func matchFilter(model mapping.Fielder, f filter.Filter) (bool, error) {
+	switch ft := f.(type) {
+	case filter.Simple:
+		return matchSimple(model, ft)
+	case *filter.Simple:
+		return matchSimple(model, *ft)
+	case filter.OrGroup:
+		for _, sf := range ft {
+			matched, err := matchSimple(model, sf)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+		return false, nil
+	case filter.Relation, *filter.Relation:
+		// The relationship filters should be reduced into the model filters by the database before reaching
+		// the repository.
+		return false, errors.WrapDetf(repository.ErrNotImplements, "relationship filters are not supported by the in-memory repository: '%s'", f)
+	default:
+		return false, errors.WrapDetf(filter.ErrFilterFormat, "unsupported filter type: '%T'", f)
+	}
+}
+
+func matchSimple(model mapping.Fielder, f filter.Simple) (bool, error) {
+	if f.StructField == nil || f.Operator == nil {
+		return false, errors.WrapDetf(filter.ErrFilterFormat, "invalid filter: '%v'", f)
+	}
+	if f.StructField.IsRelationship() {
+		return false, errors.WrapDetf(filter.ErrFilterField, "filter field: '%s' is a relationship", f.StructField)
+	}
+	fieldValue, err := model.GetFieldValue(f.StructField)
+	if err != nil {
+		return false, err
+	}
+
+	switch f.Operator {
+	case filter.OpIsNull:
+		return isNullValue(fieldValue), nil
+	case filter.OpNotNull:
+		return !isNullValue(fieldValue), nil
+	case filter.OpEqual, filter.OpIn:
+		return matchAny(fieldValue, f)
+	case filter.OpNotEqual, filter.OpNotIn:
+		matched, err := matchAny(fieldValue, f)
+		if err != nil {
+			return false, err
+		}
+		return !matched, nil
+	case filter.OpGreaterThan, filter.OpGreaterEqual, filter.OpLessThan, filter.OpLessEqual:
+		if len(f.Values) != 1 {
+			return false, errors.WrapDetf(filter.ErrFilterValues, "operator: '%s' requires exactly one value", f.Operator.Name)
+		}
+		if isNullValue(fieldValue) || isNullValue(f.Values[0]) {
+			return false, nil
+		}
+		res, err := compareValues(fieldValue, f.Values[0])
+		if err != nil {
+			return false, err
+		}
+		switch f.Operator {
+		case filter.OpGreaterThan:
+			return res > 0, nil
+		case filter.OpGreaterEqual:
+			return res >= 0, nil
+		case filter.OpLessThan:
+			return res < 0, nil
+		default:
+			return res <= 0, nil
+		}
+	case filter.OpContains, filter.OpStartsWith, filter.OpEndsWith:
+		str, ok := stringValue(fieldValue)
+		if !ok {
+			if isNullValue(fieldValue) {
+				return false, nil
+			}
+			return false, errors.WrapDetf(filter.ErrFilterField, "operator: '%s' is applicable only for string fields", f.Operator.Name)
+		}
+		for _, value := range f.Values {
+			pattern, ok := stringValue(value)
+			if !ok {
+				return false, errors.WrapDetf(filter.ErrFilterValues, "operator: '%s' requires string values", f.Operator.Name)
+			}
+			var matched bool
+			switch f.Operator {
+			case filter.OpContains:
+				matched = strings.Contains(str, pattern)
+			case filter.OpStartsWith:
+				matched = strings.HasPrefix(str, pattern)
+			default:
+				matched = strings.HasSuffix(str, pattern)
+			}
+			if matched {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, errors.WrapDetf(repository.ErrNotImplements, "filter operator: '%s' is not supported by the in-memory repository", f.Operator.Name)
+	}
+}
+
+func matchAny(fieldValue interface{}, f filter.Simple) (bool, error) {
+	for _, value := range f.Values {
+		equal, err := equalValues(fieldValue, value)
+		if err != nil {
+			return false, err
+		}
+		if equal {
+			return true, nil
+		}
+	}
+	return false, nil
+}
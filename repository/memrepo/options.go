@@ -0,0 +1,35 @@
+package memrepo
+
+import (
+	"github.com/neuronlabs/neuron/query"
+)
+
+// DefaultID is the default identifier of the in-memory repository.
+const DefaultID = "memrepo"
+
+// Options are the settings for the in-memory repository.
+type Options struct {
+	// ID is the unique repository identifier. Each repository registered within a single database needs to have
+	// an unique identifier. By default it is set to 'DefaultID'.
+	ID string
+	// DefaultIsolation is the isolation level used by the transactions that doesn't define their own.
+	// By default it is set to query.LevelReadCommitted.
+	DefaultIsolation query.IsolationLevel
+}
+
+// Option is a function that changes the repository options.
+type Option func(o *Options)
+
+// WithID sets the repository identifier.
+func WithID(id string) Option {
+	return func(o *Options) {
+		o.ID = id
+	}
+}
+
+// WithDefaultIsolation sets the default isolation level for the repository transactions.
+func WithDefaultIsolation(level query.IsolationLevel) Option {
+	return func(o *Options) {
+		o.DefaultIsolation = level
+	}
+}
@@ -0,0 +1,380 @@
+package memrepo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/repository"
+)
+
+// Compile time check for the repository interfaces.
+var (
+	_ repository.Repository    = &Repository{}
+	_ repository.Transactioner = &Repository{}
+	_ repository.Savepointer   = &Repository{}
+	_ repository.Exister       = &Repository{}
+	_ repository.Upserter      = &Repository{}
+	_ repository.Migrator      = &Repository{}
+)
+
+// Repository is the in-memory reference repository implementation. It stores the copies of the models in memory and
+// supports all the query filters, sorting, pagination and field sets. The transactions are isolated from each other
+// with respect to their isolation levels.
+// The models stored in the repository must implement mapping.Fielder interface.
+type Repository struct {
+	options *Options
+
+	lock         sync.Mutex
+	tables       map[*mapping.ModelStruct]*table
+	transactions map[uuid.UUID]*transaction
+	version      uint64
+	ordinal      uint64
+}
+
+// New creates new in-memory repository.
+func New(options ...Option) *Repository {
+	o := &Options{
+		ID:               DefaultID,
+		DefaultIsolation: query.LevelReadCommitted,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	return &Repository{
+		options:      o,
+		tables:       map[*mapping.ModelStruct]*table{},
+		transactions: map[uuid.UUID]*transaction{},
+	}
+}
+
+// ID implements repository.Repository interface.
+func (r *Repository) ID() string {
+	return r.options.ID
+}
+
+// MigrateModels implements repository.Migrator interface. It creates the storage tables for provided models.
+func (r *Repository) MigrateModels(_ context.Context, models ...*mapping.ModelStruct) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, mStruct := range models {
+		if _, ok := mapping.NewModel(mStruct).(mapping.Fielder); !ok {
+			return errModelNotFielder(mStruct)
+		}
+		r.table(mStruct)
+	}
+	return nil
+}
+
+// Count implements repository.Repository interface.
+func (r *Repository) Count(_ context.Context, s *query.Scope) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := r.view(s)
+	if err != nil {
+		return 0, err
+	}
+	records, err := v.find(s)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(records)), nil
+}
+
+// Exists implements repository.Exister interface.
+func (r *Repository) Exists(_ context.Context, s *query.Scope) (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := r.view(s)
+	if err != nil {
+		return false, err
+	}
+	records, err := v.find(s)
+	if err != nil {
+		return false, err
+	}
+	return len(records) > 0, nil
+}
+
+// Find implements repository.Repository interface.
+func (r *Repository) Find(_ context.Context, s *query.Scope) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := r.view(s)
+	if err != nil {
+		return err
+	}
+	records, err := v.find(s)
+	if err != nil {
+		return err
+	}
+	if err = sortRecords(records, s.SortingOrder); err != nil {
+		return err
+	}
+	records = paginate(records, s.Pagination)
+
+	fieldSet := s.ModelStruct.Fields()
+	if fs, ok := s.CommonFieldSet(); ok && len(fs) > 0 {
+		fieldSet = fs
+	}
+	models := make([]mapping.Model, len(records))
+	for i, rec := range records {
+		model := mapping.NewModel(s.ModelStruct)
+		fielder, ok := model.(mapping.Fielder)
+		if !ok {
+			return errModelNotFielder(s.ModelStruct)
+		}
+		for _, field := range fieldSet {
+			if !field.IsField() {
+				continue
+			}
+			if err = copyFieldValue(fielder, rec.fielder(), field); err != nil {
+				return err
+			}
+		}
+		models[i] = model
+	}
+	s.Models = models
+	if log.CurrentLevel().IsAllowed(log.LevelDebug3) {
+		log.Debug3f("[%s] found %d models for the query: %s", r.ID(), len(models), s)
+	}
+	return nil
+}
+
+// Insert implements repository.Repository interface. Models with zero value primary key gets the primary key
+// generated by the repository.
+func (r *Repository) Insert(_ context.Context, s *query.Scope) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := r.view(s)
+	if err != nil {
+		return err
+	}
+	for i, model := range s.Models {
+		if err = v.insert(s, i, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update implements repository.Repository interface. It updates all the models that matches the scope filters
+// with the values of the first scope's model.
+func (r *Repository) Update(_ context.Context, s *query.Scope) (int64, error) {
+	if len(s.Models) != 1 {
+		return 0, errors.WrapDetf(query.ErrInvalidModels, "filtered update requires exactly one model, provided: %d", len(s.Models))
+	}
+	fielder, ok := s.Models[0].(mapping.Fielder)
+	if !ok {
+		return 0, errModelNotFielder(s.ModelStruct)
+	}
+	fieldSet, err := scopeFieldSet(s, 0)
+	if err != nil {
+		return 0, err
+	}
+	if fieldSet.Contains(s.ModelStruct.Primary()) {
+		return 0, errors.WrapDet(query.ErrInvalidField, "cannot update the primary key of filtered models")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := r.view(s)
+	if err != nil {
+		return 0, err
+	}
+	records, err := v.find(s)
+	if err != nil {
+		return 0, err
+	}
+	for _, rec := range records {
+		if err = v.update(s.ModelStruct, rec, fielder, fieldSet); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(records)), nil
+}
+
+// UpdateModels implements repository.Repository interface. It updates each scope's model by its primary key value.
+// If the scope contains filters, only the models that matches them are updated.
+func (r *Repository) UpdateModels(_ context.Context, s *query.Scope) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := r.view(s)
+	if err != nil {
+		return 0, err
+	}
+	var affected int64
+	for i, model := range s.Models {
+		if model.IsPrimaryKeyZero() {
+			return affected, errors.WrapDetf(query.ErrInvalidModels, "model at index: %d have zero value primary key", i)
+		}
+		fielder, ok := model.(mapping.Fielder)
+		if !ok {
+			return affected, errModelNotFielder(s.ModelStruct)
+		}
+		fieldSet, err := scopeFieldSet(s, i)
+		if err != nil {
+			return affected, err
+		}
+		rec, ok := v.get(s.ModelStruct, model.GetPrimaryKeyHashableValue())
+		if !ok {
+			continue
+		}
+		matched, err := matchFilters(rec.fielder(), s.Filters)
+		if err != nil {
+			return affected, err
+		}
+		if !matched {
+			continue
+		}
+		if err = v.update(s.ModelStruct, rec, fielder, fieldSet); err != nil {
+			return affected, err
+		}
+		affected++
+	}
+	return affected, nil
+}
+
+// Upsert implements repository.Upserter interface. The models that already exists are updated with their field sets,
+// all the others are inserted.
+func (r *Repository) Upsert(_ context.Context, s *query.Scope) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := r.view(s)
+	if err != nil {
+		return err
+	}
+	for i, model := range s.Models {
+		if !model.IsPrimaryKeyZero() {
+			if rec, ok := v.get(s.ModelStruct, model.GetPrimaryKeyHashableValue()); ok {
+				fielder, ok := model.(mapping.Fielder)
+				if !ok {
+					return errModelNotFielder(s.ModelStruct)
+				}
+				fieldSet, err := scopeFieldSet(s, i)
+				if err != nil {
+					return err
+				}
+				if err = v.update(s.ModelStruct, rec, fielder, fieldSet); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if err = v.insert(s, i, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete implements repository.Repository interface. It deletes all the models that matches the scope filters.
+func (r *Repository) Delete(_ context.Context, s *query.Scope) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := r.view(s)
+	if err != nil {
+		return 0, err
+	}
+	records, err := v.find(s)
+	if err != nil {
+		return 0, err
+	}
+	for _, rec := range records {
+		if err = v.put(s.ModelStruct, rec.model.GetPrimaryKeyHashableValue(), nil); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(records)), nil
+}
+
+func (r *Repository) table(mStruct *mapping.ModelStruct) *table {
+	t, ok := r.tables[mStruct]
+	if !ok {
+		t = newTable()
+		r.tables[mStruct] = t
+	}
+	return t
+}
+
+// view gets the records view for provided scope.
+func (r *Repository) view(s *query.Scope) (*view, error) {
+	if s.Transaction == nil {
+		return &view{r: r}, nil
+	}
+	tx, err := r.transaction(s.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &view{r: r, tx: tx}, nil
+}
+
+func (v *view) insert(s *query.Scope, index int, model mapping.Model) error {
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return errModelNotFielder(s.ModelStruct)
+	}
+	fieldSet, err := scopeFieldSet(s, index)
+	if err != nil {
+		return err
+	}
+	if err = v.setPrimaryKey(s.ModelStruct, model); err != nil {
+		return err
+	}
+	if !fieldSet.Contains(s.ModelStruct.Primary()) {
+		fieldSet = append(mapping.FieldSet{s.ModelStruct.Primary()}, fieldSet...)
+	}
+	key := model.GetPrimaryKeyHashableValue()
+	if _, exists := v.get(s.ModelStruct, key); exists {
+		return errors.WrapDetf(query.ErrViolationUnique, "model: '%s' with primary key: '%v' already exists", s.ModelStruct, key)
+	}
+	rec, err := v.newRecord(s.ModelStruct, fielder, fieldSet, nil)
+	if err != nil {
+		return err
+	}
+	if err = v.checkUnique(s.ModelStruct, key, rec); err != nil {
+		return err
+	}
+	return v.put(s.ModelStruct, key, rec)
+}
+
+func (v *view) update(mStruct *mapping.ModelStruct, prev *record, from mapping.Fielder, fieldSet mapping.FieldSet) error {
+	rec, err := v.newRecord(mStruct, from, fieldSet, prev)
+	if err != nil {
+		return err
+	}
+	key := prev.model.GetPrimaryKeyHashableValue()
+	if err = v.checkUnique(mStruct, key, rec); err != nil {
+		return err
+	}
+	return v.put(mStruct, key, rec)
+}
+
+// scopeFieldSet gets the field set for the model at 'index'. If the scope has no field sets all model fields are
+// taken.
+func scopeFieldSet(s *query.Scope, index int) (mapping.FieldSet, error) {
+	switch len(s.FieldSets) {
+	case 0:
+		return s.ModelStruct.Fields(), nil
+	case 1:
+		return s.FieldSets[0], nil
+	case len(s.Models):
+		return s.FieldSets[index], nil
+	default:
+		return nil, errors.WrapDetf(query.ErrInvalidFieldSet, "provided invalid field sets. Models len: %d, FieldSets len: %d", len(s.Models), len(s.FieldSets))
+	}
+}
@@ -0,0 +1,405 @@
+package memrepo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+func testModelMap(t *testing.T) *mapping.ModelMap {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(testmodels.Neuron_Models...))
+	return m
+}
+
+func insertBlogs(t *testing.T, r *Repository, mStruct *mapping.ModelStruct, blogs ...*testmodels.Blog) {
+	t.Helper()
+	models := make([]mapping.Model, len(blogs))
+	for i := range blogs {
+		models[i] = blogs[i]
+	}
+	require.NoError(t, r.Insert(context.Background(), query.NewScope(mStruct, models...)))
+}
+
+func findBlogs(t *testing.T, r *Repository, s *query.Scope) []*testmodels.Blog {
+	t.Helper()
+	require.NoError(t, r.Find(context.Background(), s))
+	blogs := make([]*testmodels.Blog, len(s.Models))
+	for i, model := range s.Models {
+		blogs[i] = model.(*testmodels.Blog)
+	}
+	return blogs
+}
+
+func blogIDs(blogs []*testmodels.Blog) []int {
+	ids := make([]int, len(blogs))
+	for i, blog := range blogs {
+		ids[i] = blog.ID
+	}
+	return ids
+}
+
+func TestFind(t *testing.T) {
+	m := testModelMap(t)
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	r := New()
+
+	insertBlogs(t, r, mStruct,
+		&testmodels.Blog{Title: "first", ViewCount: 10},
+		&testmodels.Blog{Title: "second", ViewCount: 20},
+		&testmodels.Blog{Title: "third", ViewCount: 30},
+		&testmodels.Blog{Title: "fourth", ViewCount: 20},
+	)
+
+	title := mStruct.MustFieldByName("Title")
+	viewCount := mStruct.MustFieldByName("ViewCount")
+
+	t.Run("Filters", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			filters  []filter.Filter
+			expected []int
+		}{
+			{"Equal", []filter.Filter{filter.New(viewCount, filter.OpEqual, int64(20))}, []int{2, 4}},
+			{"In", []filter.Filter{filter.New(mStruct.Primary(), filter.OpIn, 1, uint(3))}, []int{1, 3}},
+			{"NotEqual", []filter.Filter{filter.New(viewCount, filter.OpNotEqual, 20)}, []int{1, 3}},
+			{"NotIn", []filter.Filter{filter.New(title, filter.OpNotIn, "first", "second")}, []int{3, 4}},
+			{"GreaterThan", []filter.Filter{filter.New(viewCount, filter.OpGreaterThan, 20)}, []int{3}},
+			{"GreaterEqual", []filter.Filter{filter.New(viewCount, filter.OpGreaterEqual, 20.0)}, []int{2, 3, 4}},
+			{"LessThan", []filter.Filter{filter.New(viewCount, filter.OpLessThan, 20)}, []int{1}},
+			{"LessEqual", []filter.Filter{filter.New(viewCount, filter.OpLessEqual, 20)}, []int{1, 2, 4}},
+			{"Contains", []filter.Filter{filter.New(title, filter.OpContains, "ir")}, []int{1, 3}},
+			{"StartsWith", []filter.Filter{filter.New(title, filter.OpStartsWith, "f")}, []int{1, 4}},
+			{"EndsWith", []filter.Filter{filter.New(title, filter.OpEndsWith, "nd")}, []int{2}},
+			{"IsNull", []filter.Filter{filter.New(title, filter.OpIsNull)}, nil},
+			{"NotNull", []filter.Filter{filter.New(title, filter.OpNotNull)}, []int{1, 2, 3, 4}},
+			{"Or", []filter.Filter{filter.Or(filter.New(title, filter.OpEqual, "first"), filter.New(viewCount, filter.OpEqual, 30))}, []int{1, 3}},
+			{"Multiple", []filter.Filter{filter.New(viewCount, filter.OpEqual, 20), filter.New(title, filter.OpStartsWith, "f")}, []int{4}},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				s := query.NewScope(mStruct)
+				s.Filters = tc.filters
+				blogs := findBlogs(t, r, s)
+				if tc.expected == nil {
+					assert.Empty(t, blogs)
+					return
+				}
+				assert.Equal(t, tc.expected, blogIDs(blogs))
+			})
+		}
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		s := query.NewScope(mStruct)
+		s.SortingOrder = []query.Sort{
+			query.SortField{StructField: viewCount, SortOrder: query.DescendingOrder},
+			query.SortField{StructField: title, SortOrder: query.AscendingOrder},
+		}
+		assert.Equal(t, []int{3, 4, 2, 1}, blogIDs(findBlogs(t, r, s)))
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		s := query.NewScope(mStruct)
+		s.Limit(2)
+		s.Offset(1)
+		assert.Equal(t, []int{2, 3}, blogIDs(findBlogs(t, r, s)))
+
+		s = query.NewScope(mStruct)
+		s.Offset(10)
+		assert.Empty(t, findBlogs(t, r, s))
+	})
+
+	t.Run("FieldSet", func(t *testing.T) {
+		s := query.NewScope(mStruct)
+		require.NoError(t, s.Select(mStruct.Primary(), title))
+		s.Filters = filter.Filters{filter.New(mStruct.Primary(), filter.OpEqual, 1)}
+		blogs := findBlogs(t, r, s)
+		require.Len(t, blogs, 1)
+		assert.Equal(t, "first", blogs[0].Title)
+		assert.Zero(t, blogs[0].ViewCount)
+	})
+
+	t.Run("CountExists", func(t *testing.T) {
+		s := query.NewScope(mStruct)
+		s.Filters = filter.Filters{filter.New(viewCount, filter.OpEqual, 20)}
+		count, err := r.Count(context.Background(), s)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		s.Filters = filter.Filters{filter.New(viewCount, filter.OpEqual, 50)}
+		exists, err := r.Exists(context.Background(), s)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestInsert(t *testing.T) {
+	m := testModelMap(t)
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	r := New()
+
+	blog := &testmodels.Blog{Title: "title"}
+	insertBlogs(t, r, mStruct, blog)
+	assert.Equal(t, 1, blog.ID)
+
+	// Changing the inserted model must not change the stored record.
+	blog.Title = "changed"
+	s := query.NewScope(mStruct)
+	blogs := findBlogs(t, r, s)
+	require.Len(t, blogs, 1)
+	assert.Equal(t, "title", blogs[0].Title)
+
+	err := r.Insert(context.Background(), query.NewScope(mStruct, &testmodels.Blog{ID: 1}))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, query.ErrViolationUnique))
+
+	next := &testmodels.Blog{ID: 10}
+	insertBlogs(t, r, mStruct, next)
+	generated := &testmodels.Blog{}
+	insertBlogs(t, r, mStruct, generated)
+	assert.Equal(t, 11, generated.ID)
+}
+
+func TestUpdateDelete(t *testing.T) {
+	m := testModelMap(t)
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	title := mStruct.MustFieldByName("Title")
+	viewCount := mStruct.MustFieldByName("ViewCount")
+	r := New()
+	ctx := context.Background()
+
+	insertBlogs(t, r, mStruct,
+		&testmodels.Blog{Title: "first", ViewCount: 10},
+		&testmodels.Blog{Title: "second", ViewCount: 20},
+		&testmodels.Blog{Title: "third", ViewCount: 30},
+	)
+
+	// Update filtered.
+	s := query.NewScope(mStruct, &testmodels.Blog{ViewCount: 100})
+	s.FieldSets = []mapping.FieldSet{{viewCount}}
+	s.Filters = filter.Filters{filter.New(viewCount, filter.OpGreaterThan, 15)}
+	affected, err := r.Update(ctx, s)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), affected)
+
+	// Update models.
+	s = query.NewScope(mStruct, &testmodels.Blog{ID: 1, Title: "updated"}, &testmodels.Blog{ID: 5, Title: "not existing"})
+	s.FieldSets = []mapping.FieldSet{{title}}
+	affected, err = r.UpdateModels(ctx, s)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	blogs := findBlogs(t, r, query.NewScope(mStruct))
+	require.Len(t, blogs, 3)
+	assert.Equal(t, "updated", blogs[0].Title)
+	assert.Equal(t, 10, blogs[0].ViewCount)
+	assert.Equal(t, "second", blogs[1].Title)
+	assert.Equal(t, 100, blogs[1].ViewCount)
+	assert.Equal(t, 100, blogs[2].ViewCount)
+
+	// Upsert.
+	s = query.NewScope(mStruct, &testmodels.Blog{ID: 2, Title: "upserted"}, &testmodels.Blog{ID: 4, Title: "new"})
+	s.FieldSets = []mapping.FieldSet{{mStruct.Primary(), title}}
+	require.NoError(t, r.Upsert(ctx, s))
+
+	blogs = findBlogs(t, r, query.NewScope(mStruct))
+	require.Len(t, blogs, 4)
+	assert.Equal(t, "upserted", blogs[1].Title)
+	assert.Equal(t, 100, blogs[1].ViewCount)
+	assert.Equal(t, "new", blogs[3].Title)
+
+	// Delete.
+	s = query.NewScope(mStruct)
+	s.Filters = filter.Filters{filter.New(viewCount, filter.OpEqual, 100)}
+	affected, err = r.Delete(ctx, s)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), affected)
+	assert.Equal(t, []int{1, 4}, blogIDs(findBlogs(t, r, query.NewScope(mStruct))))
+}
+
+func newTx(isolation query.IsolationLevel) *query.Transaction {
+	return &query.Transaction{ID: uuid.New(), Ctx: context.Background(), Options: &query.TxOptions{Isolation: isolation}}
+}
+
+func TestTransactions(t *testing.T) {
+	m := testModelMap(t)
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	title := mStruct.MustFieldByName("Title")
+	ctx := context.Background()
+
+	t.Run("ReadCommitted", func(t *testing.T) {
+		r := New()
+		tx := newTx(query.LevelReadCommitted)
+		require.NoError(t, r.Begin(ctx, tx))
+
+		s := query.NewScope(mStruct, &testmodels.Blog{Title: "tx"})
+		s.Transaction = tx
+		require.NoError(t, r.Insert(ctx, s))
+
+		// The changes are not visible outside of the transaction.
+		assert.Empty(t, findBlogs(t, r, query.NewScope(mStruct)))
+		s = query.NewScope(mStruct)
+		s.Transaction = tx
+		assert.Len(t, findBlogs(t, r, s), 1)
+
+		// Committed changes of other writers are visible within the transaction.
+		insertBlogs(t, r, mStruct, &testmodels.Blog{Title: "outside"})
+		s = query.NewScope(mStruct)
+		s.Transaction = tx
+		assert.Len(t, findBlogs(t, r, s), 2)
+
+		require.NoError(t, r.Commit(ctx, tx))
+		assert.Len(t, findBlogs(t, r, query.NewScope(mStruct)), 2)
+
+		err := r.Commit(ctx, tx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, query.ErrTxInvalid))
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		r := New()
+		tx := newTx(query.LevelDefault)
+		require.NoError(t, r.Begin(ctx, tx))
+		s := query.NewScope(mStruct, &testmodels.Blog{Title: "tx"})
+		s.Transaction = tx
+		require.NoError(t, r.Insert(ctx, s))
+		require.NoError(t, r.Rollback(ctx, tx))
+		assert.Empty(t, findBlogs(t, r, query.NewScope(mStruct)))
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		r := New()
+		insertBlogs(t, r, mStruct, &testmodels.Blog{Title: "first"})
+
+		tx := newTx(query.LevelRepeatableRead)
+		require.NoError(t, r.Begin(ctx, tx))
+
+		// Concurrent update.
+		s := query.NewScope(mStruct, &testmodels.Blog{ID: 1, Title: "concurrent"})
+		s.FieldSets = []mapping.FieldSet{{title}}
+		_, err := r.UpdateModels(ctx, s)
+		require.NoError(t, err)
+
+		// Transaction still reads its snapshot.
+		s = query.NewScope(mStruct)
+		s.Transaction = tx
+		blogs := findBlogs(t, r, s)
+		require.Len(t, blogs, 1)
+		assert.Equal(t, "first", blogs[0].Title)
+
+		s = query.NewScope(mStruct, &testmodels.Blog{ID: 1, Title: "tx"})
+		s.FieldSets = []mapping.FieldSet{{title}}
+		s.Transaction = tx
+		_, err = r.UpdateModels(ctx, s)
+		require.NoError(t, err)
+
+		err = r.Commit(ctx, tx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrSerialization))
+
+		blogs = findBlogs(t, r, query.NewScope(mStruct))
+		require.Len(t, blogs, 1)
+		assert.Equal(t, "concurrent", blogs[0].Title)
+	})
+
+	t.Run("Serializable", func(t *testing.T) {
+		r := New()
+		insertBlogs(t, r, mStruct, &testmodels.Blog{Title: "first"})
+
+		tx := newTx(query.LevelSerializable)
+		require.NoError(t, r.Begin(ctx, tx))
+		s := query.NewScope(mStruct)
+		s.Transaction = tx
+		require.Len(t, findBlogs(t, r, s), 1)
+
+		// Concurrent insert creates a phantom for the transaction read.
+		insertBlogs(t, r, mStruct, &testmodels.Blog{Title: "second"})
+
+		s = query.NewScope(mStruct, &testmodels.Blog{Title: "tx"})
+		s.Transaction = tx
+		require.NoError(t, r.Insert(ctx, s))
+
+		err := r.Commit(ctx, tx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrSerialization))
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		r := New()
+		tx := newTx(query.LevelDefault)
+		tx.Options.ReadOnly = true
+		require.NoError(t, r.Begin(ctx, tx))
+		s := query.NewScope(mStruct, &testmodels.Blog{Title: "tx"})
+		s.Transaction = tx
+		err := r.Insert(ctx, s)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrReadOnly))
+	})
+
+	t.Run("Savepoint", func(t *testing.T) {
+		r := New()
+		tx := newTx(query.LevelDefault)
+		require.NoError(t, r.Begin(ctx, tx))
+
+		s := query.NewScope(mStruct, &testmodels.Blog{Title: "first"})
+		s.Transaction = tx
+		require.NoError(t, r.Insert(ctx, s))
+		require.NoError(t, r.Savepoint(ctx, tx, "sp"))
+
+		s = query.NewScope(mStruct, &testmodels.Blog{Title: "second"})
+		s.Transaction = tx
+		require.NoError(t, r.Insert(ctx, s))
+
+		require.NoError(t, r.RollbackSavepoint(ctx, tx, "sp"))
+		err := r.RollbackSavepoint(ctx, tx, "unknown")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, query.ErrTxInvalid))
+
+		require.NoError(t, r.Commit(ctx, tx))
+		blogs := findBlogs(t, r, query.NewScope(mStruct))
+		require.Len(t, blogs, 1)
+		assert.Equal(t, "first", blogs[0].Title)
+	})
+}
+
+func TestDatabase(t *testing.T) {
+	m := testModelMap(t)
+	r := New()
+	db, err := database.New(database.WithDefaultRepository(r), database.WithModelMap(m), database.WithMigrateModels(&testmodels.Blog{}))
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, db.Dial(ctx))
+
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	err = database.RunInTransaction(ctx, db, nil, func(db database.DB) error {
+		return db.Insert(ctx, mStruct, &testmodels.Blog{Title: "first"}, &testmodels.Blog{Title: "second"})
+	})
+	require.NoError(t, err)
+
+	models, err := db.Query(mStruct).Where("Title = ?", "second").Find()
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	blog := models[0].(*testmodels.Blog)
+	assert.Equal(t, 2, blog.ID)
+	assert.False(t, blog.CreatedAt.IsZero())
+
+	affected, err := db.Delete(ctx, mStruct, blog)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	count, err := db.Query(mStruct).Count()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
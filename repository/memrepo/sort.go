@@ -0,0 +1,74 @@
+package memrepo
+
+import (
+	"sort"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/repository"
+)
+
+// sortRecords sorts the records using provided sorting order. The records with equal sort values preserves their
+// insertion order.
+func sortRecords(records []*record, order []query.Sort) error {
+	if len(order) == 0 {
+		return nil
+	}
+	for _, s := range order {
+		if _, ok := s.(query.SortField); !ok {
+			return errors.WrapDetf(repository.ErrNotImplements, "sort: '%T' is not supported by the in-memory repository", s)
+		}
+	}
+	var err error
+	sort.SliceStable(records, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		for _, s := range order {
+			var res int
+			res, err = compareRecordsField(records[i], records[j], s.Field())
+			if err != nil {
+				return false
+			}
+			if res == 0 {
+				continue
+			}
+			if s.Order() == query.DescendingOrder {
+				return res > 0
+			}
+			return res < 0
+		}
+		return false
+	})
+	return err
+}
+
+func compareRecordsField(a, b *record, field *mapping.StructField) (int, error) {
+	av, err := a.fielder().GetFieldValue(field)
+	if err != nil {
+		return 0, err
+	}
+	bv, err := b.fielder().GetFieldValue(field)
+	if err != nil {
+		return 0, err
+	}
+	return compareValues(av, bv)
+}
+
+// paginate returns the records slice limited by provided pagination.
+func paginate(records []*record, p *query.Pagination) []*record {
+	if p == nil {
+		return records
+	}
+	if p.Offset > 0 {
+		if p.Offset >= int64(len(records)) {
+			return records[:0]
+		}
+		records = records[p.Offset:]
+	}
+	if p.Limit > 0 && p.Limit < int64(len(records)) {
+		records = records[:p.Limit]
+	}
+	return records
+}
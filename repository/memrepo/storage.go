@@ -0,0 +1,322 @@
+package memrepo
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// record is a single stored model instance. Once stored, the record model is never modified - each change
+// creates a new record.
+type record struct {
+	model   mapping.Model
+	version uint64
+	ordinal uint64
+}
+
+func (r *record) fielder() mapping.Fielder {
+	return r.model.(mapping.Fielder)
+}
+
+// table is the storage for the records of a single model.
+type table struct {
+	records  map[interface{}]*record
+	sequence int64
+	// modified is the repository version of the latest commit that changed given table.
+	modified uint64
+}
+
+func newTable() *table {
+	return &table{records: map[interface{}]*record{}}
+}
+
+// view is the read and write access to the repository records. Depending on the transaction it reads the records
+// directly from the repository tables or from the transaction snapshot with its uncommitted changes.
+type view struct {
+	r  *Repository
+	tx *transaction
+}
+
+// base gets the records the view is based on.
+func (v *view) base(mStruct *mapping.ModelStruct) map[interface{}]*record {
+	if v.tx != nil && v.tx.snapshot != nil {
+		return v.tx.snapshot[mStruct]
+	}
+	t, ok := v.r.tables[mStruct]
+	if !ok {
+		return nil
+	}
+	return t.records
+}
+
+// get gets the record with the primary 'key'.
+func (v *view) get(mStruct *mapping.ModelStruct, key interface{}) (*record, bool) {
+	if v.tx != nil {
+		v.tx.read[mStruct] = struct{}{}
+		if c, ok := v.tx.changes[mStruct][key]; ok {
+			return c.record, c.record != nil
+		}
+	}
+	rec, ok := v.base(mStruct)[key]
+	return rec, ok
+}
+
+// records gets all the records in the insertion order.
+func (v *view) records(mStruct *mapping.ModelStruct) []*record {
+	base := v.base(mStruct)
+	var changes map[interface{}]*change
+	if v.tx != nil {
+		changes = v.tx.changes[mStruct]
+		v.tx.read[mStruct] = struct{}{}
+	}
+	records := make([]*record, 0, len(base)+len(changes))
+	for key, rec := range base {
+		if _, changed := changes[key]; changed {
+			continue
+		}
+		records = append(records, rec)
+	}
+	for _, c := range changes {
+		if c.record != nil {
+			records = append(records, c.record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ordinal < records[j].ordinal
+	})
+	return records
+}
+
+// find gets all the records that matches the scope filters.
+func (v *view) find(s *query.Scope) ([]*record, error) {
+	var result []*record
+	for _, rec := range v.records(s.ModelStruct) {
+		matched, err := matchFilters(rec.fielder(), s.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			result = append(result, rec)
+		}
+	}
+	return result, nil
+}
+
+// put sets the 'rec' record for the primary 'key'. If the 'rec' is nil the record is deleted.
+func (v *view) put(mStruct *mapping.ModelStruct, key interface{}, rec *record) error {
+	if v.tx == nil {
+		t := v.r.table(mStruct)
+		v.r.version++
+		t.modified = v.r.version
+		if rec == nil {
+			delete(t.records, key)
+			return nil
+		}
+		rec.version = v.r.version
+		t.records[key] = rec
+		return nil
+	}
+	if v.tx.readOnly {
+		return errors.WrapDetf(ErrReadOnly, "transaction: '%s' is read only", v.tx.id)
+	}
+	changes, ok := v.tx.changes[mStruct]
+	if !ok {
+		changes = map[interface{}]*change{}
+		v.tx.changes[mStruct] = changes
+	}
+	// The changes are shared with the savepoints - always create a new change instead of modifying the previous one.
+	c := &change{record: rec}
+	if prev, ok := changes[key]; ok {
+		c.baseVersion = prev.baseVersion
+	} else if baseRecord, exists := v.base(mStruct)[key]; exists {
+		c.baseVersion = baseRecord.version
+	}
+	changes[key] = c
+	return nil
+}
+
+// newRecord creates new record for the 'mStruct' with the field values taken from the 'from' model.
+// If the 'prev' record is provided, the fields that are not in the 'fieldSet' are taken from it.
+func (v *view) newRecord(mStruct *mapping.ModelStruct, from mapping.Fielder, fieldSet mapping.FieldSet, prev *record) (*record, error) {
+	model := mapping.NewModel(mStruct)
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return nil, errModelNotFielder(mStruct)
+	}
+	rec := &record{model: model}
+	if prev != nil {
+		rec.ordinal = prev.ordinal
+		for _, field := range mStruct.Fields() {
+			if fieldSet.Contains(field) {
+				continue
+			}
+			if err := copyFieldValue(fielder, prev.fielder(), field); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		v.r.ordinal++
+		rec.ordinal = v.r.ordinal
+	}
+	for _, field := range fieldSet {
+		if !field.IsField() {
+			continue
+		}
+		if err := copyFieldValue(fielder, from, field); err != nil {
+			return nil, err
+		}
+	}
+	return rec, nil
+}
+
+// checkUnique checks if the 'rec' doesn't violate any unique constraints within given view.
+func (v *view) checkUnique(mStruct *mapping.ModelStruct, key interface{}, rec *record) error {
+	return checkUnique(mStruct, key, rec, v.records(mStruct))
+}
+
+func checkUnique(mStruct *mapping.ModelStruct, key interface{}, rec *record, records []*record) error {
+	constraints := uniqueConstraints(mStruct)
+	if len(constraints) == 0 {
+		return nil
+	}
+	for _, other := range records {
+		if other == rec || other.model.GetPrimaryKeyHashableValue() == key {
+			continue
+		}
+		for _, fields := range constraints {
+			equal, err := equalFieldValues(rec.fielder(), other.fielder(), fields)
+			if err != nil {
+				return err
+			}
+			if equal {
+				return errors.WrapDetf(query.ErrViolationUnique, "model: '%s' violates unique constraint on fields: %v", mStruct, fields)
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueConstraints gets the field sets that needs to be unique for the model. The primary key uniqueness is
+// assured by the table itself.
+func uniqueConstraints(mStruct *mapping.ModelStruct) (constraints []mapping.FieldSet) {
+	for _, field := range mStruct.Fields() {
+		if !field.IsPrimary() && field.DatabaseUnique() {
+			constraints = append(constraints, mapping.FieldSet{field})
+		}
+	}
+	for _, index := range mStruct.DatabaseIndexes() {
+		if index.Unique && len(index.Fields) > 0 {
+			constraints = append(constraints, index.Fields)
+		}
+	}
+	return constraints
+}
+
+func equalFieldValues(a, b mapping.Fielder, fields mapping.FieldSet) (bool, error) {
+	for _, field := range fields {
+		av, err := a.GetFieldValue(field)
+		if err != nil {
+			return false, err
+		}
+		// Null values never violates the uniqueness.
+		if isNullValue(av) {
+			return false, nil
+		}
+		bv, err := b.GetFieldValue(field)
+		if err != nil {
+			return false, err
+		}
+		equal, err := equalValues(av, bv)
+		if err != nil || !equal {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// setPrimaryKey sets the primary key value for the model that is going to be inserted. If the model has zero value
+// primary key, then the integer primary keys are incremented and the string primary keys gets new UUID value.
+func (v *view) setPrimaryKey(mStruct *mapping.ModelStruct, model mapping.Model) error {
+	t := v.r.table(mStruct)
+	primary := mStruct.Primary()
+	if !model.IsPrimaryKeyZero() {
+		rv, _ := indirect(model.GetPrimaryKeyValue())
+		switch numberClass(rv.Kind()) {
+		case reflect.Int64:
+			if rv.Int() > t.sequence {
+				t.sequence = rv.Int()
+			}
+		case reflect.Uint64:
+			if rv.Uint() > uint64(t.sequence) {
+				t.sequence = int64(rv.Uint())
+			}
+		}
+		return nil
+	}
+	switch kind := primary.GetDereferencedType().Kind(); {
+	case isNumber(kind) && numberClass(kind) != reflect.Float64:
+		t.sequence++
+		return model.SetPrimaryKeyValue(t.sequence)
+	case kind == reflect.String:
+		return model.SetPrimaryKeyStringValue(uuid.New().String())
+	case kind == reflect.Array && primary.GetDereferencedType().Len() == 16:
+		return model.SetPrimaryKeyValue(uuid.New())
+	default:
+		return errors.WrapDetf(query.ErrInvalidModels, "cannot generate primary key value for the model: '%s'", mStruct)
+	}
+}
+
+func copyFieldValue(to, from mapping.Fielder, field *mapping.StructField) error {
+	value, err := from.GetFieldValue(field)
+	if err != nil {
+		return err
+	}
+	if isNullValue(value) {
+		return to.SetFieldZeroValue(field)
+	}
+	return to.SetFieldValue(field, cloneValue(value))
+}
+
+func errModelNotFielder(mStruct *mapping.ModelStruct) error {
+	return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement mapping.Fielder interface", mStruct)
+}
+
+// change is the transaction change of a single record. Nil record means the record was deleted.
+type change struct {
+	record      *record
+	baseVersion uint64
+}
+
+// transaction is the repository transaction state.
+type transaction struct {
+	id           uuid.UUID
+	isolation    query.IsolationLevel
+	readOnly     bool
+	startVersion uint64
+	snapshot     map[*mapping.ModelStruct]map[interface{}]*record
+	changes      map[*mapping.ModelStruct]map[interface{}]*change
+	read         map[*mapping.ModelStruct]struct{}
+	savepoints   []*savepoint
+}
+
+type savepoint struct {
+	name    string
+	changes map[*mapping.ModelStruct]map[interface{}]*change
+}
+
+func copyChanges(changes map[*mapping.ModelStruct]map[interface{}]*change) map[*mapping.ModelStruct]map[interface{}]*change {
+	cp := make(map[*mapping.ModelStruct]map[interface{}]*change, len(changes))
+	for mStruct, tableChanges := range changes {
+		tableCopy := make(map[interface{}]*change, len(tableChanges))
+		for key, c := range tableChanges {
+			tableCopy[key] = c
+		}
+		cp[mStruct] = tableCopy
+	}
+	return cp
+}
@@ -0,0 +1,214 @@
+package memrepo
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// Begin implements repository.Transactioner interface.
+//
+// The isolation levels are implemented as follows:
+//	- LevelReadUncommitted, LevelReadCommitted and LevelWriteCommitted	- the transaction reads the latest committed
+//	  records. On commit the last writer wins.
+//	- LevelRepeatableRead and LevelSnapshot - the transaction reads the records from the snapshot taken at its beginning.
+//	  The commit fails with ErrSerialization if any of the changed records were committed by other transaction
+//	  in the meantime.
+//	- LevelSerializable and LevelLinearizable - works as the snapshot isolation. In addition the commit fails with
+//	  ErrSerialization if any model the transaction had read from was changed by other transaction in the meantime.
+func (r *Repository) Begin(_ context.Context, tx *query.Transaction) error {
+	if tx == nil {
+		return errors.WrapDet(query.ErrTxInvalid, "provided nil transaction")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.transactions[tx.ID]; ok {
+		return errors.WrapDetf(query.ErrTxState, "transaction: '%s' already began", tx.ID)
+	}
+	t := &transaction{
+		id:           tx.ID,
+		isolation:    r.options.DefaultIsolation,
+		startVersion: r.version,
+		changes:      map[*mapping.ModelStruct]map[interface{}]*change{},
+		read:         map[*mapping.ModelStruct]struct{}{},
+	}
+	if tx.Options != nil {
+		if tx.Options.Isolation != query.LevelDefault {
+			t.isolation = tx.Options.Isolation
+		}
+		t.readOnly = tx.Options.ReadOnly
+	}
+	if t.isolation >= query.LevelRepeatableRead {
+		// Stored records are never modified, thus the snapshot needs only to copy the records mappings.
+		t.snapshot = make(map[*mapping.ModelStruct]map[interface{}]*record, len(r.tables))
+		for mStruct, tb := range r.tables {
+			records := make(map[interface{}]*record, len(tb.records))
+			for key, rec := range tb.records {
+				records[key] = rec
+			}
+			t.snapshot[mStruct] = records
+		}
+	}
+	r.transactions[tx.ID] = t
+	log.Debug3f("[%s] begin transaction: '%s' with isolation: %s", r.ID(), tx.ID, t.isolation)
+	return nil
+}
+
+// Commit implements repository.Transactioner interface.
+func (r *Repository) Commit(_ context.Context, tx *query.Transaction) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	t, err := r.transaction(tx)
+	if err != nil {
+		return err
+	}
+	// The transaction is finished regardless of the commit result.
+	delete(r.transactions, t.id)
+
+	if t.isolation >= query.LevelRepeatableRead {
+		if err = r.checkConflicts(t); err != nil {
+			return err
+		}
+	}
+
+	// Apply the changes on the copies of the tables so that the commit is either fully applied or not at all.
+	version := r.version + 1
+	tables := make(map[*mapping.ModelStruct]map[interface{}]*record, len(t.changes))
+	for mStruct, changes := range t.changes {
+		if len(changes) == 0 {
+			continue
+		}
+		current := r.table(mStruct).records
+		records := make(map[interface{}]*record, len(current)+len(changes))
+		for key, rec := range current {
+			records[key] = rec
+		}
+		for key, c := range changes {
+			if c.record == nil {
+				delete(records, key)
+				continue
+			}
+			rec := *c.record
+			rec.version = version
+			records[key] = &rec
+		}
+		tables[mStruct] = records
+	}
+	// Check the unique constraints of the changed records against the records committed by other transactions.
+	for mStruct, records := range tables {
+		all := make([]*record, 0, len(records))
+		for _, rec := range records {
+			all = append(all, rec)
+		}
+		for key := range t.changes[mStruct] {
+			rec, ok := records[key]
+			if !ok {
+				continue
+			}
+			if err = checkUnique(mStruct, key, rec, all); err != nil {
+				return err
+			}
+		}
+	}
+	if len(tables) == 0 {
+		log.Debug3f("[%s] commit transaction: '%s' - nothing to commit", r.ID(), t.id)
+		return nil
+	}
+	r.version = version
+	for mStruct, records := range tables {
+		tb := r.table(mStruct)
+		tb.records = records
+		tb.modified = version
+	}
+	log.Debug3f("[%s] commit transaction: '%s'", r.ID(), t.id)
+	return nil
+}
+
+// Rollback implements repository.Transactioner interface.
+func (r *Repository) Rollback(_ context.Context, tx *query.Transaction) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	t, err := r.transaction(tx)
+	if err != nil {
+		return err
+	}
+	delete(r.transactions, t.id)
+	log.Debug3f("[%s] rollback transaction: '%s'", r.ID(), t.id)
+	return nil
+}
+
+// Savepoint implements repository.Savepointer interface.
+func (r *Repository) Savepoint(_ context.Context, tx *query.Transaction, name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	t, err := r.transaction(tx)
+	if err != nil {
+		return err
+	}
+	t.savepoints = append(t.savepoints, &savepoint{name: name, changes: copyChanges(t.changes)})
+	return nil
+}
+
+// RollbackSavepoint implements repository.Savepointer interface. It reverts all the transaction changes done after
+// the savepoint with provided 'name'. The savepoint itself remains valid, all the later savepoints are released.
+func (r *Repository) RollbackSavepoint(_ context.Context, tx *query.Transaction, name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	t, err := r.transaction(tx)
+	if err != nil {
+		return err
+	}
+	for i := len(t.savepoints) - 1; i >= 0; i-- {
+		sp := t.savepoints[i]
+		if sp.name != name {
+			continue
+		}
+		t.changes = copyChanges(sp.changes)
+		t.savepoints = t.savepoints[:i+1]
+		return nil
+	}
+	return errors.WrapDetf(query.ErrTxInvalid, "transaction: '%s' doesn't have savepoint: '%s'", t.id, name)
+}
+
+func (r *Repository) transaction(tx *query.Transaction) (*transaction, error) {
+	if tx == nil {
+		return nil, errors.WrapDet(query.ErrTxInvalid, "provided nil transaction")
+	}
+	t, ok := r.transactions[tx.ID]
+	if !ok {
+		return nil, errors.WrapDetf(query.ErrTxInvalid, "transaction: '%s' not found in the repository: '%s'", tx.ID, r.ID())
+	}
+	return t, nil
+}
+
+func (r *Repository) checkConflicts(t *transaction) error {
+	for mStruct, changes := range t.changes {
+		current := r.table(mStruct).records
+		for key, c := range changes {
+			var currentVersion uint64
+			if rec, ok := current[key]; ok {
+				currentVersion = rec.version
+			}
+			if currentVersion != c.baseVersion {
+				return errors.WrapDetf(ErrSerialization, "model: '%s' with primary key: '%v' was changed by concurrent transaction", mStruct, key)
+			}
+		}
+	}
+	if t.isolation < query.LevelSerializable {
+		return nil
+	}
+	for mStruct := range t.read {
+		if tb, ok := r.tables[mStruct]; ok && tb.modified > t.startVersion {
+			return errors.WrapDetf(ErrSerialization, "models: '%s' read by the transaction were changed by concurrent transaction", mStruct)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package memrepo
+
+import (
+	"bytes"
+	"reflect"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// compareValues compares values 'a' and 'b'. The result is 0 if a == b, -1 if a < b and +1 if a > b.
+// Nil values are always lower than non nil ones.
+// Numeric values of different types are compared by their numeric values.
+func compareValues(a, b interface{}) (int, error) {
+	av, aNil := indirect(a)
+	bv, bNil := indirect(b)
+	switch {
+	case aNil && bNil:
+		return 0, nil
+	case aNil:
+		return -1, nil
+	case bNil:
+		return 1, nil
+	}
+
+	if av.Type() == timeType && bv.Type() == timeType {
+		at, bt := av.Interface().(time.Time), bv.Interface().(time.Time)
+		switch {
+		case at.Before(bt):
+			return -1, nil
+		case at.After(bt):
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	switch {
+	case isNumber(av.Kind()) && isNumber(bv.Kind()):
+		return compareNumbers(av, bv), nil
+	case isStringLike(av) && isStringLike(bv):
+		return bytes.Compare(stringBytes(av), stringBytes(bv)), nil
+	case av.Kind() == reflect.Bool && bv.Kind() == reflect.Bool:
+		ab, bb := av.Bool(), bv.Bool()
+		switch {
+		case ab == bb:
+			return 0, nil
+		case !ab:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	case av.Kind() == reflect.Array && bv.Kind() == reflect.Array && av.Type().Elem().Kind() == reflect.Uint8 && bv.Type().Elem().Kind() == reflect.Uint8:
+		return bytes.Compare(arrayBytes(av), arrayBytes(bv)), nil
+	}
+	if reflect.DeepEqual(av.Interface(), bv.Interface()) {
+		return 0, nil
+	}
+	return 0, errors.WrapDetf(filter.ErrFilterValues, "cannot compare values of types: '%s' and '%s'", av.Type(), bv.Type())
+}
+
+// equalValues checks if the values 'a' and 'b' are equal.
+func equalValues(a, b interface{}) (bool, error) {
+	res, err := compareValues(a, b)
+	if err != nil {
+		return false, err
+	}
+	return res == 0, nil
+}
+
+// isNullValue checks if provided value is nil.
+func isNullValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// stringValue gets the string value of provided 'v'. If the value is not string like the function returns false.
+func stringValue(v interface{}) (string, bool) {
+	rv, isNil := indirect(v)
+	if isNil || !isStringLike(rv) {
+		return "", false
+	}
+	return string(stringBytes(rv)), true
+}
+
+func indirect(v interface{}) (reflect.Value, bool) {
+	if v == nil {
+		return reflect.Value{}, true
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return rv, true
+		}
+		rv = rv.Elem()
+	}
+	return rv, false
+}
+
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func compareNumbers(a, b reflect.Value) int {
+	ak, bk := numberClass(a.Kind()), numberClass(b.Kind())
+	switch {
+	case ak == reflect.Int64 && bk == reflect.Int64:
+		return compareInt64(a.Int(), b.Int())
+	case ak == reflect.Uint64 && bk == reflect.Uint64:
+		return compareUint64(a.Uint(), b.Uint())
+	case ak == reflect.Int64 && bk == reflect.Uint64:
+		if a.Int() < 0 {
+			return -1
+		}
+		return compareUint64(uint64(a.Int()), b.Uint())
+	case ak == reflect.Uint64 && bk == reflect.Int64:
+		if b.Int() < 0 {
+			return 1
+		}
+		return compareUint64(a.Uint(), uint64(b.Int()))
+	}
+	return compareFloat64(toFloat64(a), toFloat64(b))
+}
+
+func numberClass(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint64
+	default:
+		return reflect.Float64
+	}
+}
+
+func toFloat64(v reflect.Value) float64 {
+	switch numberClass(v.Kind()) {
+	case reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint64:
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isStringLike(v reflect.Value) bool {
+	return v.Kind() == reflect.String || (v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8)
+}
+
+func stringBytes(v reflect.Value) []byte {
+	if v.Kind() == reflect.String {
+		return []byte(v.String())
+	}
+	return v.Bytes()
+}
+
+func arrayBytes(v reflect.Value) []byte {
+	b := make([]byte, v.Len())
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+	return b
+}
+
+// cloneValue creates a deep copy of provided value so that the stored records are not shared with the query models.
+func cloneValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return cloneReflectValue(reflect.ValueOf(v)).Interface()
+}
+
+func cloneReflectValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(cloneReflectValue(v.Elem()))
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(cloneReflectValue(v.Index(i)))
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), cloneReflectValue(iter.Value()))
+		}
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(cloneReflectValue(v.Field(i)))
+			}
+		}
+		return cp
+	default:
+		return v
+	}
+}
@@ -0,0 +1,252 @@
+package sqlrepo
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/repository"
+)
+
+// builder is the SQL statement builder.
+type builder struct {
+	dialect Dialect
+	sb      strings.Builder
+	args    []interface{}
+}
+
+func newBuilder(dialect Dialect) *builder {
+	return &builder{dialect: dialect}
+}
+
+// String gets the built statement.
+func (b *builder) String() string {
+	return b.sb.String()
+}
+
+func (b *builder) write(parts ...string) {
+	for _, part := range parts {
+		b.sb.WriteString(part)
+	}
+}
+
+// arg adds the argument and writes its placeholder.
+func (b *builder) arg(value interface{}) {
+	b.args = append(b.args, value)
+	b.sb.WriteString(b.dialect.Placeholder(len(b.args)))
+}
+
+func (b *builder) column(field *mapping.StructField) string {
+	return b.dialect.QuoteIdentifier(field.DatabaseName)
+}
+
+// columns writes comma separated list of the field columns.
+func (b *builder) columns(fields mapping.FieldSet) {
+	for i, field := range fields {
+		if i != 0 {
+			b.write(", ")
+		}
+		b.write(b.column(field))
+	}
+}
+
+// where writes the WHERE clause for provided filters.
+func (b *builder) where(filters filter.Filters) error {
+	if len(filters) == 0 {
+		return nil
+	}
+	b.write(" WHERE ")
+	for i, f := range filters {
+		if i != 0 {
+			b.write(" AND ")
+		}
+		if err := b.filter(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builder) filter(f filter.Filter) error {
+	switch ft := f.(type) {
+	case filter.Simple:
+		return b.simpleFilter(ft)
+	case *filter.Simple:
+		return b.simpleFilter(*ft)
+	case filter.OrGroup:
+		if len(ft) == 0 {
+			return errors.WrapDet(filter.ErrFilterFormat, "empty or group filter")
+		}
+		b.write("(")
+		for i, sf := range ft {
+			if i != 0 {
+				b.write(" OR ")
+			}
+			if err := b.simpleFilter(sf); err != nil {
+				return err
+			}
+		}
+		b.write(")")
+		return nil
+	case filter.Relation, *filter.Relation:
+		// The relationship filters are reduced into model filters by the database before reaching the repository.
+		return errors.WrapDetf(repository.ErrNotImplements, "relationship filters are not supported by the sql repository: '%s'", f)
+	default:
+		return errors.WrapDetf(filter.ErrFilterFormat, "unsupported filter type: '%T'", f)
+	}
+}
+
+func (b *builder) simpleFilter(f filter.Simple) error {
+	if f.StructField == nil || f.Operator == nil {
+		return errors.WrapDetf(filter.ErrFilterFormat, "invalid filter: '%v'", f)
+	}
+	if !f.StructField.IsField() {
+		return errors.WrapDetf(filter.ErrFilterField, "filter field: '%s' is not a model field", f.StructField)
+	}
+	column := b.column(f.StructField)
+	switch f.Operator {
+	case filter.OpIsNull:
+		b.write(column, " IS NULL")
+		return nil
+	case filter.OpNotNull:
+		b.write(column, " IS NOT NULL")
+		return nil
+	}
+	if len(f.Values) == 0 {
+		return errors.WrapDetf(filter.ErrFilterValues, "no values provided for the filter: '%s'", f)
+	}
+
+	var sqlOperator string
+	switch f.Operator {
+	case filter.OpEqual, filter.OpIn:
+		if len(f.Values) == 1 && f.Values[0] == nil {
+			b.write(column, " IS NULL")
+			return nil
+		}
+		if len(f.Values) > 1 || f.Operator == filter.OpIn {
+			return b.inFilter(column, " IN (", f)
+		}
+		sqlOperator = " = "
+	case filter.OpNotEqual, filter.OpNotIn:
+		if len(f.Values) == 1 && f.Values[0] == nil {
+			b.write(column, " IS NOT NULL")
+			return nil
+		}
+		if len(f.Values) > 1 || f.Operator == filter.OpNotIn {
+			return b.inFilter(column, " NOT IN (", f)
+		}
+		sqlOperator = " <> "
+	case filter.OpGreaterThan:
+		sqlOperator = " > "
+	case filter.OpGreaterEqual:
+		sqlOperator = " >= "
+	case filter.OpLessThan:
+		sqlOperator = " < "
+	case filter.OpLessEqual:
+		sqlOperator = " <= "
+	case filter.OpContains, filter.OpStartsWith, filter.OpEndsWith:
+		return b.likeFilter(column, f)
+	default:
+		return errors.WrapDetf(repository.ErrNotImplements, "filter operator: '%s' is not supported by the sql repository", f.Operator.Name)
+	}
+	if len(f.Values) != 1 {
+		return errors.WrapDetf(filter.ErrFilterValues, "operator: '%s' requires exactly one value", f.Operator.Name)
+	}
+	value, err := fieldArgument(f.StructField, f.Values[0])
+	if err != nil {
+		return err
+	}
+	b.write(column, sqlOperator)
+	b.arg(value)
+	return nil
+}
+
+func (b *builder) inFilter(column, operator string, f filter.Simple) error {
+	b.write(column, operator)
+	for i, v := range f.Values {
+		if i != 0 {
+			b.write(", ")
+		}
+		value, err := fieldArgument(f.StructField, v)
+		if err != nil {
+			return err
+		}
+		b.arg(value)
+	}
+	b.write(")")
+	return nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (b *builder) likeFilter(column string, f filter.Simple) error {
+	if len(f.Values) > 1 {
+		b.write("(")
+	}
+	for i, v := range f.Values {
+		if i != 0 {
+			b.write(" OR ")
+		}
+		rv := reflect.ValueOf(v)
+		if v == nil || (rv.Kind() != reflect.String && !(rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8)) {
+			return errors.WrapDetf(filter.ErrFilterValues, "operator: '%s' requires string values", f.Operator.Name)
+		}
+		var pattern string
+		if rv.Kind() == reflect.String {
+			pattern = rv.String()
+		} else {
+			pattern = string(rv.Bytes())
+		}
+		pattern = likeEscaper.Replace(pattern)
+		switch f.Operator {
+		case filter.OpContains:
+			pattern = "%" + pattern + "%"
+		case filter.OpStartsWith:
+			pattern += "%"
+		default:
+			pattern = "%" + pattern
+		}
+		b.write(column, " LIKE ")
+		b.arg(pattern)
+		b.write(` ESCAPE '\'`)
+	}
+	if len(f.Values) > 1 {
+		b.write(")")
+	}
+	return nil
+}
+
+// orderBy writes the ORDER BY clause for provided sorting order.
+func (b *builder) orderBy(order []query.Sort) error {
+	if len(order) == 0 {
+		return nil
+	}
+	b.write(" ORDER BY ")
+	for i, s := range order {
+		sortField, ok := s.(query.SortField)
+		if !ok {
+			return errors.WrapDetf(repository.ErrNotImplements, "sort: '%T' is not supported by the sql repository", s)
+		}
+		if i != 0 {
+			b.write(", ")
+		}
+		b.write(b.column(sortField.StructField))
+		if sortField.SortOrder == query.DescendingOrder {
+			b.write(" DESC")
+		} else {
+			b.write(" ASC")
+		}
+	}
+	return nil
+}
+
+// pagination writes the pagination clause.
+func (b *builder) pagination(p *query.Pagination) {
+	if p == nil || (p.Limit == 0 && p.Offset == 0) {
+		return
+	}
+	b.write(" ", b.dialect.LimitOffset(p.Limit, p.Offset))
+}
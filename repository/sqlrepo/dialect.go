@@ -0,0 +1,92 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/repository"
+)
+
+// Dialect is the interface that defines the differences between SQL databases. The repository builds all the
+// queries using the dialect so that it could be used with any database that has a database/sql driver.
+type Dialect interface {
+	// Name gets the dialect name.
+	Name() string
+	// DriverName gets the database/sql driver name used by the dialect.
+	DriverName() string
+	// DataSourceName creates the driver specific data source name from the repository options.
+	DataSourceName(o *repository.Options) (string, error)
+
+	// QuoteIdentifier quotes the identifier i.e. table, column or index name.
+	QuoteIdentifier(identifier string) string
+	// Placeholder gets the query argument placeholder for the argument at 1-based 'index'.
+	Placeholder(index int) string
+	// LimitOffset gets the limit and offset clause. Zero 'limit' means no limit.
+	LimitOffset(limit, offset int64) string
+	// Returning gets the clause that returns the 'column' value of the inserted row. If the result is empty the
+	// repository uses sql.Result LastInsertId.
+	Returning(column string) string
+	// OnConflictUpdate gets the upsert clause that updates the 'update' columns when the insert conflicts
+	// on the 'conflict' columns.
+	OnConflictUpdate(conflict, update []string) string
+
+	// ColumnType gets the database column type for provided non primary field.
+	ColumnType(field *mapping.StructField) (string, error)
+	// PrimaryKeyDefinition gets the type and constraints of the primary key column.
+	PrimaryKeyDefinition(field *mapping.StructField) (string, error)
+	// CreateIndex gets the statement that creates the 'index' on the 'schema'.'table' with provided 'columns'.
+	CreateIndex(schema, table string, index *mapping.DatabaseIndex, columns []string) string
+
+	// IsolationLevel maps the query isolation level into the database/sql isolation level supported by the database.
+	IsolationLevel(level query.IsolationLevel) (sql.IsolationLevel, error)
+	// Savepoint gets the statement that creates savepoint with 'name'.
+	Savepoint(name string) string
+	// RollbackSavepoint gets the statement that rollbacks to the savepoint with 'name'.
+	RollbackSavepoint(name string) string
+
+	// TranslateError translates the driver specific error into neuron error classes.
+	// If the error is not known it should be returned as it is.
+	TranslateError(err error) error
+}
+
+// TableName gets the quoted, schema qualified table name for provided model.
+func TableName(d Dialect, mStruct *mapping.ModelStruct) string {
+	if mStruct.DatabaseSchemaName == "" {
+		return d.QuoteIdentifier(mStruct.DatabaseName)
+	}
+	return d.QuoteIdentifier(mStruct.DatabaseSchemaName) + "." + d.QuoteIdentifier(mStruct.DatabaseName)
+}
+
+// QuoteIdentifier quotes the 'identifier' using provided 'quote' rune. The quote characters within the identifier
+// are doubled.
+func QuoteIdentifier(identifier string, quote rune) string {
+	q := string(quote)
+	return q + strings.Replace(identifier, q, q+q, -1) + q
+}
+
+// DefaultIsolationLevel maps the query isolation level directly into matching database/sql isolation level.
+func DefaultIsolationLevel(level query.IsolationLevel) (sql.IsolationLevel, error) {
+	switch level {
+	case query.LevelDefault:
+		return sql.LevelDefault, nil
+	case query.LevelReadUncommitted:
+		return sql.LevelReadUncommitted, nil
+	case query.LevelReadCommitted:
+		return sql.LevelReadCommitted, nil
+	case query.LevelWriteCommitted:
+		return sql.LevelWriteCommitted, nil
+	case query.LevelRepeatableRead:
+		return sql.LevelRepeatableRead, nil
+	case query.LevelSnapshot:
+		return sql.LevelSnapshot, nil
+	case query.LevelSerializable:
+		return sql.LevelSerializable, nil
+	case query.LevelLinearizable:
+		return sql.LevelLinearizable, nil
+	default:
+		return 0, errors.WrapDetf(query.ErrTxInvalid, "unknown isolation level: %d", level)
+	}
+}
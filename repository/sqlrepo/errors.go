@@ -0,0 +1,13 @@
+package sqlrepo
+
+import (
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/repository"
+)
+
+var (
+	// ErrSQL is the error classification for the errors returned by the SQL database.
+	ErrSQL = errors.Wrap(repository.ErrRepository, "sql")
+	// ErrNotConnected is the error classification for the queries executed on the repository without connection.
+	ErrNotConnected = errors.Wrap(repository.ErrConnection, "not connected")
+)
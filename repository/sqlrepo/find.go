@@ -0,0 +1,133 @@
+package sqlrepo
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// Find implements repository.Repository interface.
+func (r *Repository) Find(ctx context.Context, s *query.Scope) error {
+	fieldSet := s.ModelStruct.Fields()
+	if fs, ok := s.CommonFieldSet(); ok && len(fs) > 0 {
+		fieldSet = fs
+	}
+	fieldSet = databaseFields(fieldSet)
+	if len(fieldSet) == 0 {
+		return errors.WrapDetf(query.ErrNoFieldsInFieldSet, "no database fields selected for the model: '%s'", s.ModelStruct)
+	}
+
+	b := newBuilder(r.Dialect)
+	b.write("SELECT ")
+	b.columns(fieldSet)
+	b.write(" FROM ", TableName(r.Dialect, s.ModelStruct))
+	if err := b.where(s.Filters); err != nil {
+		return err
+	}
+	if err := b.orderBy(s.SortingOrder); err != nil {
+		return err
+	}
+	b.pagination(s.Pagination)
+
+	rows, err := r.queryRows(ctx, s, b)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var models []mapping.Model
+	for rows.Next() {
+		model := mapping.NewModel(s.ModelStruct)
+		fielder, ok := model.(mapping.Fielder)
+		if !ok {
+			return errModelNotFielder(s.ModelStruct)
+		}
+		destinations := make([]*fieldDestination, len(fieldSet))
+		dest := make([]interface{}, len(fieldSet))
+		for i, field := range fieldSet {
+			if destinations[i], err = newFieldDestination(fielder, field); err != nil {
+				return err
+			}
+			dest[i] = destinations[i].dest
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return r.translateError(err)
+		}
+		for _, destination := range destinations {
+			if err = destination.set(); err != nil {
+				return err
+			}
+		}
+		models = append(models, model)
+	}
+	if err = rows.Err(); err != nil {
+		return r.translateError(err)
+	}
+	s.Models = models
+	return nil
+}
+
+// Count implements repository.Repository interface.
+func (r *Repository) Count(ctx context.Context, s *query.Scope) (int64, error) {
+	b := newBuilder(r.Dialect)
+	b.write("SELECT COUNT(*) FROM ", TableName(r.Dialect, s.ModelStruct))
+	if err := b.where(s.Filters); err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := r.queryRow(ctx, s, b, &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Exists implements repository.Exister interface.
+func (r *Repository) Exists(ctx context.Context, s *query.Scope) (bool, error) {
+	b := newBuilder(r.Dialect)
+	b.write("SELECT EXISTS (SELECT 1 FROM ", TableName(r.Dialect, s.ModelStruct))
+	if err := b.where(s.Filters); err != nil {
+		return false, err
+	}
+	b.write(")")
+	var exists bool
+	if err := r.queryRow(ctx, s, b, &exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *Repository) queryRow(ctx context.Context, s *query.Scope, b *builder, dest ...interface{}) error {
+	rows, err := r.queryRows(ctx, s, b)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return r.translateError(err)
+		}
+		return errors.WrapDet(query.ErrNoResult, "no rows in the result")
+	}
+	if err = rows.Scan(dest...); err != nil {
+		return r.translateError(err)
+	}
+	return nil
+}
+
+// databaseFields gets the fields from the 'fieldSet' that are stored in the database.
+func databaseFields(fieldSet mapping.FieldSet) mapping.FieldSet {
+	fields := make(mapping.FieldSet, 0, len(fieldSet))
+	for _, field := range fieldSet {
+		if !field.IsField() || field.DatabaseSkip() {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func errModelNotFielder(mStruct *mapping.ModelStruct) error {
+	return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement mapping.Fielder interface", mStruct)
+}
@@ -0,0 +1,142 @@
+package sqlrepo
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/google/uuid"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// Insert implements repository.Repository interface. Models with zero value integer primary keys gets their
+// values from the database, the zero string and UUID primary keys are generated by the repository.
+func (r *Repository) Insert(ctx context.Context, s *query.Scope) error {
+	for i, model := range s.Models {
+		if err := r.insertModel(ctx, s, i, model, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upsert implements repository.Upserter interface. If the model with given primary key already exists, the fields
+// from the model's field set are updated.
+func (r *Repository) Upsert(ctx context.Context, s *query.Scope) error {
+	for i, model := range s.Models {
+		if err := r.insertModel(ctx, s, i, model, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) insertModel(ctx context.Context, s *query.Scope, index int, model mapping.Model, upsert bool) error {
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return errModelNotFielder(s.ModelStruct)
+	}
+	fieldSet, err := scopeFieldSet(s, index)
+	if err != nil {
+		return err
+	}
+	primary := s.ModelStruct.Primary()
+	// The primary key is always set by the insert - remove it from the field set.
+	columns := make(mapping.FieldSet, 0, len(fieldSet)+1)
+	for _, field := range databaseFields(fieldSet) {
+		if field != primary {
+			columns = append(columns, field)
+		}
+	}
+
+	autoIncrement := false
+	if model.IsPrimaryKeyZero() {
+		switch kind := primary.GetDereferencedType().Kind(); {
+		case kind >= reflect.Int && kind <= reflect.Uint64:
+			autoIncrement = true
+		case kind == reflect.String:
+			err = model.SetPrimaryKeyStringValue(uuid.New().String())
+		case kind == reflect.Array && primary.GetDereferencedType().Len() == 16:
+			err = model.SetPrimaryKeyValue(uuid.New())
+		default:
+			err = errors.WrapDetf(query.ErrInvalidModels, "cannot generate primary key value for the model: '%s'", s.ModelStruct)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if !autoIncrement {
+		columns = append(mapping.FieldSet{primary}, columns...)
+	}
+
+	b := newBuilder(r.Dialect)
+	b.write("INSERT INTO ", TableName(r.Dialect, s.ModelStruct))
+	if len(columns) == 0 {
+		b.write(" DEFAULT VALUES")
+	} else {
+		b.write(" (")
+		b.columns(columns)
+		b.write(") VALUES (")
+		for i, field := range columns {
+			if i != 0 {
+				b.write(", ")
+			}
+			value, err := modelArgument(fielder, field)
+			if err != nil {
+				return err
+			}
+			b.arg(value)
+		}
+		b.write(")")
+	}
+	if upsert && !autoIncrement {
+		update := make([]string, 0, len(columns))
+		for _, field := range columns {
+			if field != primary {
+				update = append(update, r.Dialect.QuoteIdentifier(field.DatabaseName))
+			}
+		}
+		b.write(" ", r.Dialect.OnConflictUpdate([]string{r.Dialect.QuoteIdentifier(primary.DatabaseName)}, update))
+	}
+
+	if !autoIncrement {
+		_, err = r.exec(ctx, s, b)
+		return err
+	}
+
+	returning := r.Dialect.Returning(r.Dialect.QuoteIdentifier(primary.DatabaseName))
+	if returning == "" {
+		result, err := r.exec(ctx, s, b)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return r.translateError(err)
+		}
+		return model.SetPrimaryKeyValue(id)
+	}
+	b.write(" ", returning)
+	var id int64
+	if err = r.queryRow(ctx, s, b, &id); err != nil {
+		return err
+	}
+	return model.SetPrimaryKeyValue(id)
+}
+
+// scopeFieldSet gets the field set for the model at 'index'. If the scope has no field sets all model fields are
+// taken.
+func scopeFieldSet(s *query.Scope, index int) (mapping.FieldSet, error) {
+	switch len(s.FieldSets) {
+	case 0:
+		return s.ModelStruct.Fields(), nil
+	case 1:
+		return s.FieldSets[0], nil
+	case len(s.Models):
+		return s.FieldSets[index], nil
+	default:
+		return nil, errors.WrapDetf(query.ErrInvalidFieldSet, "provided invalid field sets. Models len: %d, FieldSets len: %d", len(s.Models), len(s.FieldSets))
+	}
+}
@@ -0,0 +1,79 @@
+package sqlrepo
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// MigrateModels implements repository.Migrator interface. It creates the tables and indexes for provided models
+// if they don't exist yet.
+func (r *Repository) MigrateModels(ctx context.Context, models ...*mapping.ModelStruct) error {
+	if r.db == nil {
+		return errors.WrapDetf(ErrNotConnected, "repository: '%s' is not connected", r.ID())
+	}
+	for _, mStruct := range models {
+		statements, err := r.createTableStatements(mStruct)
+		if err != nil {
+			return err
+		}
+		for _, stmt := range statements {
+			log.Debug2f("[%s] migrate model: '%s': %s", r.ID(), mStruct, stmt)
+			if _, err = r.db.ExecContext(ctx, stmt); err != nil {
+				return r.translateError(err)
+			}
+		}
+	}
+	return nil
+}
+
+// createTableStatements creates the statements that creates the table with its indexes for the 'mStruct'.
+func (r *Repository) createTableStatements(mStruct *mapping.ModelStruct) ([]string, error) {
+	b := newBuilder(r.Dialect)
+	b.write("CREATE TABLE IF NOT EXISTS ", TableName(r.Dialect, mStruct), " (")
+	for i, field := range databaseFields(mStruct.Fields()) {
+		if i != 0 {
+			b.write(", ")
+		}
+		b.write(b.column(field), " ")
+		if field.IsPrimary() {
+			definition, err := r.Dialect.PrimaryKeyDefinition(field)
+			if err != nil {
+				return nil, err
+			}
+			b.write(definition)
+			continue
+		}
+		columnType := field.DatabaseType
+		if columnType == "" {
+			var err error
+			if columnType, err = r.Dialect.ColumnType(field); err != nil {
+				return nil, err
+			}
+		}
+		b.write(columnType)
+		if field.DatabaseNotNull() {
+			b.write(" NOT NULL")
+		}
+		if field.DatabaseUnique() {
+			b.write(" UNIQUE")
+		}
+	}
+	b.write(")")
+	statements := []string{b.String()}
+
+	for _, index := range mStruct.DatabaseIndexes() {
+		if len(index.Fields) == 0 {
+			return nil, errors.WrapDetf(query.ErrInvalidInput, "database index: '%s' for model: '%s' has no fields", index.Name, mStruct)
+		}
+		columns := make([]string, len(index.Fields))
+		for i, field := range index.Fields {
+			columns[i] = r.Dialect.QuoteIdentifier(field.DatabaseName)
+		}
+		statements = append(statements, r.Dialect.CreateIndex(mStruct.DatabaseSchemaName, mStruct.DatabaseName, index, columns))
+	}
+	return statements, nil
+}
@@ -0,0 +1,187 @@
+// Package sqlrepo contains the database/sql based repository implementation. The SQL differences between
+// the databases are defined by the Dialect interface implementations - i.e. the SQLite dialect in the
+// 'sqlrepo/sqlite' package.
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/repository"
+)
+
+// Compile time check for the repository interfaces.
+var (
+	_ repository.Repository    = &Repository{}
+	_ repository.Dialer        = &Repository{}
+	_ repository.Closer        = &Repository{}
+	_ repository.Transactioner = &Repository{}
+	_ repository.Savepointer   = &Repository{}
+	_ repository.Exister       = &Repository{}
+	_ repository.Upserter      = &Repository{}
+	_ repository.Migrator      = &Repository{}
+	_ repository.HealthChecker = &Repository{}
+)
+
+// Repository is the database/sql based repository. The queries are built using the repository Dialect.
+type Repository struct {
+	Dialect Dialect
+	Options *repository.Options
+
+	db           *sql.DB
+	lock         sync.RWMutex
+	transactions map[uuid.UUID]*sql.Tx
+}
+
+// New creates new SQL repository with provided 'dialect'. The connection is established on Dial.
+func New(dialect Dialect, options ...repository.Option) *Repository {
+	o := &repository.Options{}
+	for _, option := range options {
+		option(o)
+	}
+	return &Repository{
+		Dialect:      dialect,
+		Options:      o,
+		transactions: map[uuid.UUID]*sql.Tx{},
+	}
+}
+
+// NewWithDB creates new SQL repository with provided 'dialect' that uses already opened 'db' connection.
+func NewWithDB(dialect Dialect, db *sql.DB, options ...repository.Option) *Repository {
+	r := New(dialect, options...)
+	r.db = db
+	return r
+}
+
+// ID implements repository.Repository interface.
+func (r *Repository) ID() string {
+	id := r.Dialect.Name()
+	switch {
+	case r.Options.URI != "":
+		id += ":" + r.Options.URI
+	case r.Options.Database != "":
+		id += ":" + r.Options.Database
+	}
+	return id
+}
+
+// DB gets the repository database connection.
+func (r *Repository) DB() *sql.DB {
+	return r.db
+}
+
+// Dial implements repository.Dialer interface. It opens the database connection and verifies it.
+func (r *Repository) Dial(ctx context.Context) error {
+	if r.db == nil {
+		dsn, err := r.Dialect.DataSourceName(r.Options)
+		if err != nil {
+			return err
+		}
+		db, err := sql.Open(r.Dialect.DriverName(), dsn)
+		if err != nil {
+			return errors.WrapDetf(repository.ErrConnection, "opening '%s' connection failed: %v", r.Dialect.Name(), err)
+		}
+		r.db = db
+	}
+	if err := r.db.PingContext(ctx); err != nil {
+		return errors.WrapDetf(repository.ErrConnection, "connecting to the '%s' database failed: %v", r.Dialect.Name(), err)
+	}
+	log.Debugf("[%s] connected", r.ID())
+	return nil
+}
+
+// Close implements repository.Closer interface.
+func (r *Repository) Close(_ context.Context) error {
+	if r.db == nil {
+		return nil
+	}
+	if err := r.db.Close(); err != nil {
+		return errors.WrapDetf(repository.ErrConnection, "closing '%s' connection failed: %v", r.Dialect.Name(), err)
+	}
+	return nil
+}
+
+// HealthCheck implements repository.HealthChecker interface.
+func (r *Repository) HealthCheck(ctx context.Context) (*repository.HealthResponse, error) {
+	if r.db == nil {
+		return &repository.HealthResponse{Status: repository.StatusFail, Output: "not connected"}, nil
+	}
+	if err := r.db.PingContext(ctx); err != nil {
+		return &repository.HealthResponse{Status: repository.StatusFail, Output: err.Error()}, nil
+	}
+	return &repository.HealthResponse{Status: repository.StatusPass}, nil
+}
+
+// executor is the common interface for the sql.DB and sql.Tx.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// executor gets the query executor for provided scope - if the scope is within a transaction it gets the sql.Tx.
+func (r *Repository) executor(s *query.Scope) (executor, error) {
+	if s.Transaction != nil {
+		return r.transaction(s.Transaction)
+	}
+	if r.db == nil {
+		return nil, errors.WrapDetf(ErrNotConnected, "repository: '%s' is not connected", r.ID())
+	}
+	return r.db, nil
+}
+
+func (r *Repository) exec(ctx context.Context, s *query.Scope, b *builder) (sql.Result, error) {
+	ex, err := r.executor(s)
+	if err != nil {
+		return nil, err
+	}
+	stmt := b.String()
+	if log.CurrentLevel().IsAllowed(log.LevelDebug3) {
+		log.Debug3f("[%s] %s %v", r.ID(), stmt, b.args)
+	}
+	result, err := ex.ExecContext(ctx, stmt, b.args...)
+	if err != nil {
+		return nil, r.translateError(err)
+	}
+	return result, nil
+}
+
+func (r *Repository) queryRows(ctx context.Context, s *query.Scope, b *builder) (*sql.Rows, error) {
+	ex, err := r.executor(s)
+	if err != nil {
+		return nil, err
+	}
+	stmt := b.String()
+	if log.CurrentLevel().IsAllowed(log.LevelDebug3) {
+		log.Debug3f("[%s] %s %v", r.ID(), stmt, b.args)
+	}
+	rows, err := ex.QueryContext(ctx, stmt, b.args...)
+	if err != nil {
+		return nil, r.translateError(err)
+	}
+	return rows, nil
+}
+
+// translateError translates the database error using the dialect. Unknown errors are wrapped with the ErrSQL class.
+func (r *Repository) translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if translated := r.Dialect.TranslateError(err); translated != err {
+		return translated
+	}
+	switch err {
+	case context.Canceled, context.DeadlineExceeded:
+		return err
+	case sql.ErrTxDone:
+		return errors.WrapDet(query.ErrTxDone, err.Error())
+	case sql.ErrConnDone:
+		return errors.WrapDet(repository.ErrConnection, err.Error())
+	}
+	return errors.WrapDet(ErrSQL, err.Error())
+}
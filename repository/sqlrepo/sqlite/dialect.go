@@ -0,0 +1,218 @@
+// Package sqlite contains the SQLite dialect for the database/sql based repository. It uses the
+// 'github.com/mattn/go-sqlite3' driver which requires cgo.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/repository"
+	"github.com/neuronlabs/neuron/repository/sqlrepo"
+)
+
+// DialectName is the name of the SQLite dialect.
+const DialectName = "sqlite"
+
+// Compile time check for the sqlrepo.Dialect interface.
+var _ sqlrepo.Dialect = Dialect{}
+
+// Dialect is the SQLite implementation of the sqlrepo.Dialect.
+type Dialect struct{}
+
+// New creates new SQL repository that uses the SQLite dialect. The database file is taken from the options URI
+// or the Database name.
+func New(options ...repository.Option) *sqlrepo.Repository {
+	return sqlrepo.New(Dialect{}, options...)
+}
+
+// Name implements sqlrepo.Dialect interface.
+func (Dialect) Name() string {
+	return DialectName
+}
+
+// DriverName implements sqlrepo.Dialect interface.
+func (Dialect) DriverName() string {
+	return "sqlite3"
+}
+
+// DataSourceName implements sqlrepo.Dialect interface. The options URI is used as it is, otherwise the Database
+// is used as the database file name.
+func (Dialect) DataSourceName(o *repository.Options) (string, error) {
+	switch {
+	case o.URI != "":
+		return o.URI, nil
+	case o.Database != "":
+		return o.Database, nil
+	default:
+		return "", errors.WrapDet(repository.ErrRepository, "no sqlite database file name or uri provided")
+	}
+}
+
+// QuoteIdentifier implements sqlrepo.Dialect interface.
+func (Dialect) QuoteIdentifier(identifier string) string {
+	return sqlrepo.QuoteIdentifier(identifier, '"')
+}
+
+// Placeholder implements sqlrepo.Dialect interface.
+func (Dialect) Placeholder(int) string {
+	return "?"
+}
+
+// LimitOffset implements sqlrepo.Dialect interface. SQLite requires the LIMIT clause for the OFFSET, thus
+// the offset without limit is written as 'LIMIT -1 OFFSET n'.
+func (Dialect) LimitOffset(limit, offset int64) string {
+	switch {
+	case limit == 0 && offset == 0:
+		return ""
+	case offset == 0:
+		return fmt.Sprintf("LIMIT %d", limit)
+	case limit == 0:
+		return fmt.Sprintf("LIMIT -1 OFFSET %d", offset)
+	default:
+		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+	}
+}
+
+// Returning implements sqlrepo.Dialect interface. The SQLite driver supports LastInsertId, thus the
+// clause is not used.
+func (Dialect) Returning(string) string {
+	return ""
+}
+
+// OnConflictUpdate implements sqlrepo.Dialect interface.
+func (Dialect) OnConflictUpdate(conflict, update []string) string {
+	sb := strings.Builder{}
+	sb.WriteString("ON CONFLICT (")
+	sb.WriteString(strings.Join(conflict, ", "))
+	if len(update) == 0 {
+		sb.WriteString(") DO NOTHING")
+		return sb.String()
+	}
+	sb.WriteString(") DO UPDATE SET ")
+	for i, column := range update {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(column)
+		sb.WriteString(" = excluded.")
+		sb.WriteString(column)
+	}
+	return sb.String()
+}
+
+// ColumnType implements sqlrepo.Dialect interface.
+func (Dialect) ColumnType(field *mapping.StructField) (string, error) {
+	if field.IsTime() || field.IsTimePointer() {
+		return "DATETIME", nil
+	}
+	if sqlrepo.IsJSONField(field) {
+		return "TEXT", nil
+	}
+	t := field.GetDereferencedType()
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER", nil
+	case reflect.Float32, reflect.Float64:
+		return "REAL", nil
+	case reflect.String:
+		return "TEXT", nil
+	case reflect.Slice, reflect.Array:
+		return "BLOB", nil
+	}
+	return "", errors.WrapDetf(mapping.ErrInvalidModelField, "unsupported sqlite field: '%s' type: '%s'", field, t)
+}
+
+// PrimaryKeyDefinition implements sqlrepo.Dialect interface. The integer primary keys are defined
+// with the AUTOINCREMENT.
+func (d Dialect) PrimaryKeyDefinition(field *mapping.StructField) (string, error) {
+	switch field.GetDereferencedType().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER PRIMARY KEY AUTOINCREMENT", nil
+	}
+	columnType := field.DatabaseType
+	if columnType == "" {
+		var err error
+		if columnType, err = d.ColumnType(field); err != nil {
+			return "", err
+		}
+	}
+	return columnType + " PRIMARY KEY NOT NULL", nil
+}
+
+// CreateIndex implements sqlrepo.Dialect interface. SQLite indexes are always created in the schema of the table.
+func (d Dialect) CreateIndex(schema, table string, index *mapping.DatabaseIndex, columns []string) string {
+	sb := strings.Builder{}
+	sb.WriteString("CREATE ")
+	if index.Unique {
+		sb.WriteString("UNIQUE ")
+	}
+	sb.WriteString("INDEX IF NOT EXISTS ")
+	if schema != "" {
+		sb.WriteString(d.QuoteIdentifier(schema))
+		sb.WriteRune('.')
+	}
+	sb.WriteString(d.QuoteIdentifier(index.Name))
+	sb.WriteString(" ON ")
+	sb.WriteString(d.QuoteIdentifier(table))
+	sb.WriteString(" (")
+	sb.WriteString(strings.Join(columns, ", "))
+	sb.WriteRune(')')
+	return sb.String()
+}
+
+// IsolationLevel implements sqlrepo.Dialect interface. SQLite transactions are always serializable, thus
+// all levels up to the serializable are accepted.
+func (Dialect) IsolationLevel(level query.IsolationLevel) (sql.IsolationLevel, error) {
+	if level > query.LevelSerializable {
+		return 0, errors.WrapDetf(query.ErrTxInvalid, "unsupported sqlite isolation level: '%s'", level)
+	}
+	return sql.LevelDefault, nil
+}
+
+// Savepoint implements sqlrepo.Dialect interface.
+func (d Dialect) Savepoint(name string) string {
+	return "SAVEPOINT " + d.QuoteIdentifier(name)
+}
+
+// RollbackSavepoint implements sqlrepo.Dialect interface.
+func (d Dialect) RollbackSavepoint(name string) string {
+	return "ROLLBACK TO SAVEPOINT " + d.QuoteIdentifier(name)
+}
+
+// TranslateError implements sqlrepo.Dialect interface.
+func (Dialect) TranslateError(err error) error {
+	sqliteErr, ok := err.(sqlite3.Error)
+	if !ok {
+		return err
+	}
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+		return errors.WrapDet(query.ErrViolationUnique, sqliteErr.Error())
+	case sqlite3.ErrConstraintNotNull:
+		return errors.WrapDet(query.ErrViolationNotNull, sqliteErr.Error())
+	case sqlite3.ErrConstraintForeignKey:
+		return errors.WrapDet(query.ErrViolationForeignKey, sqliteErr.Error())
+	case sqlite3.ErrConstraintCheck:
+		return errors.WrapDet(query.ErrViolationCheck, sqliteErr.Error())
+	}
+	switch sqliteErr.Code {
+	case sqlite3.ErrConstraint:
+		return errors.WrapDet(query.ErrViolationIntegrityConstraint, sqliteErr.Error())
+	case sqlite3.ErrBusy, sqlite3.ErrLocked:
+		return errors.WrapDet(query.ErrTxState, sqliteErr.Error())
+	case sqlite3.ErrReadonly:
+		return errors.WrapDet(query.ErrTxInvalid, sqliteErr.Error())
+	}
+	return err
+}
@@ -0,0 +1,291 @@
+package sqlite
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/repository"
+	"github.com/neuronlabs/neuron/repository/sqlrepo"
+)
+
+// testRepository creates the connected repository on the temporary database file with migrated Blog model.
+// The returned function closes the repository and removes the database.
+func testRepository(t *testing.T) (*sqlrepo.Repository, *mapping.ModelMap, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "neuron-sqlite")
+	require.NoError(t, err)
+
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(testmodels.Neuron_Models...))
+
+	r := New(repository.WithURI("file:" + filepath.Join(dir, "test.db") + "?_busy_timeout=5000&_txlock=immediate"))
+	ctx := context.Background()
+	cleanup := func() {
+		r.Close(ctx)
+		os.RemoveAll(dir)
+	}
+	if err = r.Dial(ctx); err == nil {
+		err = r.MigrateModels(ctx, m.MustModelStruct(&testmodels.Blog{}))
+	}
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return r, m, cleanup
+}
+
+func insertBlogs(t *testing.T, r *sqlrepo.Repository, mStruct *mapping.ModelStruct, blogs ...*testmodels.Blog) {
+	t.Helper()
+	models := make([]mapping.Model, len(blogs))
+	for i := range blogs {
+		models[i] = blogs[i]
+	}
+	require.NoError(t, r.Insert(context.Background(), query.NewScope(mStruct, models...)))
+}
+
+func findBlogs(t *testing.T, r *sqlrepo.Repository, s *query.Scope) []*testmodels.Blog {
+	t.Helper()
+	require.NoError(t, r.Find(context.Background(), s))
+	blogs := make([]*testmodels.Blog, len(s.Models))
+	for i, model := range s.Models {
+		blogs[i] = model.(*testmodels.Blog)
+	}
+	return blogs
+}
+
+func blogIDs(blogs []*testmodels.Blog) []int {
+	ids := make([]int, len(blogs))
+	for i, blog := range blogs {
+		ids[i] = blog.ID
+	}
+	return ids
+}
+
+func TestFind(t *testing.T) {
+	r, m, cleanup := testRepository(t)
+	defer cleanup()
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+
+	insertBlogs(t, r, mStruct,
+		&testmodels.Blog{Title: "first", ViewCount: 10},
+		&testmodels.Blog{Title: "second", ViewCount: 20},
+		&testmodels.Blog{Title: "third", ViewCount: 30},
+		&testmodels.Blog{Title: "fourth_%", ViewCount: 20},
+	)
+
+	title := mStruct.MustFieldByName("Title")
+	viewCount := mStruct.MustFieldByName("ViewCount")
+
+	t.Run("Filters", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			filters  []filter.Filter
+			expected []int
+		}{
+			{"Equal", []filter.Filter{filter.New(viewCount, filter.OpEqual, 20)}, []int{2, 4}},
+			{"In", []filter.Filter{filter.New(mStruct.Primary(), filter.OpIn, 1, 3)}, []int{1, 3}},
+			{"NotEqual", []filter.Filter{filter.New(viewCount, filter.OpNotEqual, 20)}, []int{1, 3}},
+			{"NotIn", []filter.Filter{filter.New(title, filter.OpNotIn, "first", "second")}, []int{3, 4}},
+			{"GreaterThan", []filter.Filter{filter.New(viewCount, filter.OpGreaterThan, 20)}, []int{3}},
+			{"LessEqual", []filter.Filter{filter.New(viewCount, filter.OpLessEqual, 20)}, []int{1, 2, 4}},
+			{"Contains", []filter.Filter{filter.New(title, filter.OpContains, "ir")}, []int{1, 3}},
+			{"ContainsEscaped", []filter.Filter{filter.New(title, filter.OpContains, "_%")}, []int{4}},
+			{"StartsWith", []filter.Filter{filter.New(title, filter.OpStartsWith, "f")}, []int{1, 4}},
+			{"EndsWith", []filter.Filter{filter.New(title, filter.OpEndsWith, "nd")}, []int{2}},
+			{"IsNull", []filter.Filter{filter.New(title, filter.OpIsNull)}, nil},
+			{"Or", []filter.Filter{filter.Or(filter.New(title, filter.OpEqual, "first"), filter.New(viewCount, filter.OpEqual, 30))}, []int{1, 3}},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				s := query.NewScope(mStruct)
+				s.Filters = tc.filters
+				s.SortingOrder = []query.Sort{query.SortField{StructField: mStruct.Primary()}}
+				blogs := findBlogs(t, r, s)
+				if tc.expected == nil {
+					assert.Empty(t, blogs)
+					return
+				}
+				assert.Equal(t, tc.expected, blogIDs(blogs))
+			})
+		}
+	})
+
+	t.Run("SortPagination", func(t *testing.T) {
+		s := query.NewScope(mStruct)
+		s.SortingOrder = []query.Sort{
+			query.SortField{StructField: viewCount, SortOrder: query.DescendingOrder},
+			query.SortField{StructField: title, SortOrder: query.AscendingOrder},
+		}
+		assert.Equal(t, []int{3, 4, 2, 1}, blogIDs(findBlogs(t, r, s)))
+
+		s.Offset(1)
+		assert.Equal(t, []int{4, 2, 1}, blogIDs(findBlogs(t, r, s)))
+		s.Limit(2)
+		assert.Equal(t, []int{4, 2}, blogIDs(findBlogs(t, r, s)))
+	})
+
+	t.Run("CountExists", func(t *testing.T) {
+		s := query.NewScope(mStruct)
+		s.Filters = filter.Filters{filter.New(viewCount, filter.OpEqual, 20)}
+		count, err := r.Count(context.Background(), s)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		exists, err := r.Exists(context.Background(), s)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func TestInsertUpdateDelete(t *testing.T) {
+	r, m, cleanup := testRepository(t)
+	defer cleanup()
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	title := mStruct.MustFieldByName("Title")
+	viewCount := mStruct.MustFieldByName("ViewCount")
+	ctx := context.Background()
+
+	blog := &testmodels.Blog{Title: "first", ViewCount: 10}
+	insertBlogs(t, r, mStruct, blog, &testmodels.Blog{Title: "second", ViewCount: 20})
+	assert.Equal(t, 1, blog.ID)
+
+	err := r.Insert(ctx, query.NewScope(mStruct, &testmodels.Blog{ID: 1}))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, query.ErrViolationUnique))
+
+	// Update filtered.
+	s := query.NewScope(mStruct, &testmodels.Blog{ViewCount: 100})
+	s.FieldSets = []mapping.FieldSet{{viewCount}}
+	s.Filters = filter.Filters{filter.New(viewCount, filter.OpGreaterThan, 15)}
+	affected, err := r.Update(ctx, s)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	// Update models.
+	s = query.NewScope(mStruct, &testmodels.Blog{ID: 1, Title: "updated"}, &testmodels.Blog{ID: 5, Title: "not existing"})
+	s.FieldSets = []mapping.FieldSet{{title}}
+	affected, err = r.UpdateModels(ctx, s)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	// Upsert.
+	s = query.NewScope(mStruct, &testmodels.Blog{ID: 2, Title: "upserted"}, &testmodels.Blog{ID: 4, Title: "new"})
+	s.FieldSets = []mapping.FieldSet{{mStruct.Primary(), title}}
+	require.NoError(t, r.Upsert(ctx, s))
+
+	blogs := findBlogs(t, r, query.NewScope(mStruct))
+	require.Len(t, blogs, 3)
+	assert.Equal(t, "updated", blogs[0].Title)
+	assert.Equal(t, 10, blogs[0].ViewCount)
+	assert.Equal(t, "upserted", blogs[1].Title)
+	assert.Equal(t, 100, blogs[1].ViewCount)
+	assert.Equal(t, "new", blogs[2].Title)
+
+	// Delete.
+	s = query.NewScope(mStruct)
+	s.Filters = filter.Filters{filter.New(viewCount, filter.OpEqual, 100)}
+	affected, err = r.Delete(ctx, s)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+	assert.Equal(t, []int{1, 4}, blogIDs(findBlogs(t, r, query.NewScope(mStruct))))
+}
+
+func newTx(isolation query.IsolationLevel) *query.Transaction {
+	return &query.Transaction{ID: uuid.New(), Ctx: context.Background(), Options: &query.TxOptions{Isolation: isolation}}
+}
+
+func TestTransactions(t *testing.T) {
+	r, m, cleanup := testRepository(t)
+	defer cleanup()
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	ctx := context.Background()
+
+	t.Run("CommitRollback", func(t *testing.T) {
+		tx := newTx(query.LevelSerializable)
+		require.NoError(t, r.Begin(ctx, tx))
+		s := query.NewScope(mStruct, &testmodels.Blog{Title: "committed"})
+		s.Transaction = tx
+		require.NoError(t, r.Insert(ctx, s))
+		require.NoError(t, r.Commit(ctx, tx))
+
+		err := r.Commit(ctx, tx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, query.ErrTxInvalid))
+
+		tx = newTx(query.LevelDefault)
+		require.NoError(t, r.Begin(ctx, tx))
+		s = query.NewScope(mStruct, &testmodels.Blog{Title: "rolled back"})
+		s.Transaction = tx
+		require.NoError(t, r.Insert(ctx, s))
+		require.NoError(t, r.Rollback(ctx, tx))
+
+		blogs := findBlogs(t, r, query.NewScope(mStruct))
+		require.Len(t, blogs, 1)
+		assert.Equal(t, "committed", blogs[0].Title)
+	})
+
+	t.Run("Savepoint", func(t *testing.T) {
+		tx := newTx(query.LevelDefault)
+		require.NoError(t, r.Begin(ctx, tx))
+		require.NoError(t, r.Savepoint(ctx, tx, "sp"))
+
+		s := query.NewScope(mStruct, &testmodels.Blog{Title: "savepoint"})
+		s.Transaction = tx
+		require.NoError(t, r.Insert(ctx, s))
+		require.NoError(t, r.RollbackSavepoint(ctx, tx, "sp"))
+		require.NoError(t, r.Commit(ctx, tx))
+
+		assert.Len(t, findBlogs(t, r, query.NewScope(mStruct)), 1)
+	})
+
+	t.Run("Linearizable", func(t *testing.T) {
+		err := r.Begin(ctx, newTx(query.LevelLinearizable))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, query.ErrTxInvalid))
+	})
+}
+
+func TestDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neuron-sqlite")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(testmodels.Neuron_Models...))
+	r := New(repository.WithDatabase(filepath.Join(dir, "test.db")))
+	db, err := database.New(database.WithDefaultRepository(r), database.WithModelMap(m), database.WithMigrateModels(&testmodels.Blog{}))
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, db.Dial(ctx))
+	defer r.Close(ctx)
+
+	mStruct := m.MustModelStruct(&testmodels.Blog{})
+	err = database.RunInTransaction(ctx, db, nil, func(db database.DB) error {
+		return db.Insert(ctx, mStruct, &testmodels.Blog{Title: "first"}, &testmodels.Blog{Title: "second"})
+	})
+	require.NoError(t, err)
+
+	models, err := db.Query(mStruct).Where("Title = ?", "second").Find()
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	blog := models[0].(*testmodels.Blog)
+	assert.Equal(t, 2, blog.ID)
+	assert.False(t, blog.CreatedAt.IsZero())
+
+	affected, err := db.Delete(ctx, mStruct, blog)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+}
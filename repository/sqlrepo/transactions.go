@@ -0,0 +1,114 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/query"
+)
+
+// Begin implements repository.Transactioner interface. The transaction isolation level is mapped into database/sql
+// isolation level by the repository Dialect.
+func (r *Repository) Begin(ctx context.Context, tx *query.Transaction) error {
+	if tx == nil {
+		return errors.WrapDet(query.ErrTxInvalid, "provided nil transaction")
+	}
+	if r.db == nil {
+		return errors.WrapDetf(ErrNotConnected, "repository: '%s' is not connected", r.ID())
+	}
+	options := &sql.TxOptions{}
+	if tx.Options != nil {
+		isolation, err := r.Dialect.IsolationLevel(tx.Options.Isolation)
+		if err != nil {
+			return err
+		}
+		options.Isolation = isolation
+		options.ReadOnly = tx.Options.ReadOnly
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.transactions[tx.ID]; ok {
+		return errors.WrapDetf(query.ErrTxState, "transaction: '%s' already began", tx.ID)
+	}
+	sqlTx, err := r.db.BeginTx(ctx, options)
+	if err != nil {
+		return r.translateError(err)
+	}
+	r.transactions[tx.ID] = sqlTx
+	log.Debug3f("[%s] begin transaction: '%s'", r.ID(), tx.ID)
+	return nil
+}
+
+// Commit implements repository.Transactioner interface.
+func (r *Repository) Commit(_ context.Context, tx *query.Transaction) error {
+	sqlTx, err := r.finishTransaction(tx)
+	if err != nil {
+		return err
+	}
+	log.Debug3f("[%s] commit transaction: '%s'", r.ID(), tx.ID)
+	return r.translateError(sqlTx.Commit())
+}
+
+// Rollback implements repository.Transactioner interface.
+func (r *Repository) Rollback(_ context.Context, tx *query.Transaction) error {
+	sqlTx, err := r.finishTransaction(tx)
+	if err != nil {
+		return err
+	}
+	log.Debug3f("[%s] rollback transaction: '%s'", r.ID(), tx.ID)
+	return r.translateError(sqlTx.Rollback())
+}
+
+// Savepoint implements repository.Savepointer interface.
+func (r *Repository) Savepoint(ctx context.Context, tx *query.Transaction, name string) error {
+	sqlTx, err := r.transaction(tx)
+	if err != nil {
+		return err
+	}
+	if _, err = sqlTx.ExecContext(ctx, r.Dialect.Savepoint(name)); err != nil {
+		return r.translateError(err)
+	}
+	return nil
+}
+
+// RollbackSavepoint implements repository.Savepointer interface.
+func (r *Repository) RollbackSavepoint(ctx context.Context, tx *query.Transaction, name string) error {
+	sqlTx, err := r.transaction(tx)
+	if err != nil {
+		return err
+	}
+	if _, err = sqlTx.ExecContext(ctx, r.Dialect.RollbackSavepoint(name)); err != nil {
+		return r.translateError(err)
+	}
+	return nil
+}
+
+func (r *Repository) transaction(tx *query.Transaction) (*sql.Tx, error) {
+	if tx == nil {
+		return nil, errors.WrapDet(query.ErrTxInvalid, "provided nil transaction")
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	sqlTx, ok := r.transactions[tx.ID]
+	if !ok {
+		return nil, errors.WrapDetf(query.ErrTxInvalid, "transaction: '%s' not found in the repository: '%s'", tx.ID, r.ID())
+	}
+	return sqlTx, nil
+}
+
+func (r *Repository) finishTransaction(tx *query.Transaction) (*sql.Tx, error) {
+	if tx == nil {
+		return nil, errors.WrapDet(query.ErrTxInvalid, "provided nil transaction")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	sqlTx, ok := r.transactions[tx.ID]
+	if !ok {
+		return nil, errors.WrapDetf(query.ErrTxInvalid, "transaction: '%s' not found in the repository: '%s'", tx.ID, r.ID())
+	}
+	delete(r.transactions, tx.ID)
+	return sqlTx, nil
+}
@@ -0,0 +1,110 @@
+package sqlrepo
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// Update implements repository.Repository interface. It updates all the rows that matches the scope filters
+// with the field set values of the first scope's model.
+func (r *Repository) Update(ctx context.Context, s *query.Scope) (int64, error) {
+	if len(s.Models) != 1 {
+		return 0, errors.WrapDetf(query.ErrInvalidModels, "filtered update requires exactly one model, provided: %d", len(s.Models))
+	}
+	fieldSet, err := scopeFieldSet(s, 0)
+	if err != nil {
+		return 0, err
+	}
+	if fieldSet.Contains(s.ModelStruct.Primary()) {
+		return 0, errors.WrapDet(query.ErrInvalidField, "cannot update the primary key of filtered models")
+	}
+	return r.updateModel(ctx, s, s.Models[0], fieldSet, s.Filters)
+}
+
+// UpdateModels implements repository.Repository interface. It updates each scope's model by its primary key value.
+// If the scope contains filters, only the rows that matches them are updated.
+func (r *Repository) UpdateModels(ctx context.Context, s *query.Scope) (int64, error) {
+	var affected int64
+	for i, model := range s.Models {
+		if model.IsPrimaryKeyZero() {
+			return affected, errors.WrapDetf(query.ErrInvalidModels, "model at index: %d have zero value primary key", i)
+		}
+		fieldSet, err := scopeFieldSet(s, i)
+		if err != nil {
+			return affected, err
+		}
+		filters := make(filter.Filters, 0, len(s.Filters)+1)
+		filters = append(filters, filter.New(s.ModelStruct.Primary(), filter.OpEqual, model.GetPrimaryKeyValue()))
+		filters = append(filters, s.Filters...)
+		modelAffected, err := r.updateModel(ctx, s, model, fieldSet, filters)
+		if err != nil {
+			return affected, err
+		}
+		affected += modelAffected
+	}
+	return affected, nil
+}
+
+func (r *Repository) updateModel(ctx context.Context, s *query.Scope, model mapping.Model, fieldSet mapping.FieldSet, filters filter.Filters) (int64, error) {
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return 0, errModelNotFielder(s.ModelStruct)
+	}
+	columns := make(mapping.FieldSet, 0, len(fieldSet))
+	for _, field := range databaseFields(fieldSet) {
+		if !field.IsPrimary() {
+			columns = append(columns, field)
+		}
+	}
+	if len(columns) == 0 {
+		return 0, errors.WrapDetf(query.ErrNoFieldsInFieldSet, "nothing to update for the model: '%s'", s.ModelStruct)
+	}
+
+	b := newBuilder(r.Dialect)
+	b.write("UPDATE ", TableName(r.Dialect, s.ModelStruct), " SET ")
+	for i, field := range columns {
+		if i != 0 {
+			b.write(", ")
+		}
+		value, err := modelArgument(fielder, field)
+		if err != nil {
+			return 0, err
+		}
+		b.write(b.column(field), " = ")
+		b.arg(value)
+	}
+	if err := b.where(filters); err != nil {
+		return 0, err
+	}
+	result, err := r.exec(ctx, s, b)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, r.translateError(err)
+	}
+	return affected, nil
+}
+
+// Delete implements repository.Repository interface. It deletes all the rows that matches the scope filters.
+func (r *Repository) Delete(ctx context.Context, s *query.Scope) (int64, error) {
+	b := newBuilder(r.Dialect)
+	b.write("DELETE FROM ", TableName(r.Dialect, s.ModelStruct))
+	if err := b.where(s.Filters); err != nil {
+		return 0, err
+	}
+	result, err := r.exec(ctx, s, b)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, r.translateError(err)
+	}
+	return affected, nil
+}
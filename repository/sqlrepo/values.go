@@ -0,0 +1,107 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// IsJSONField checks if the field value is stored as a JSON encoded text. This applies to nested structures, maps
+// and slices other than []byte.
+func IsJSONField(field *mapping.StructField) bool {
+	t := field.GetDereferencedType()
+	switch t.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array:
+		return t.Elem().Kind() != reflect.Uint8
+	case reflect.Struct:
+		return t != timeType && !reflect.PtrTo(t).Implements(scannerType)
+	}
+	return false
+}
+
+// fieldArgument gets the query argument value for the 'field' 'value'.
+func fieldArgument(field *mapping.StructField, value interface{}) (interface{}, error) {
+	if value == nil || !IsJSONField(field) {
+		return value, nil
+	}
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.WrapDetf(mapping.ErrFieldValue, "marshaling field: '%s' value failed: %v", field, err)
+	}
+	return string(data), nil
+}
+
+// modelArgument gets the query argument of the 'model' 'field'.
+func modelArgument(fielder mapping.Fielder, field *mapping.StructField) (interface{}, error) {
+	value, err := fielder.GetFieldValue(field)
+	if err != nil {
+		return nil, err
+	}
+	return fieldArgument(field, value)
+}
+
+// fieldDestination is the scan destination for a single model field. Non pointer fields are scanned using
+// the pointer to pointer value, so that the NULL values sets the field zero value.
+type fieldDestination struct {
+	field   *mapping.StructField
+	address reflect.Value
+	dest    interface{}
+}
+
+func newFieldDestination(fielder mapping.Fielder, field *mapping.StructField) (*fieldDestination, error) {
+	address, err := fielder.GetFieldsAddress(field)
+	if err != nil {
+		return nil, err
+	}
+	fd := &fieldDestination{field: field, address: reflect.ValueOf(address)}
+	switch {
+	case IsJSONField(field):
+		fd.dest = new([]byte)
+	case fd.address.Elem().Kind() == reflect.Ptr:
+		// The database/sql sets the pointer fields to nil on NULL values.
+		fd.dest = address
+	default:
+		fd.dest = reflect.New(reflect.PtrTo(fd.address.Elem().Type())).Interface()
+	}
+	return fd, nil
+}
+
+// set sets the scanned value into the model field.
+func (f *fieldDestination) set() error {
+	if IsJSONField(f.field) {
+		data := *(f.dest.(*[]byte))
+		if data == nil {
+			f.address.Elem().Set(reflect.Zero(f.address.Elem().Type()))
+			return nil
+		}
+		if err := json.Unmarshal(data, f.address.Interface()); err != nil {
+			return errors.WrapDetf(mapping.ErrFieldValue, "unmarshaling field: '%s' value failed: %v", f.field, err)
+		}
+		return nil
+	}
+	if f.address.Elem().Kind() == reflect.Ptr {
+		return nil
+	}
+	ptr := reflect.ValueOf(f.dest).Elem()
+	if ptr.IsNil() {
+		f.address.Elem().Set(reflect.Zero(f.address.Elem().Type()))
+		return nil
+	}
+	f.address.Elem().Set(ptr.Elem())
+	return nil
+}
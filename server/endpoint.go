@@ -14,4 +14,9 @@ type Endpoint struct {
 	ModelStruct *mapping.ModelStruct
 	Relation    *mapping.StructField
 	Roles       []auth.Role
+	// Scopes are the authorization scopes required by the endpoint. The scope restricted credentials, i.e. the API
+	// keys or the tokens with the non empty scope claim, need to be granted all of them. If the scopes are not
+	// defined, the scope restricted credentials need the '<collection>:read' scope for the reading endpoints and
+	// the '<collection>:write' scope for the others. The credentials with an empty scope have no scope restrictions.
+	Scopes []string
 }
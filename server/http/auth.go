@@ -0,0 +1,152 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/server"
+)
+
+const (
+	// APIKeyHeader is the request header that contains the API key.
+	APIKeyHeader = "X-API-Key"
+	// bearerPrefix is the authorization header prefix of the bearer tokens.
+	bearerPrefix = "Bearer "
+)
+
+// authenticate is the middleware that authenticates the request with the 'X-API-Key' header key or the
+// 'Authorization: Bearer' header token. The account of the key or the access token claims is set in the request
+// context. The API key or the bearer token claims are also stored in the context. The requests without the
+// credentials are passed as anonymous.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		account, claims, err := s.requestAccount(r)
+		if err != nil {
+			req := &request{Request: r, codec: s.Options.Codecs[0]}
+			if c, err := s.responseCodec(r); err == nil {
+				req.codec = c
+			}
+			if errors.Is(err, auth.ErrToken) || errors.Is(err, auth.ErrAuthorizationHeader) {
+				rw.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			}
+			s.writeError(rw, req, err)
+			return
+		}
+		if account == nil {
+			next.ServeHTTP(rw, r)
+			return
+		}
+		ctx := auth.CtxWithAccount(r.Context(), account)
+		if claims != nil {
+			ctx = auth.CtxWithClaims(ctx, claims)
+		}
+		next.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
+
+// requestAccount gets the account and claims of the request API key or the bearer token. If the request has no
+// credentials the account is nil.
+func (s *Server) requestAccount(r *http.Request) (auth.Account, auth.Claims, error) {
+	if key := r.Header.Get(APIKeyHeader); key != "" && s.Options.APIKeyAuthenticator != nil {
+		return s.Options.APIKeyAuthenticator.AuthenticateAPIKey(r.Context(), key)
+	}
+	if header := r.Header.Get("Authorization"); header != "" && s.Options.Tokener != nil {
+		return s.bearerAccount(r, header)
+	}
+	return nil, nil, nil
+}
+
+// bearerAccount inspects the bearer token from the authorization 'header' and gets its claims with their account.
+func (s *Server) bearerAccount(r *http.Request, header string) (auth.Account, auth.Claims, error) {
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return nil, nil, errors.WrapDet(auth.ErrAuthorizationHeader, "authorization header is not a bearer token").
+			WithDetail("The authorization header must contain the bearer token.")
+	}
+	claims, err := s.Options.Tokener.InspectToken(r.Context(), strings.TrimSpace(header[len(bearerPrefix):]))
+	if err != nil {
+		return nil, nil, err
+	}
+	accessClaims, ok := claims.(auth.AccessClaims)
+	if !ok {
+		return nil, nil, errors.WrapDet(auth.ErrToken, "provided token is not an access token").
+			WithDetail("The token is not an access token.")
+	}
+	account := accessClaims.GetAccount()
+	if account == nil {
+		return nil, nil, errors.WrapDet(auth.ErrToken, "access token has no account").
+			WithDetail("The token is not valid.")
+	}
+	return account, claims, nil
+}
+
+// verifyEndpoint checks if the request context claims are granted all the endpoint scopes and the account has any
+// of the request endpoint roles.
+func (s *Server) verifyEndpoint(req *request) error {
+	if req.endpoint == nil {
+		return nil
+	}
+	if err := verifyScopes(req); err != nil {
+		return err
+	}
+	if len(req.endpoint.Roles) == 0 && len(req.endpoint.Scopes) == 0 {
+		return nil
+	}
+	account, ok := auth.CtxGetAccount(req.Context())
+	if !ok {
+		return errors.WrapDet(auth.ErrAuthorizationHeader, "no authorization header provided").
+			WithDetail("The endpoint requires authorization.")
+	}
+	if len(req.endpoint.Roles) == 0 {
+		return nil
+	}
+	if s.Options.Verifier == nil {
+		return errors.WrapDetf(server.ErrInternal, "no verifier defined for the endpoint: '%s %s' roles", req.endpoint.HTTPMethod, req.endpoint.Path)
+	}
+	return s.Options.Verifier.Verify(req.Context(), account, auth.VerifyAllowedRoles(req.endpoint.Roles...))
+}
+
+// verifyScopes checks if the request context claims are granted all the endpoint scopes. If the endpoint doesn't
+// define its scopes, the default endpoint scopes are required. The claims with an empty scope, i.e. the API keys
+// created without the scope, have no scope restrictions.
+func verifyScopes(req *request) error {
+	claims, ok := auth.CtxGetClaims(req.Context())
+	if !ok {
+		return nil
+	}
+	scoper, ok := claims.(auth.Scoper)
+	if !ok || scoper.Scope() == "" {
+		return nil
+	}
+	granted := map[string]struct{}{}
+	for _, scope := range strings.Fields(scoper.Scope()) {
+		granted[scope] = struct{}{}
+	}
+	scopes := req.endpoint.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes(req.endpoint)
+	}
+	for _, scope := range scopes {
+		if _, ok := granted[scope]; !ok {
+			return errors.WrapDetf(auth.ErrAuthorizationScope, "credentials have no scope: '%s'", scope).
+				WithDetail("The credentials are not allowed for the endpoint.")
+		}
+	}
+	return nil
+}
+
+// defaultScopes gets the scopes required by the 'endpoint' that doesn't define its own scopes. The reading endpoints
+// require the '<collection>:read' scope and the others the '<collection>:write' scope. The related endpoint
+// additionally requires the related collection read scope.
+func defaultScopes(endpoint *server.Endpoint) []string {
+	if endpoint.HTTPMethod != http.MethodGet {
+		return []string{endpoint.ModelStruct.Collection() + ":write"}
+	}
+	scopes := []string{endpoint.ModelStruct.Collection() + ":read"}
+	if endpoint.QueryMethod == query.GetRelated {
+		scopes = append(scopes, endpoint.Relation.Relationship().RelatedModelStruct().Collection()+":read")
+	}
+	return scopes
+}
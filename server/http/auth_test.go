@@ -0,0 +1,260 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/query"
+)
+
+type testClaims struct {
+	account auth.Account
+}
+
+func (c *testClaims) Subject() string {
+	return ""
+}
+
+func (c *testClaims) ExpiresIn() int64 {
+	return 0
+}
+
+func (c *testClaims) Valid() error {
+	return nil
+}
+
+type testAccessClaims struct {
+	testClaims
+}
+
+func (c *testAccessClaims) GetAccount() auth.Account {
+	return c.account
+}
+
+// testTokener inspects the tokens stored in the map.
+type testTokener map[string]auth.Claims
+
+func (t testTokener) InspectToken(_ context.Context, token string) (auth.Claims, error) {
+	switch token {
+	case "expired":
+		return nil, errors.WrapDet(auth.ErrTokenExpired, "token is expired")
+	case "revoked":
+		return nil, errors.WrapDet(auth.ErrTokenRevoked, "token is revoked")
+	}
+	claims, ok := t[token]
+	if !ok {
+		return nil, errors.WrapDet(auth.ErrToken, "invalid token")
+	}
+	return claims, nil
+}
+
+func (t testTokener) Token(context.Context, auth.Account, ...auth.TokenOption) (auth.Token, error) {
+	return auth.Token{}, nil
+}
+
+func (t testTokener) RevokeToken(context.Context, string) error {
+	return nil
+}
+
+type testRole string
+
+func (r testRole) RoleName() string {
+	return string(r)
+}
+
+// testVerifier maps the account usernames to their roles.
+type testVerifier map[string]testRole
+
+func (v testVerifier) Verify(_ context.Context, account auth.Account, options ...auth.VerifyOption) error {
+	o := &auth.VerifyOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	for _, role := range o.AllowedRoles {
+		if role.RoleName() == string(v[account.GetUsername()]) {
+			return nil
+		}
+	}
+	return errors.WrapDet(auth.ErrForbidden, "forbidden")
+}
+
+func TestBearerAuthentication(t *testing.T) {
+	var (
+		account auth.Account
+		claims  auth.Claims
+	)
+	s := testServer(t, WithTokener(testTokener{
+		"admin":   &testAccessClaims{testClaims{account: &testmodels.User{ID: 1, Username: "admin"}}},
+		"user":    &testAccessClaims{testClaims{account: &testmodels.User{ID: 2, Username: "user"}}},
+		"refresh": &testClaims{account: &testmodels.User{ID: 1, Username: "admin"}},
+	}), WithVerifier(testVerifier{"admin": "admin", "user": "user"}))
+	// Capture the request context account and claims.
+	s.handler = s.authenticate(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		account, _ = auth.CtxGetAccount(req.Context())
+		claims, _ = auth.CtxGetClaims(req.Context())
+		s.serveHTTP(rw, req)
+	}))
+	for _, endpoint := range s.GetEndpoints() {
+		if endpoint.ModelStruct.Collection() == "blogs" && endpoint.QueryMethod == query.Insert {
+			endpoint.Roles = []auth.Role{testRole("admin")}
+		}
+	}
+
+	resp := doRequest(t, s, http.MethodGet, "/blogs", "")
+	assert.Equal(t, http.StatusOK, resp.Status)
+	assert.Nil(t, account)
+
+	resp = doRequest(t, s, http.MethodGet, "/blogs", "", "Authorization", "Bearer user")
+	assert.Equal(t, http.StatusOK, resp.Status)
+	require.NotNil(t, account)
+	assert.Equal(t, "user", account.GetUsername())
+	assert.IsType(t, &testAccessClaims{}, claims)
+
+	body := `{"data":{"type":"blogs","attributes":{"title":"first"}}}`
+	resp = doRequest(t, s, http.MethodPost, "/blogs", body)
+	assert.Equal(t, http.StatusUnauthorized, resp.Status)
+
+	resp = doRequest(t, s, http.MethodPost, "/blogs", body, "Authorization", "Bearer user")
+	assert.Equal(t, http.StatusForbidden, resp.Status)
+
+	resp = doRequest(t, s, http.MethodPost, "/blogs", body, "Authorization", "Bearer admin")
+	assert.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+
+	for _, header := range []string{"Basic admin", "Bearer expired", "Bearer revoked", "Bearer invalid", "Bearer refresh"} {
+		account = nil
+		resp = doRequest(t, s, http.MethodGet, "/blogs", "", "Authorization", header)
+		assert.Equal(t, http.StatusUnauthorized, resp.Status, header)
+		assert.Equal(t, `Bearer error="invalid_token"`, resp.Header.Get("WWW-Authenticate"))
+		assert.Nil(t, account)
+	}
+
+	t.Run("Middlewares", func(t *testing.T) {
+		var middlewareAccount auth.Account
+		s := testServer(t, WithTokener(testTokener{
+			"user": &testAccessClaims{testClaims{account: &testmodels.User{ID: 2, Username: "user"}}},
+		}), WithMiddlewares(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				middlewareAccount, _ = auth.CtxGetAccount(req.Context())
+				next.ServeHTTP(rw, req)
+			})
+		}))
+		// The request is authenticated before the middlewares are applied.
+		resp := doRequest(t, s, http.MethodGet, "/blogs", "", "Authorization", "Bearer user")
+		assert.Equal(t, http.StatusOK, resp.Status)
+		require.NotNil(t, middlewareAccount)
+		assert.Equal(t, "user", middlewareAccount.GetUsername())
+	})
+
+	t.Run("NoVerifier", func(t *testing.T) {
+		s := testServer(t, WithTokener(testTokener{
+			"admin": &testAccessClaims{testClaims{account: &testmodels.User{ID: 1, Username: "admin"}}},
+		}))
+		for _, endpoint := range s.GetEndpoints() {
+			endpoint.Roles = []auth.Role{testRole("admin")}
+		}
+		resp := doRequest(t, s, http.MethodGet, "/blogs", "", "Authorization", "Bearer admin")
+		assert.Equal(t, http.StatusInternalServerError, resp.Status)
+	})
+}
+
+// testAPIKey is the API key account with its scope.
+type testAPIKey struct {
+	account auth.Account
+	scope   string
+}
+
+// testAPIKeyClaims are the claims of the test API key.
+type testAPIKeyClaims struct {
+	testClaims
+	scope string
+}
+
+func (c *testAPIKeyClaims) Scope() string {
+	return c.scope
+}
+
+// testAPIKeyAuthenticator maps the API keys to their accounts and scopes.
+type testAPIKeyAuthenticator map[string]testAPIKey
+
+func (a testAPIKeyAuthenticator) AuthenticateAPIKey(_ context.Context, key string) (auth.Account, auth.Claims, error) {
+	apiKey, ok := a[key]
+	if !ok {
+		return nil, nil, errors.WrapDet(auth.ErrAPIKey, "invalid api key")
+	}
+	return apiKey.account, &testAPIKeyClaims{testClaims: testClaims{account: apiKey.account}, scope: apiKey.scope}, nil
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	s := testServer(t, WithAPIKeyAuthenticator(testAPIKeyAuthenticator{
+		"admin.secret": {account: &testmodels.User{ID: 1, Username: "admin"}},
+		"admin.read":   {account: &testmodels.User{ID: 1, Username: "admin"}, scope: "read"},
+	}), WithVerifier(testVerifier{"admin": "admin"}))
+	for _, endpoint := range s.GetEndpoints() {
+		endpoint.Roles = []auth.Role{testRole("admin")}
+	}
+
+	resp := doRequest(t, s, http.MethodGet, "/blogs", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.Status)
+
+	resp = doRequest(t, s, http.MethodGet, "/blogs", "", APIKeyHeader, "admin.secret")
+	assert.Equal(t, http.StatusOK, resp.Status, resp.Body)
+
+	resp = doRequest(t, s, http.MethodGet, "/blogs", "", APIKeyHeader, "admin.invalid")
+	assert.Equal(t, http.StatusUnauthorized, resp.Status)
+	assert.Empty(t, resp.Header.Get("WWW-Authenticate"))
+
+	// The bearer tokens are not inspected without the tokener.
+	resp = doRequest(t, s, http.MethodGet, "/blogs", "", "Authorization", "Bearer admin")
+	assert.Equal(t, http.StatusUnauthorized, resp.Status)
+
+	t.Run("Scopes", func(t *testing.T) {
+		for _, endpoint := range s.GetEndpoints() {
+			if endpoint.HTTPMethod == http.MethodGet {
+				endpoint.Scopes = []string{"read"}
+			} else {
+				endpoint.Scopes = []string{"write"}
+			}
+		}
+		resp := doRequest(t, s, http.MethodGet, "/blogs", "", APIKeyHeader, "admin.read")
+		assert.Equal(t, http.StatusOK, resp.Status, resp.Body)
+
+		resp = doRequest(t, s, http.MethodPost, "/blogs", `{"data":{"type":"blogs","attributes":{"title":"scoped"}}}`, APIKeyHeader, "admin.read")
+		assert.Equal(t, http.StatusForbidden, resp.Status)
+
+		// The key without scope has no scope restrictions.
+		resp = doRequest(t, s, http.MethodPost, "/blogs", `{"data":{"type":"blogs","attributes":{"title":"scoped"}}}`, APIKeyHeader, "admin.secret")
+		assert.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+	})
+
+	t.Run("DefaultScopes", func(t *testing.T) {
+		s := testServer(t, WithAPIKeyAuthenticator(testAPIKeyAuthenticator{
+			"admin.secret": {account: &testmodels.User{ID: 1, Username: "admin"}},
+			"blogs.read":   {account: &testmodels.User{ID: 1, Username: "admin"}, scope: "blogs:read"},
+			"posts.read":   {account: &testmodels.User{ID: 1, Username: "admin"}, scope: "posts:read"},
+		}))
+		blog := `{"data":{"type":"blogs","attributes":{"title":"scoped"}}}`
+
+		// The endpoints without the scopes are still available for the anonymous and not scoped requests.
+		resp := doRequest(t, s, http.MethodGet, "/blogs", "")
+		assert.Equal(t, http.StatusOK, resp.Status, resp.Body)
+		resp = doRequest(t, s, http.MethodPost, "/blogs", blog, APIKeyHeader, "admin.secret")
+		assert.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+
+		resp = doRequest(t, s, http.MethodGet, "/blogs", "", APIKeyHeader, "blogs.read")
+		assert.Equal(t, http.StatusOK, resp.Status, resp.Body)
+		resp = doRequest(t, s, http.MethodPost, "/blogs", blog, APIKeyHeader, "blogs.read")
+		assert.Equal(t, http.StatusForbidden, resp.Status)
+		resp = doRequest(t, s, http.MethodGet, "/blogs", "", APIKeyHeader, "posts.read")
+		assert.Equal(t, http.StatusForbidden, resp.Status)
+		// The related endpoint requires also the related collection scope.
+		resp = doRequest(t, s, http.MethodGet, "/blogs/1/posts", "", APIKeyHeader, "blogs.read")
+		assert.Equal(t, http.StatusForbidden, resp.Status)
+	})
+}
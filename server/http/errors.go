@@ -0,0 +1,113 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/server"
+)
+
+var (
+	// ErrEndpointNotFound is an error thrown when the request path doesn't match any endpoint.
+	ErrEndpointNotFound = errors.Wrap(server.ErrServer, "endpoint not found")
+	// ErrMethodNotAllowed is an error thrown when the endpoint doesn't handle the request method.
+	ErrMethodNotAllowed = errors.Wrap(server.ErrServer, "method not allowed")
+	// ErrForbiddenOperation is an error thrown when the request operation is not allowed for the model.
+	ErrForbiddenOperation = errors.Wrap(server.ErrServer, "forbidden operation")
+	// ErrConflict is an error thrown when the request content conflicts with the endpoint.
+	ErrConflict = errors.Wrap(server.ErrServer, "conflict")
+)
+
+// errorStatuses maps the error classes with the http statuses. The first matching class is taken.
+var errorStatuses = []struct {
+	class  error
+	status int
+}{
+	{class: query.ErrNoResult, status: http.StatusNotFound},
+	{class: ErrEndpointNotFound, status: http.StatusNotFound},
+	{class: ErrMethodNotAllowed, status: http.StatusMethodNotAllowed},
+	{class: ErrForbiddenOperation, status: http.StatusForbidden},
+	{class: ErrConflict, status: http.StatusConflict},
+	{class: server.ErrHeaderNotAcceptable, status: http.StatusNotAcceptable},
+	{class: server.ErrUnsupportedHeader, status: http.StatusUnsupportedMediaType},
+	{class: server.ErrHeader, status: http.StatusBadRequest},
+	{class: server.ErrURIParameter, status: http.StatusBadRequest},
+	{class: auth.ErrPasswordPolicy, status: http.StatusBadRequest},
+	{class: auth.ErrAuthentication, status: http.StatusUnauthorized},
+	{class: auth.ErrAuthorizationHeader, status: http.StatusUnauthorized},
+	{class: auth.ErrAuthorization, status: http.StatusForbidden},
+	{class: query.ErrViolation, status: http.StatusConflict},
+	{class: query.ErrInput, status: http.StatusBadRequest},
+	{class: codec.ErrUnmarshal, status: http.StatusBadRequest},
+	{class: mapping.ErrFieldValue, status: http.StatusBadRequest},
+}
+
+// codecErrors converts the 'err' into codec errors. The internal errors are logged and their details are not
+// exposed in the response. Each error of the errors.MultiError is converted separately.
+func codecErrors(err error) codec.MultiError {
+	switch e := err.(type) {
+	case *codec.Error:
+		return codec.MultiError{e}
+	case codec.MultiError:
+		return e
+	case errors.MultiError:
+		var result codec.MultiError
+		for _, subErr := range e {
+			result = append(result, codecErrors(subErr)...)
+		}
+		return result
+	}
+
+	status := http.StatusInternalServerError
+	for _, s := range errorStatuses {
+		if errors.Is(err, s.class) {
+			status = s.status
+			break
+		}
+	}
+	codecErr := &codec.Error{
+		Title:  http.StatusText(status),
+		Status: strconv.Itoa(status),
+	}
+	detailed := &errors.DetailedError{}
+	isDetailed := errors.As(err, &detailed)
+	if isDetailed {
+		codecErr.ID = detailed.ID.String()
+	}
+	if status == http.StatusInternalServerError {
+		log.Errorf("Internal server error: %v", err)
+		return codec.MultiError{codecErr}
+	}
+	switch {
+	case isDetailed && detailed.Details != "":
+		codecErr.Detail = detailed.Details
+	case isDetailed:
+		codecErr.Detail = detailed.Message
+	default:
+		codecErr.Detail = err.Error()
+	}
+	return codec.MultiError{codecErr}
+}
+
+// writeError writes the 'err' with the request codec.
+func (s *Server) writeError(rw http.ResponseWriter, req *request, err error) {
+	errs := codecErrors(err)
+	buf := &bytes.Buffer{}
+	if err := req.codec.MarshalErrors(buf, errs...); err != nil {
+		log.Errorf("Marshaling errors failed: %v", err)
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", req.codec.MimeType())
+	rw.WriteHeader(errs.Status())
+	if _, err := rw.Write(buf.Bytes()); err != nil {
+		log.Debugf("Writing error response failed: %v", err)
+	}
+}
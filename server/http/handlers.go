@@ -0,0 +1,370 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/server"
+)
+
+// handleList handles the request for the collection resources. The resources are filtered, sorted, paginated and
+// included with respect to the request url query.
+func (s *Server) handleList(rw http.ResponseWriter, req *request) {
+	q := s.DB.QueryCtx(req.Context(), req.mStruct)
+	fieldSet, err := applyQuery(req, q.Scope())
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	models, err := q.Find()
+	if err != nil && !errors.Is(err, query.ErrNoResult) {
+		s.writeError(rw, req, err)
+		return
+	}
+	payload := &codec.Payload{ModelStruct: req.mStruct, Data: models, IncludedRelations: q.Scope().IncludedRelations}
+	if fieldSet = responseFieldSet(req.mStruct, fieldSet, q.Scope()); fieldSet != nil {
+		payload.FieldSets = []mapping.FieldSet{fieldSet}
+	}
+	s.marshalPayload(rw, req, http.StatusOK, payload, codec.MarshalWithLinks(s.linkOptions(req, codec.ResourceLink)))
+}
+
+// handleGet handles the request for the single resource. The resource fields and included relations are taken
+// from the request url query.
+func (s *Server) handleGet(rw http.ResponseWriter, req *request) {
+	model, err := s.rootModel(req)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	q := s.DB.QueryCtx(req.Context(), req.mStruct)
+	fieldSet, err := applyQuery(req, q.Scope())
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	if model, err = q.Filter(primaryFilter(req.mStruct, model)).Get(); err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	payload := &codec.Payload{ModelStruct: req.mStruct, Data: []mapping.Model{model}, IncludedRelations: q.Scope().IncludedRelations}
+	if fieldSet = responseFieldSet(req.mStruct, fieldSet, q.Scope()); fieldSet != nil {
+		payload.FieldSets = []mapping.FieldSet{fieldSet}
+	}
+	s.marshalPayload(rw, req, http.StatusOK, payload, codec.MarshalSingleModel(), codec.MarshalWithLinks(s.linkOptions(req, codec.ResourceLink)))
+}
+
+// handleInsert handles the request that creates new resource. The relations of the resource are set within the
+// same transaction.
+func (s *Server) handleInsert(rw http.ResponseWriter, req *request) {
+	payload, err := s.unmarshalPayload(req, req.mStruct, codec.UnmarshalWithSingleExpectation())
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	if len(payload.Data) == 0 {
+		s.writeError(rw, req, errors.WrapDet(query.ErrInvalidInput, "no resource data provided"))
+		return
+	}
+	model := payload.Data[0]
+	if !model.IsPrimaryKeyZero() && !req.mStruct.AllowClientID() {
+		s.writeError(rw, req, errors.WrapDetf(ErrForbiddenOperation, "client generated id is not allowed for the collection: '%s'", req.mStruct.Collection()))
+		return
+	}
+	_, relations := splitFieldSet(payload.FieldSets[0])
+
+	ctx := req.Context()
+	err = database.RunInTransaction(ctx, s.DB, nil, func(db database.DB) error {
+		if _, err := setForeignKeys(model, relations); err != nil {
+			return err
+		}
+		if err := db.Insert(ctx, req.mStruct, model); err != nil {
+			return err
+		}
+		return setRelations(ctx, db, model, relations, false)
+	})
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	if req.id, err = model.GetPrimaryKeyStringValue(); err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	inserted, fieldSet, err := s.getResponseModel(ctx, req)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	rw.Header().Set("Location", s.baseURL(req)+"/"+req.mStruct.Collection()+"/"+req.id)
+	s.marshalModel(rw, req, http.StatusCreated, inserted, fieldSet)
+}
+
+// handleUpdate handles the request that updates the resource fields and relations.
+func (s *Server) handleUpdate(rw http.ResponseWriter, req *request) {
+	payload, err := s.unmarshalPayload(req, req.mStruct, codec.UnmarshalWithSingleExpectation())
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	if len(payload.Data) == 0 {
+		s.writeError(rw, req, errors.WrapDet(query.ErrInvalidInput, "no resource data provided"))
+		return
+	}
+	model := payload.Data[0]
+	if model.IsPrimaryKeyZero() {
+		if err = model.SetPrimaryKeyStringValue(req.id); err != nil {
+			s.writeError(rw, req, errors.WrapDetf(server.ErrURIParameter, "invalid resource id: '%s'", req.id))
+			return
+		}
+	} else {
+		id, err := model.GetPrimaryKeyStringValue()
+		if err != nil {
+			s.writeError(rw, req, err)
+			return
+		}
+		if id != req.id {
+			s.writeError(rw, req, errors.WrapDetf(ErrConflict, "resource id: '%s' doesn't match the endpoint id: '%s'", id, req.id))
+			return
+		}
+	}
+	fields, relations := splitFieldSet(payload.FieldSets[0])
+
+	ctx := req.Context()
+	err = database.RunInTransaction(ctx, s.DB, nil, func(db database.DB) error {
+		foreignKeys, err := setForeignKeys(model, relations)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, foreignKeys...)
+		if len(fields) > 0 {
+			affected, err := db.QueryCtx(ctx, req.mStruct, model).Select(fields...).Update()
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				return errors.WrapDetf(query.ErrNoResult, "resource: '%s' not found", req.id)
+			}
+		} else if _, err = s.getModel(ctx, db, req); err != nil {
+			return err
+		}
+		return setRelations(ctx, db, model, relations, true)
+	})
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	updated, fieldSet, err := s.getResponseModel(ctx, req)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	s.marshalModel(rw, req, http.StatusOK, updated, fieldSet)
+}
+
+// handleDelete handles the request that deletes the resource.
+func (s *Server) handleDelete(rw http.ResponseWriter, req *request) {
+	model, err := s.rootModel(req)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	affected, err := s.DB.Delete(req.Context(), req.mStruct, model)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	if affected == 0 {
+		s.writeError(rw, req, errors.WrapDetf(query.ErrNoResult, "resource: '%s' not found", req.id))
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// handleGetRelated handles the request for the related resources. The related resources are queried by their
+// primary keys, so that they are marshaled with the field set of the related query.
+func (s *Server) handleGetRelated(rw http.ResponseWriter, req *request) {
+	relatedStruct := req.relation.Relationship().RelatedModelStruct()
+	identifiers, err := s.getRelations(req, relatedStruct.Primary())
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	if len(identifiers) == 0 {
+		s.marshalRelations(rw, req, identifiers, nil, codec.RelatedLink)
+		return
+	}
+	primaryKeys := make([]interface{}, len(identifiers))
+	for i, identifier := range identifiers {
+		primaryKeys[i] = identifier.GetPrimaryKeyValue()
+	}
+	q := s.DB.QueryCtx(req.Context(), relatedStruct).Filter(filter.New(relatedStruct.Primary(), filter.OpIn, primaryKeys...))
+	related, err := q.Find()
+	if err != nil && !errors.Is(err, query.ErrNoResult) {
+		s.writeError(rw, req, err)
+		return
+	}
+	s.marshalRelations(rw, req, related, responseFieldSet(relatedStruct, nil, q.Scope()), codec.RelatedLink)
+}
+
+// handleGetRelationship handles the request for the relationship resource identifiers.
+func (s *Server) handleGetRelationship(rw http.ResponseWriter, req *request) {
+	related, err := s.getRelations(req, req.relation.Relationship().RelatedModelStruct().Primary())
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	s.marshalRelations(rw, req, related, nil, codec.RelationshipLink)
+}
+
+// handleSetRelationship handles the request that replaces all the relationship members. An empty data clears
+// the relationship.
+func (s *Server) handleSetRelationship(rw http.ResponseWriter, req *request) {
+	var options []codec.UnmarshalOption
+	if req.relation.Kind() == mapping.KindRelationshipSingle {
+		options = append(options, codec.UnmarshalWithSingleExpectation())
+	}
+	payload, err := s.unmarshalPayload(req, req.relation.Relationship().RelatedModelStruct(), options...)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	ctx := req.Context()
+	err = database.RunInTransaction(ctx, s.DB, nil, func(db database.DB) error {
+		model, err := s.getModel(ctx, db, req)
+		if err != nil {
+			return err
+		}
+		if len(payload.Data) == 0 {
+			_, err = db.ClearRelations(ctx, model, req.relation)
+			return err
+		}
+		return db.SetRelations(ctx, model, req.relation, payload.Data...)
+	})
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// handleAddRelationship handles the request that adds the members to the to-many relationship.
+func (s *Server) handleAddRelationship(rw http.ResponseWriter, req *request) {
+	payload, err := s.unmarshalPayload(req, req.relation.Relationship().RelatedModelStruct())
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	ctx := req.Context()
+	err = database.RunInTransaction(ctx, s.DB, nil, func(db database.DB) error {
+		model, err := s.getModel(ctx, db, req)
+		if err != nil {
+			return err
+		}
+		if len(payload.Data) == 0 {
+			return nil
+		}
+		return db.AddRelations(ctx, model, req.relation, payload.Data...)
+	})
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// handleRemoveRelationship handles the request that removes the members from the to-many relationship.
+func (s *Server) handleRemoveRelationship(rw http.ResponseWriter, req *request) {
+	relatedStruct := req.relation.Relationship().RelatedModelStruct()
+	payload, err := s.unmarshalPayload(req, relatedStruct)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	removed := map[string]struct{}{}
+	for _, model := range payload.Data {
+		id, err := model.GetPrimaryKeyStringValue()
+		if err != nil {
+			s.writeError(rw, req, err)
+			return
+		}
+		removed[id] = struct{}{}
+	}
+
+	ctx := req.Context()
+	err = database.RunInTransaction(ctx, s.DB, nil, func(db database.DB) error {
+		model, err := s.getModel(ctx, db, req)
+		if err != nil {
+			return err
+		}
+		current, err := db.GetRelations(ctx, req.mStruct, []mapping.Model{model}, req.relation, relatedStruct.Primary())
+		if err != nil {
+			return err
+		}
+		remaining := make([]mapping.Model, 0, len(current))
+		for _, related := range current {
+			id, err := related.GetPrimaryKeyStringValue()
+			if err != nil {
+				return err
+			}
+			if _, ok := removed[id]; !ok {
+				remaining = append(remaining, related)
+			}
+		}
+		switch {
+		case len(remaining) == len(current):
+			return nil
+		case len(remaining) == 0:
+			_, err = db.ClearRelations(ctx, model, req.relation)
+			return err
+		default:
+			return db.SetRelations(ctx, model, req.relation, remaining...)
+		}
+	})
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// getRelations gets the request relation models for the root resource.
+func (s *Server) getRelations(req *request, relationFieldSet ...*mapping.StructField) ([]mapping.Model, error) {
+	ctx := req.Context()
+	model, err := s.getModel(ctx, s.DB, req)
+	if err != nil {
+		return nil, err
+	}
+	related, err := s.DB.GetRelations(ctx, req.mStruct, []mapping.Model{model}, req.relation, relationFieldSet...)
+	if err != nil && !errors.Is(err, query.ErrNoResult) {
+		return nil, err
+	}
+	return related, nil
+}
+
+// getModel gets the request root resource from the 'db'.
+func (s *Server) getModel(ctx context.Context, db database.DB, req *request) (mapping.Model, error) {
+	model, err := s.rootModel(req)
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryCtx(ctx, req.mStruct).Filter(primaryFilter(req.mStruct, model)).Get()
+}
+
+// getResponseModel gets the request root resource along with the field set used to marshal it in the response.
+func (s *Server) getResponseModel(ctx context.Context, req *request) (mapping.Model, mapping.FieldSet, error) {
+	model, err := s.rootModel(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	q := s.DB.QueryCtx(ctx, req.mStruct).Filter(primaryFilter(req.mStruct, model))
+	if model, err = q.Get(); err != nil {
+		return nil, nil, err
+	}
+	return model, responseFieldSet(req.mStruct, nil, q.Scope()), nil
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/server"
+)
+
+// rootModel creates the request model with the primary key value taken from the request path.
+func (s *Server) rootModel(req *request) (mapping.Model, error) {
+	model := mapping.NewModel(req.mStruct)
+	if err := model.SetPrimaryKeyStringValue(req.id); err != nil {
+		return nil, errors.WrapDetf(server.ErrURIParameter, "invalid resource id: '%s'", req.id)
+	}
+	return model, nil
+}
+
+// applyQuery parses the request url query and sets its values in the scope 's'. The relations are not selected in
+// the scope field set. Returns the field set with the relations used for marshaling or nil if not defined.
+func applyQuery(req *request, s *query.Scope) (mapping.FieldSet, error) {
+	parsed, err := query.ParseQuery(req.mStruct, req.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+	s.Filters = append(s.Filters, parsed.Filters...)
+	s.SortingOrder = parsed.SortingOrder
+	s.IncludedRelations = parsed.IncludedRelations
+	s.Pagination = parsed.Pagination
+	if len(parsed.FieldSets) == 0 {
+		return nil, nil
+	}
+	fieldSet := parsed.FieldSets[0]
+	fields := mapping.FieldSet{}
+	for _, field := range fieldSet {
+		if !field.IsRelationship() {
+			fields = append(fields, field)
+		}
+	}
+	s.FieldSets = []mapping.FieldSet{fields}
+	return fieldSet, nil
+}
+
+// responseFieldSet gets the field set used to marshal the models found with the scope 's'. The fields of the
+// 'requested' field set, or of the whole model if nil, that were not selected in the scope i.e. pruned by the query
+// authorizer, are omitted. Returns nil if all the model fields should be marshaled.
+func responseFieldSet(mStruct *mapping.ModelStruct, requested mapping.FieldSet, s *query.Scope) mapping.FieldSet {
+	if len(s.FieldSets) == 0 || len(s.FieldSets[0]) == 0 {
+		return requested
+	}
+	selected := s.FieldSets[0]
+	if requested == nil {
+		if len(selected) == len(mStruct.Fields()) {
+			return nil
+		}
+		requested = append(mapping.FieldSet{}, mStruct.Fields()...)
+		requested = append(requested, mStruct.RelationFields()...)
+	}
+	fieldSet := mapping.FieldSet{}
+	for _, field := range requested {
+		if field.IsRelationship() || selected.Contains(field) {
+			fieldSet = append(fieldSet, field)
+		}
+	}
+	return fieldSet
+}
+
+// primaryFilter creates the filter for the 'model' primary key value.
+func primaryFilter(mStruct *mapping.ModelStruct, model mapping.Model) filter.Filter {
+	return filter.New(mStruct.Primary(), filter.OpEqual, model.GetPrimaryKeyValue())
+}
+
+// splitFieldSet splits the unmarshaled 'fieldSet' into the model fields and relations. The primary key is omitted.
+func splitFieldSet(fieldSet mapping.FieldSet) (fields, relations mapping.FieldSet) {
+	for _, field := range fieldSet {
+		switch field.Kind() {
+		case mapping.KindPrimary:
+		case mapping.KindRelationshipSingle, mapping.KindRelationshipMultiple:
+			relations = append(relations, field)
+		default:
+			fields = append(fields, field)
+		}
+	}
+	return fields, relations
+}
+
+// setForeignKeys sets the foreign key values of the 'model' belongs to 'relations'. Returns the foreign key fields.
+func setForeignKeys(model mapping.Model, relations mapping.FieldSet) (mapping.FieldSet, error) {
+	var foreignKeys mapping.FieldSet
+	for _, relation := range relations {
+		if relation.Relationship().Kind() != mapping.RelBelongsTo {
+			continue
+		}
+		fielder, ok := model.(mapping.Fielder)
+		if !ok {
+			return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.Fielder interface", model)
+		}
+		related, err := relationModels(model, relation)
+		if err != nil {
+			return nil, err
+		}
+		foreignKey := relation.Relationship().ForeignKey()
+		if len(related) == 0 {
+			err = fielder.SetFieldZeroValue(foreignKey)
+		} else {
+			err = fielder.SetFieldValue(foreignKey, related[0].GetPrimaryKeyValue())
+		}
+		if err != nil {
+			return nil, err
+		}
+		foreignKeys = append(foreignKeys, foreignKey)
+	}
+	return foreignKeys, nil
+}
+
+// setRelations sets the 'model' relations other than belongs to. If the 'clear' flag is set the relations without
+// related models are cleared.
+func setRelations(ctx context.Context, db database.DB, model mapping.Model, relations mapping.FieldSet, clear bool) error {
+	for _, relation := range relations {
+		if relation.Relationship().Kind() == mapping.RelBelongsTo {
+			continue
+		}
+		related, err := relationModels(model, relation)
+		if err != nil {
+			return err
+		}
+		if len(related) == 0 {
+			if !clear {
+				continue
+			}
+			if _, err = db.ClearRelations(ctx, model, relation); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = db.SetRelations(ctx, model, relation, related...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relationModels gets the 'model' related models stored in the 'relation' field.
+func relationModels(model mapping.Model, relation *mapping.StructField) ([]mapping.Model, error) {
+	if relation.Kind() == mapping.KindRelationshipSingle {
+		relationer, ok := model.(mapping.SingleRelationer)
+		if !ok {
+			return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.SingleRelationer interface", model)
+		}
+		related, err := relationer.GetRelationModel(relation)
+		if err != nil || related == nil {
+			return nil, err
+		}
+		return []mapping.Model{related}, nil
+	}
+	relationer, ok := model.(mapping.MultiRelationer)
+	if !ok {
+		return nil, errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement mapping.MultiRelationer interface", model)
+	}
+	return relationer.GetRelationModels(relation)
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/server"
+)
+
+// acceptedType is the single media range from the 'Accept' header.
+type acceptedType struct {
+	mediaType string
+	quality   float64
+}
+
+// responseCodec gets the codec for the response based on the request 'Accept' header. If the header is not defined
+// or accepts any media type, the default codec is used.
+func (s *Server) responseCodec(r *http.Request) (codec.Codec, error) {
+	header := r.Header.Get("Accept")
+	if header == "" {
+		return s.Options.Codecs[0], nil
+	}
+	accepted, err := parseAccept(header)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accepted {
+		switch {
+		case a.mediaType == "*/*":
+			return s.Options.Codecs[0], nil
+		case strings.HasSuffix(a.mediaType, "/*"):
+			for _, c := range s.Options.Codecs {
+				if strings.HasPrefix(c.MimeType(), strings.TrimSuffix(a.mediaType, "*")) {
+					return c, nil
+				}
+			}
+		default:
+			if c, ok := s.codecByMimeType(a.mediaType); ok {
+				return c, nil
+			}
+		}
+	}
+	return nil, errors.WrapDetf(server.ErrHeaderNotAcceptable, "none of the accepted media types: '%s' is supported", header)
+}
+
+// requestCodec gets the codec for the request body based on the 'Content-Type' header. If the header is not
+// defined the default codec is used.
+func (s *Server) requestCodec(r *http.Request) (codec.Codec, error) {
+	header := r.Header.Get("Content-Type")
+	if header == "" {
+		return s.Options.Codecs[0], nil
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return nil, errors.WrapDetf(server.ErrHeaderValue, "invalid 'Content-Type' header value: '%s'", header)
+	}
+	c, ok := s.codecByMimeType(mediaType)
+	if !ok {
+		return nil, errors.WrapDetf(server.ErrUnsupportedHeader, "unsupported media type: '%s'", mediaType)
+	}
+	return c, nil
+}
+
+func (s *Server) codecByMimeType(mediaType string) (codec.Codec, bool) {
+	for _, c := range s.Options.Codecs {
+		if strings.EqualFold(c.MimeType(), mediaType) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// parseAccept parses the 'Accept' header media ranges and sorts them by their quality. The media ranges with
+// zero quality are omitted.
+func parseAccept(header string) ([]acceptedType, error) {
+	var accepted []acceptedType
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			return nil, errors.WrapDetf(server.ErrHeaderValue, "invalid 'Accept' header media range: '%s'", part)
+		}
+		a := acceptedType{mediaType: mediaType, quality: 1}
+		if q, ok := params["q"]; ok {
+			if a.quality, err = strconv.ParseFloat(q, 64); err != nil {
+				return nil, errors.WrapDetf(server.ErrHeaderValue, "invalid 'Accept' header quality value: '%s'", q)
+			}
+		}
+		if a.quality > 0 {
+			accepted = append(accepted, a)
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+	return accepted, nil
+}
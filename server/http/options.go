@@ -0,0 +1,143 @@
+package http
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/server"
+)
+
+// DefaultAddress is the default address the server listens on.
+const DefaultAddress = ":8080"
+
+// Options are the http server options.
+type Options struct {
+	// Address is the TCP network address the server listens on.
+	Address string
+	// PathPrefix is the prefix for all the model endpoints i.e.: '/v1'.
+	PathPrefix string
+	// Codecs are the codecs used in the content negotiation. The first codec is the default one.
+	// If no codecs are provided the server uses JSON:API and flat JSON codecs.
+	Codecs []codec.Codec
+	// Models are the models that the server creates the endpoints for. If empty, the endpoints are created for all
+	// non join models in the database model map.
+	Models []mapping.Model
+	// ExcludedModels are the models that the server doesn't create the endpoints for, i.e. the models storing
+	// the credentials. The relationship endpoints of the exposed models don't reach the excluded models.
+	ExcludedModels []mapping.Model
+	// Middlewares are the middlewares applied for all the server endpoints. If the server authenticates
+	// the requests, the middlewares are applied after the authentication and could get the context account.
+	Middlewares server.MiddlewareChain
+	// Tokener inspects the 'Authorization: Bearer' header tokens. If set, the account of the access token is
+	// stored in the request context.
+	Tokener auth.Tokener
+	// APIKeyAuthenticator authenticates the 'X-API-Key' header keys. If set, the account of the key is stored in
+	// the request context.
+	APIKeyAuthenticator auth.APIKeyAuthenticator
+	// Verifier verifies if the request account has any of the endpoint roles.
+	Verifier auth.Verifier
+	// ReadTimeout is the maximum duration for reading the entire request.
+	ReadTimeout time.Duration
+	// WriteTimeout is the maximum duration before timing out writes of the response.
+	WriteTimeout time.Duration
+	// IdleTimeout is the maximum amount of time to wait for the next request when keep-alive are enabled.
+	IdleTimeout time.Duration
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		Address:      DefaultAddress,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+}
+
+// Option is the function that sets the server options.
+type Option func(o *Options)
+
+// WithAddress sets the server listen address.
+func WithAddress(address string) Option {
+	return func(o *Options) {
+		o.Address = address
+	}
+}
+
+// WithPathPrefix sets the path prefix for all the model endpoints.
+func WithPathPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.PathPrefix = prefix
+	}
+}
+
+// WithCodecs sets the server codecs. The first codec is used as the default one.
+func WithCodecs(codecs ...codec.Codec) Option {
+	return func(o *Options) {
+		o.Codecs = codecs
+	}
+}
+
+// WithModels adds the models that the server creates the endpoints for.
+func WithModels(models ...mapping.Model) Option {
+	return func(o *Options) {
+		o.Models = append(o.Models, models...)
+	}
+}
+
+// WithExcludedModels adds the models that the server doesn't create the endpoints for.
+func WithExcludedModels(models ...mapping.Model) Option {
+	return func(o *Options) {
+		o.ExcludedModels = append(o.ExcludedModels, models...)
+	}
+}
+
+// WithMiddlewares adds the middlewares applied for all the server endpoints.
+func WithMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.Middlewares = append(o.Middlewares, middlewares...)
+	}
+}
+
+// WithTokener sets the tokener used to authenticate the requests with the bearer tokens.
+func WithTokener(tokener auth.Tokener) Option {
+	return func(o *Options) {
+		o.Tokener = tokener
+	}
+}
+
+// WithAPIKeyAuthenticator sets the authenticator of the requests with the API keys.
+func WithAPIKeyAuthenticator(authenticator auth.APIKeyAuthenticator) Option {
+	return func(o *Options) {
+		o.APIKeyAuthenticator = authenticator
+	}
+}
+
+// WithVerifier sets the verifier of the endpoint roles.
+func WithVerifier(verifier auth.Verifier) Option {
+	return func(o *Options) {
+		o.Verifier = verifier
+	}
+}
+
+// WithReadTimeout sets the server read timeout.
+func WithReadTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.ReadTimeout = timeout
+	}
+}
+
+// WithWriteTimeout sets the server write timeout.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.WriteTimeout = timeout
+	}
+}
+
+// WithIdleTimeout sets the server idle timeout.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.IdleTimeout = timeout
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// marshalModel writes the single 'model' resource response. If the 'fieldSet' is nil all the model fields are
+// marshaled.
+func (s *Server) marshalModel(rw http.ResponseWriter, req *request, status int, model mapping.Model, fieldSet mapping.FieldSet) {
+	payload := &codec.Payload{ModelStruct: req.mStruct, Data: []mapping.Model{model}}
+	if fieldSet != nil {
+		payload.FieldSets = []mapping.FieldSet{fieldSet}
+	}
+	s.marshalPayload(rw, req, status, payload, codec.MarshalSingleModel(), codec.MarshalWithLinks(s.linkOptions(req, codec.ResourceLink)))
+}
+
+// marshalRelations writes the 'related' models of the request relation with provided link type. If the 'fieldSet'
+// is nil all the related model fields are marshaled.
+func (s *Server) marshalRelations(rw http.ResponseWriter, req *request, related []mapping.Model, fieldSet mapping.FieldSet, linkType codec.LinkType) {
+	payload := &codec.Payload{ModelStruct: req.relation.Relationship().RelatedModelStruct(), Data: related}
+	if fieldSet != nil {
+		payload.FieldSets = []mapping.FieldSet{fieldSet}
+	}
+	options := []codec.MarshalOption{codec.MarshalWithLinks(s.linkOptions(req, linkType))}
+	if req.relation.Kind() == mapping.KindRelationshipSingle {
+		options = append(options, codec.MarshalSingleModel())
+	}
+	s.marshalPayload(rw, req, http.StatusOK, payload, options...)
+}
+
+// marshalPayload marshals the 'payload' with the request codec and writes it with given 'status'.
+func (s *Server) marshalPayload(rw http.ResponseWriter, req *request, status int, payload *codec.Payload, options ...codec.MarshalOption) {
+	buf := &bytes.Buffer{}
+	if err := req.codec.(codec.PayloadMarshaler).MarshalPayload(buf, payload, options...); err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	rw.Header().Set("Content-Type", req.codec.MimeType())
+	rw.WriteHeader(status)
+	if _, err := rw.Write(buf.Bytes()); err != nil {
+		log.Debugf("Writing response failed: %v", err)
+	}
+}
+
+// unmarshalPayload unmarshals the request body for the 'mStruct' using the codec matching the request content type.
+func (s *Server) unmarshalPayload(req *request, mStruct *mapping.ModelStruct, options ...codec.UnmarshalOption) (*codec.Payload, error) {
+	c, err := s.requestCodec(req.Request)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, codec.UnmarshalWithModelStruct(mStruct), codec.UnmarshalStrictly())
+	return c.(codec.PayloadUnmarshaler).UnmarshalPayload(req.Body, options...)
+}
+
+// linkOptions creates the link options of given 'linkType' for the request.
+func (s *Server) linkOptions(req *request, linkType codec.LinkType) codec.LinkOptions {
+	o := codec.LinkOptions{
+		Type:       linkType,
+		BaseURL:    s.baseURL(req),
+		Collection: req.mStruct.Collection(),
+		RootID:     req.id,
+	}
+	if req.relation != nil {
+		o.RelationField = req.relation.NeuronName()
+	}
+	return o
+}
+
+// baseURL gets the request base url with the server path prefix.
+func (s *Server) baseURL(req *request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host + s.Options.PathPrefix
+}
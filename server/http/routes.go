@@ -0,0 +1,233 @@
+package http
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/server"
+)
+
+// relationshipsSegment is the path segment that precedes the relationship name in the relationship endpoints.
+const relationshipsSegment = "relationships"
+
+// route is the collection route that contains the model struct and it's relations mapped by their names.
+type route struct {
+	mStruct   *mapping.ModelStruct
+	relations map[string]*mapping.StructField
+}
+
+// request is the model endpoint request with resolved route parameters.
+type request struct {
+	*http.Request
+	// codec is the codec used for the response.
+	codec    codec.Codec
+	mStruct  *mapping.ModelStruct
+	id       string
+	relation *mapping.StructField
+	endpoint *server.Endpoint
+}
+
+type handlerFunc func(rw http.ResponseWriter, req *request)
+
+// initializeRoutes creates the routes and the endpoints for the exposed non join models in the model map.
+func (s *Server) initializeRoutes() error {
+	exposed, err := s.exposedModels()
+	if err != nil {
+		return err
+	}
+	s.routes = map[string]*route{}
+	s.endpoints = nil
+	s.endpointsByPath = map[string]*server.Endpoint{}
+	for _, mStruct := range s.ModelMap.Models() {
+		if !exposed[mStruct] {
+			continue
+		}
+		r := &route{mStruct: mStruct, relations: map[string]*mapping.StructField{}}
+		s.routes[mStruct.Collection()] = r
+
+		collectionPath := s.Options.PathPrefix + "/" + mStruct.Collection()
+		resourcePath := collectionPath + "/{id}"
+		s.addEndpoint(collectionPath, http.MethodGet, query.List, mStruct, nil)
+		s.addEndpoint(collectionPath, http.MethodPost, query.Insert, mStruct, nil)
+		s.addEndpoint(resourcePath, http.MethodGet, query.Get, mStruct, nil)
+		s.addEndpoint(resourcePath, http.MethodPatch, query.Update, mStruct, nil)
+		s.addEndpoint(resourcePath, http.MethodDelete, query.Delete, mStruct, nil)
+		for _, relation := range mStruct.RelationFields() {
+			if !exposed[relation.Relationship().RelatedModelStruct()] {
+				continue
+			}
+			r.relations[relation.NeuronName()] = relation
+			relatedPath := resourcePath + "/" + relation.NeuronName()
+			relationshipPath := resourcePath + "/" + relationshipsSegment + "/" + relation.NeuronName()
+			s.addEndpoint(relatedPath, http.MethodGet, query.GetRelated, mStruct, relation)
+			s.addEndpoint(relationshipPath, http.MethodGet, query.GetRelationship, mStruct, relation)
+			s.addEndpoint(relationshipPath, http.MethodPatch, query.UpdateRelationship, mStruct, relation)
+			if relation.Kind() == mapping.KindRelationshipMultiple {
+				s.addEndpoint(relationshipPath, http.MethodPost, query.InsertRelationship, mStruct, relation)
+				s.addEndpoint(relationshipPath, http.MethodDelete, query.DeleteRelationship, mStruct, relation)
+			}
+		}
+	}
+	sort.SliceStable(s.endpoints, func(i, j int) bool {
+		if s.endpoints[i].Path != s.endpoints[j].Path {
+			return s.endpoints[i].Path < s.endpoints[j].Path
+		}
+		return s.endpoints[i].QueryMethod < s.endpoints[j].QueryMethod
+	})
+	return nil
+}
+
+// exposedModels gets the non join models that have the endpoints. If the options models are not defined all
+// the model map models are exposed. The options excluded models are never exposed.
+func (s *Server) exposedModels() (map[*mapping.ModelStruct]bool, error) {
+	exposed := map[*mapping.ModelStruct]bool{}
+	if len(s.Options.Models) == 0 {
+		for _, mStruct := range s.ModelMap.Models() {
+			exposed[mStruct] = true
+		}
+	}
+	for _, model := range s.Options.Models {
+		mStruct, err := s.modelStruct(model)
+		if err != nil {
+			return nil, err
+		}
+		exposed[mStruct] = true
+	}
+	for _, model := range s.Options.ExcludedModels {
+		mStruct, err := s.modelStruct(model)
+		if err != nil {
+			return nil, err
+		}
+		delete(exposed, mStruct)
+	}
+	for mStruct := range exposed {
+		if mStruct.IsJoin() {
+			delete(exposed, mStruct)
+		}
+	}
+	return exposed, nil
+}
+
+// modelStruct gets the model struct of the options 'model'. The model needs to be registered in the model map.
+func (s *Server) modelStruct(model mapping.Model) (*mapping.ModelStruct, error) {
+	modelType := reflect.TypeOf(model)
+	for _, mStruct := range s.ModelMap.Models() {
+		if reflect.PtrTo(mStruct.Type()) == modelType {
+			return mStruct, nil
+		}
+	}
+	return nil, errors.WrapDetf(server.ErrServerOptions, "model: '%T' not found in the model map", model)
+}
+
+func (s *Server) addEndpoint(path, method string, queryMethod query.Method, mStruct *mapping.ModelStruct, relation *mapping.StructField) {
+	log.Debug2f("Endpoint: %s %s", method, path)
+	endpoint := &server.Endpoint{
+		Path:        path,
+		HTTPMethod:  method,
+		QueryMethod: queryMethod,
+		ModelStruct: mStruct,
+		Relation:    relation,
+	}
+	s.endpoints = append(s.endpoints, endpoint)
+	s.endpointsByPath[method+" "+path] = endpoint
+}
+
+// serveHTTP routes the request to the model endpoint handler.
+func (s *Server) serveHTTP(rw http.ResponseWriter, r *http.Request) {
+	req := &request{Request: r, codec: s.Options.Codecs[0]}
+	handler, err := s.route(rw, req)
+	if err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	if req.codec, err = s.responseCodec(r); err != nil {
+		req.codec = s.Options.Codecs[0]
+		s.writeError(rw, req, err)
+		return
+	}
+	if err = s.verifyEndpoint(req); err != nil {
+		s.writeError(rw, req, err)
+		return
+	}
+	handler(rw, req)
+}
+
+// route resolves the request path parameters and gets the handler for the request method.
+func (s *Server) route(rw http.ResponseWriter, req *request) (handlerFunc, error) {
+	path := req.URL.Path
+	if s.Options.PathPrefix != "" {
+		if !strings.HasPrefix(path, s.Options.PathPrefix+"/") {
+			return nil, errors.WrapDetf(ErrEndpointNotFound, "endpoint: '%s' not found", path)
+		}
+		path = strings.TrimPrefix(path, s.Options.PathPrefix)
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	r, ok := s.routes[segments[0]]
+	if !ok {
+		return nil, errors.WrapDetf(ErrEndpointNotFound, "endpoint: '%s' not found", req.URL.Path)
+	}
+	req.mStruct = r.mStruct
+
+	if len(segments) > 1 {
+		req.id = segments[1]
+	}
+	var relationName string
+	switch {
+	case len(segments) == 3:
+		relationName = segments[2]
+	case len(segments) == 4 && segments[2] == relationshipsSegment:
+		relationName = segments[3]
+	case len(segments) > 2:
+		return nil, errors.WrapDetf(ErrEndpointNotFound, "endpoint: '%s' not found", req.URL.Path)
+	}
+	if relationName != "" {
+		if req.relation, ok = r.relations[relationName]; !ok {
+			return nil, errors.WrapDetf(ErrEndpointNotFound, "relation: '%s' not found for the collection: '%s'", relationName, r.mStruct.Collection())
+		}
+	}
+	if req.id == "" && len(segments) > 1 {
+		return nil, errors.WrapDet(server.ErrURIParameter, "empty resource id")
+	}
+
+	handlers := map[string]handlerFunc{}
+	switch len(segments) {
+	case 1:
+		handlers[http.MethodGet] = s.handleList
+		handlers[http.MethodPost] = s.handleInsert
+	case 2:
+		handlers[http.MethodGet] = s.handleGet
+		handlers[http.MethodPatch] = s.handleUpdate
+		handlers[http.MethodDelete] = s.handleDelete
+	case 3:
+		handlers[http.MethodGet] = s.handleGetRelated
+	case 4:
+		handlers[http.MethodGet] = s.handleGetRelationship
+		handlers[http.MethodPatch] = s.handleSetRelationship
+		if req.relation.Kind() == mapping.KindRelationshipMultiple {
+			handlers[http.MethodPost] = s.handleAddRelationship
+			handlers[http.MethodDelete] = s.handleRemoveRelationship
+		}
+	}
+	handler, ok := handlers[req.Method]
+	if !ok {
+		allowed := make([]string, 0, len(handlers))
+		for method := range handlers {
+			allowed = append(allowed, method)
+		}
+		sort.Strings(allowed)
+		rw.Header().Set("Allow", strings.Join(allowed, ", "))
+		return nil, errors.WrapDetf(ErrMethodNotAllowed, "method: '%s' is not allowed for the endpoint: '%s'", req.Method, req.URL.Path)
+	}
+	if len(segments) > 1 {
+		segments[1] = "{id}"
+	}
+	req.endpoint = s.endpointsByPath[req.Method+" "+s.Options.PathPrefix+"/"+strings.Join(segments, "/")]
+	return handler, nil
+}
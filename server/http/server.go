@@ -0,0 +1,124 @@
+// Package http contains the net/http based implementation of the server.Server. It exposes the collection,
+// resource, related and relationship endpoints for all the models mapped in the database.
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/codec/json"
+	"github.com/neuronlabs/neuron/codec/jsonapi"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/server"
+)
+
+// Compile time check for the server interfaces.
+var (
+	_ server.Server      = &Server{}
+	_ server.Initializer = &Server{}
+	_ http.Handler       = &Server{}
+)
+
+// Server is the http server that handles the model endpoints by executing the queries on the database.
+type Server struct {
+	Options *Options
+	// DB is the database used by the endpoints handlers.
+	DB database.DB
+	// ModelMap is the database model map.
+	ModelMap *mapping.ModelMap
+	// HTTPServer is the underlying net/http server. It is created on the server initialization.
+	HTTPServer *http.Server
+
+	handler   http.Handler
+	routes    map[string]*route
+	endpoints []*server.Endpoint
+	// endpointsByPath maps the endpoints by their http method and path.
+	endpointsByPath map[string]*server.Endpoint
+}
+
+// New creates new http server with provided options. The server needs to be initialized with the database
+// before it could serve the requests.
+func New(options ...Option) *Server {
+	o := defaultOptions()
+	for _, option := range options {
+		option(o)
+	}
+	o.PathPrefix = strings.TrimSuffix(o.PathPrefix, "/")
+	return &Server{Options: o}
+}
+
+// InitializeServer implements server.Initializer interface. It creates the endpoints for all the models
+// in the database model map.
+func (s *Server) InitializeServer(db database.DB) error {
+	if db == nil {
+		return errors.WrapDet(server.ErrServerOptions, "provided nil database")
+	}
+	s.DB = db
+	s.ModelMap = db.ModelMap()
+	if len(s.Options.Codecs) == 0 {
+		s.Options.Codecs = []codec.Codec{jsonapi.New(s.ModelMap), json.New(s.ModelMap)}
+	}
+	for _, c := range s.Options.Codecs {
+		if _, ok := c.(codec.PayloadMarshaler); !ok {
+			return errors.WrapDetf(server.ErrServerOptions, "codec: '%s' doesn't implement codec.PayloadMarshaler", c.MimeType())
+		}
+		if _, ok := c.(codec.PayloadUnmarshaler); !ok {
+			return errors.WrapDetf(server.ErrServerOptions, "codec: '%s' doesn't implement codec.PayloadUnmarshaler", c.MimeType())
+		}
+	}
+	if err := s.initializeRoutes(); err != nil {
+		return err
+	}
+
+	middlewares := s.Options.Middlewares
+	if s.Options.Tokener != nil || s.Options.APIKeyAuthenticator != nil {
+		// The request is authenticated first, so that the middlewares could use the context account.
+		middlewares = append(server.MiddlewareChain{s.authenticate}, middlewares...)
+	}
+	s.handler = middlewares.Handle(http.HandlerFunc(s.serveHTTP))
+	s.HTTPServer = &http.Server{
+		Addr:         s.Options.Address,
+		Handler:      s.handler,
+		ReadTimeout:  s.Options.ReadTimeout,
+		WriteTimeout: s.Options.WriteTimeout,
+		IdleTimeout:  s.Options.IdleTimeout,
+	}
+	return nil
+}
+
+// GetEndpoints implements server.EndpointsGetter interface. The endpoint roles and scopes set after the
+// initialization are verified for each endpoint request.
+func (s *Server) GetEndpoints() []*server.Endpoint {
+	return s.endpoints
+}
+
+// Serve implements server.Server interface. It listens on the TCP network address and serves the requests.
+func (s *Server) Serve() error {
+	if s.HTTPServer == nil {
+		return errors.WrapDet(server.ErrServer, "server is not initialized")
+	}
+	log.Infof("Listening and serve on: %s", s.Options.Address)
+	return s.HTTPServer.ListenAndServe()
+}
+
+// Shutdown implements server.Server interface.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.HTTPServer == nil {
+		return nil
+	}
+	return s.HTTPServer.Shutdown(ctx)
+}
+
+// ServeHTTP implements http.Handler interface. It handles the request with all the middlewares applied.
+func (s *Server) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if s.handler == nil {
+		http.Error(rw, "server is not initialized", http.StatusInternalServerError)
+		return
+	}
+	s.handler.ServeHTTP(rw, req)
+}
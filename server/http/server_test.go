@@ -0,0 +1,387 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/codec/jsonapi"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/internal/testmodels"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/repository/memrepo"
+	"github.com/neuronlabs/neuron/server"
+)
+
+func testServer(t *testing.T, options ...Option) *Server {
+	t.Helper()
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(testmodels.Neuron_Models...))
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+
+	s := New(options...)
+	require.NoError(t, s.InitializeServer(db))
+	return s
+}
+
+type testResponse struct {
+	Status int
+	Header http.Header
+	Body   map[string]interface{}
+	Raw    []byte
+}
+
+func doRequest(t *testing.T, s *Server, method, target, body string, headers ...string) *testResponse {
+	t.Helper()
+	var req *http.Request
+	if body == "" {
+		req = httptest.NewRequest(method, target, nil)
+	} else {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", jsonapi.MimeType)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rw := httptest.NewRecorder()
+	s.ServeHTTP(rw, req)
+
+	resp := &testResponse{Status: rw.Code, Header: rw.Header(), Raw: rw.Body.Bytes()}
+	if rw.Body.Len() > 0 && rw.Body.Bytes()[0] == '{' {
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp.Body), rw.Body.String())
+	}
+	return resp
+}
+
+func TestEndpoints(t *testing.T) {
+	s := testServer(t, WithPathPrefix("/v1/"))
+
+	methods := map[string]query.Method{}
+	for _, endpoint := range s.GetEndpoints() {
+		if endpoint.ModelStruct.Collection() == "blogs" {
+			methods[endpoint.HTTPMethod+" "+endpoint.Path] = endpoint.QueryMethod
+		}
+	}
+	expected := map[string]query.Method{
+		"GET /v1/blogs":                                   query.List,
+		"POST /v1/blogs":                                  query.Insert,
+		"GET /v1/blogs/{id}":                              query.Get,
+		"PATCH /v1/blogs/{id}":                            query.Update,
+		"DELETE /v1/blogs/{id}":                           query.Delete,
+		"GET /v1/blogs/{id}/posts":                        query.GetRelated,
+		"GET /v1/blogs/{id}/relationships/posts":          query.GetRelationship,
+		"PATCH /v1/blogs/{id}/relationships/posts":        query.UpdateRelationship,
+		"POST /v1/blogs/{id}/relationships/posts":         query.InsertRelationship,
+		"DELETE /v1/blogs/{id}/relationships/posts":       query.DeleteRelationship,
+		"GET /v1/blogs/{id}/current_post":                 query.GetRelated,
+		"GET /v1/blogs/{id}/relationships/current_post":   query.GetRelationship,
+		"PATCH /v1/blogs/{id}/relationships/current_post": query.UpdateRelationship,
+	}
+	assert.Equal(t, expected, methods)
+}
+
+func TestExposedModels(t *testing.T) {
+	collections := func(s *Server) map[string]bool {
+		result := map[string]bool{}
+		for _, endpoint := range s.GetEndpoints() {
+			result[endpoint.ModelStruct.Collection()] = true
+		}
+		return result
+	}
+	s := testServer(t, WithExcludedModels(&testmodels.Comment{}))
+	exposed := collections(s)
+	assert.True(t, exposed["posts"])
+	assert.False(t, exposed["comments"])
+	for _, endpoint := range s.GetEndpoints() {
+		if endpoint.Relation != nil {
+			assert.NotEqual(t, "comments", endpoint.Relation.Relationship().RelatedModelStruct().Collection(), endpoint.Path)
+		}
+	}
+	resp := doRequest(t, s, http.MethodGet, "/comments", "")
+	assert.Equal(t, http.StatusNotFound, resp.Status)
+	resp = doRequest(t, s, http.MethodGet, "/posts/1/comments", "")
+	assert.Equal(t, http.StatusNotFound, resp.Status)
+
+	s = testServer(t, WithModels(&testmodels.Blog{}, &testmodels.Post{}))
+	assert.Equal(t, map[string]bool{"blogs": true, "posts": true}, collections(s))
+
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(&testmodels.Blog{}, &testmodels.Post{}, &testmodels.Comment{}))
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m))
+	require.NoError(t, err)
+	err = New(WithExcludedModels(&testmodels.TestingModel{})).InitializeServer(db)
+	assert.True(t, errors.Is(err, server.ErrServerOptions))
+}
+
+func TestResources(t *testing.T) {
+	s := testServer(t, WithPathPrefix("/v1"))
+
+	resp := doRequest(t, s, http.MethodPost, "/v1/blogs", `{"data":{"type":"blogs","attributes":{"title":"first"}}}`)
+	require.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+	assert.Equal(t, jsonapi.MimeType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "http://example.com/v1/blogs/1", resp.Header.Get("Location"))
+	data := resp.Body["data"].(map[string]interface{})
+	assert.Equal(t, "1", data["id"])
+	assert.Equal(t, "first", data["attributes"].(map[string]interface{})["title"])
+
+	resp = doRequest(t, s, http.MethodGet, "/v1/blogs/1", "")
+	require.Equal(t, http.StatusOK, resp.Status)
+	assert.Equal(t, "http://example.com/v1/blogs/1", resp.Body["links"].(map[string]interface{})["self"])
+
+	resp = doRequest(t, s, http.MethodPatch, "/v1/blogs/1", `{"data":{"type":"blogs","id":"1","attributes":{"title":"changed"}}}`)
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	data = resp.Body["data"].(map[string]interface{})
+	assert.Equal(t, "changed", data["attributes"].(map[string]interface{})["title"])
+
+	resp = doRequest(t, s, http.MethodPatch, "/v1/blogs/1", `{"data":{"type":"blogs","id":"2","attributes":{"title":"changed"}}}`)
+	assert.Equal(t, http.StatusConflict, resp.Status)
+
+	resp = doRequest(t, s, http.MethodGet, "/v1/blogs", "", "Accept", "application/json")
+	require.Equal(t, http.StatusOK, resp.Status)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(resp.Raw), `"title":"changed"`)
+
+	resp = doRequest(t, s, http.MethodDelete, "/v1/blogs/1", "")
+	require.Equal(t, http.StatusNoContent, resp.Status)
+
+	resp = doRequest(t, s, http.MethodGet, "/v1/blogs/1", "")
+	require.Equal(t, http.StatusNotFound, resp.Status)
+	errs := resp.Body["errors"].([]interface{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "404", errs[0].(map[string]interface{})["status"])
+
+	resp = doRequest(t, s, http.MethodGet, "/v1/blogs", "")
+	require.Equal(t, http.StatusOK, resp.Status)
+	assert.Len(t, resp.Body["data"], 0)
+}
+
+func TestRequestErrors(t *testing.T) {
+	s := testServer(t)
+
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, doRequest(t, s, http.MethodGet, "/unknown", "").Status)
+		assert.Equal(t, http.StatusNotFound, doRequest(t, s, http.MethodGet, "/blogs/1/unknown", "").Status)
+		assert.Equal(t, http.StatusNotFound, doRequest(t, s, http.MethodGet, "/blogs/1/relationships/posts/1", "").Status)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		resp := doRequest(t, s, http.MethodPut, "/blogs", "")
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.Status)
+		assert.Equal(t, "GET, POST", resp.Header.Get("Allow"))
+
+		resp = doRequest(t, s, http.MethodPost, "/blogs/1/relationships/current_post", "")
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.Status)
+	})
+
+	t.Run("NotAcceptable", func(t *testing.T) {
+		resp := doRequest(t, s, http.MethodGet, "/blogs", "", "Accept", "text/html")
+		assert.Equal(t, http.StatusNotAcceptable, resp.Status)
+
+		resp = doRequest(t, s, http.MethodGet, "/blogs", "", "Accept", "text/html, application/*;q=0.5")
+		assert.Equal(t, http.StatusOK, resp.Status)
+	})
+
+	t.Run("UnsupportedMediaType", func(t *testing.T) {
+		resp := doRequest(t, s, http.MethodPost, "/blogs", `{}`, "Content-Type", "text/plain")
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.Status)
+	})
+
+	t.Run("InvalidDocument", func(t *testing.T) {
+		resp := doRequest(t, s, http.MethodPost, "/blogs", `{"data":{"type":"blogs","attributes":{"unknown":1}}}`)
+		require.Equal(t, http.StatusBadRequest, resp.Status)
+		errs := resp.Body["errors"].([]interface{})
+		require.Len(t, errs, 1)
+		assert.NotEmpty(t, errs[0].(map[string]interface{})["detail"])
+	})
+
+	t.Run("ClientID", func(t *testing.T) {
+		resp := doRequest(t, s, http.MethodPost, "/blogs", `{"data":{"type":"blogs","id":"5","attributes":{"title":"client"}}}`)
+		assert.Equal(t, http.StatusForbidden, resp.Status)
+	})
+}
+
+func TestCodecErrors(t *testing.T) {
+	errs := codecErrors(errors.MultiError{
+		errors.WrapDet(auth.ErrPasswordTooShort, "too short").WithDetail("Password is too short."),
+		errors.WrapDet(auth.ErrPasswordCommon, "common").WithDetail("Password is too common."),
+	})
+	require.Len(t, errs, 2)
+	assert.Equal(t, http.StatusBadRequest, errs.Status())
+	assert.Equal(t, "Password is too short.", errs[0].Detail)
+	assert.Equal(t, "Password is too common.", errs[1].Detail)
+}
+
+func TestRelationships(t *testing.T) {
+	s := testServer(t)
+
+	for _, title := range []string{"first", "second", "third"} {
+		resp := doRequest(t, s, http.MethodPost, "/posts", `{"data":{"type":"posts","attributes":{"title":"`+title+`"}}}`)
+		require.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+	}
+	resp := doRequest(t, s, http.MethodPost, "/blogs", `{"data":{"type":"blogs","attributes":{"title":"blog"},"relationships":{"posts":{"data":[{"type":"posts","id":"1"},{"type":"posts","id":"2"}]},"current_post":{"data":{"type":"posts","id":"2"}}}}}`)
+	require.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+
+	relationshipIDs := func(target string) []string {
+		resp := doRequest(t, s, http.MethodGet, target, "")
+		require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+		var ids []string
+		switch data := resp.Body["data"].(type) {
+		case []interface{}:
+			for _, identifier := range data {
+				ids = append(ids, identifier.(map[string]interface{})["id"].(string))
+			}
+		case map[string]interface{}:
+			ids = append(ids, data["id"].(string))
+		}
+		return ids
+	}
+	assert.ElementsMatch(t, []string{"1", "2"}, relationshipIDs("/blogs/1/relationships/posts"))
+	assert.Equal(t, []string{"2"}, relationshipIDs("/blogs/1/relationships/current_post"))
+
+	resp = doRequest(t, s, http.MethodGet, "/blogs/1/current_post", "")
+	require.Equal(t, http.StatusOK, resp.Status)
+	assert.Equal(t, "second", resp.Body["data"].(map[string]interface{})["attributes"].(map[string]interface{})["title"])
+
+	resp = doRequest(t, s, http.MethodPost, "/blogs/1/relationships/posts", `{"data":[{"type":"posts","id":"3"}]}`)
+	require.Equal(t, http.StatusNoContent, resp.Status, resp.Body)
+	assert.ElementsMatch(t, []string{"1", "2", "3"}, relationshipIDs("/blogs/1/relationships/posts"))
+
+	resp = doRequest(t, s, http.MethodDelete, "/blogs/1/relationships/posts", `{"data":[{"type":"posts","id":"1"}]}`)
+	require.Equal(t, http.StatusNoContent, resp.Status, resp.Body)
+	assert.ElementsMatch(t, []string{"2", "3"}, relationshipIDs("/blogs/1/relationships/posts"))
+
+	resp = doRequest(t, s, http.MethodPatch, "/blogs/1/relationships/posts", `{"data":[]}`)
+	require.Equal(t, http.StatusNoContent, resp.Status, resp.Body)
+	assert.Empty(t, relationshipIDs("/blogs/1/relationships/posts"))
+
+	resp = doRequest(t, s, http.MethodPatch, "/blogs/1/relationships/current_post", `{"data":{"type":"posts","id":"3"}}`)
+	require.Equal(t, http.StatusNoContent, resp.Status, resp.Body)
+	assert.Equal(t, []string{"3"}, relationshipIDs("/blogs/1/relationships/current_post"))
+
+	resp = doRequest(t, s, http.MethodGet, "/blogs/2/relationships/posts", "")
+	assert.Equal(t, http.StatusNotFound, resp.Status)
+}
+
+func TestQueryParameters(t *testing.T) {
+	s := testServer(t)
+
+	for _, title := range []string{"b", "c", "a"} {
+		resp := doRequest(t, s, http.MethodPost, "/posts", `{"data":{"type":"posts","attributes":{"title":"`+title+`","body":"body"}}}`)
+		require.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+	}
+	resp := doRequest(t, s, http.MethodPost, "/blogs", `{"data":{"type":"blogs","attributes":{"title":"blog"},"relationships":{"posts":{"data":[{"type":"posts","id":"1"},{"type":"posts","id":"2"}]}}}}`)
+	require.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+
+	titles := func(data interface{}) []string {
+		var result []string
+		for _, resource := range data.([]interface{}) {
+			result = append(result, resource.(map[string]interface{})["attributes"].(map[string]interface{})["title"].(string))
+		}
+		return result
+	}
+
+	resp = doRequest(t, s, http.MethodGet, "/posts?sort=-title&page[limit]=2", "")
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	assert.Equal(t, []string{"c", "b"}, titles(resp.Body["data"]))
+
+	resp = doRequest(t, s, http.MethodGet, "/posts?filter[title][$in]=a,c&sort=title", "")
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	assert.Equal(t, []string{"a", "c"}, titles(resp.Body["data"]))
+
+	resp = doRequest(t, s, http.MethodGet, "/posts?fields[posts]=title", "")
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	for _, resource := range resp.Body["data"].([]interface{}) {
+		assert.Len(t, resource.(map[string]interface{})["attributes"], 1)
+	}
+
+	resp = doRequest(t, s, http.MethodGet, "/blogs/1?include=posts&fields[posts]=title", "")
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	assert.ElementsMatch(t, []string{"b", "c"}, titles(resp.Body["included"]))
+
+	resp = doRequest(t, s, http.MethodGet, "/posts?filter[unknown]=1", "")
+	require.Equal(t, http.StatusBadRequest, resp.Status)
+	errs := resp.Body["errors"].([]interface{})
+	require.Len(t, errs, 1)
+	assert.NotEmpty(t, errs[0].(map[string]interface{})["detail"])
+}
+
+// pruningAuthorizer removes the 'field' from the read queries field sets.
+type pruningAuthorizer struct {
+	field *mapping.StructField
+}
+
+func (a *pruningAuthorizer) AuthorizeQuery(_ context.Context, _ database.DB, method query.Method, s *query.Scope) error {
+	if s.ModelStruct != a.field.Struct() || (method != query.List && method != query.Get) {
+		return nil
+	}
+	if len(s.FieldSets) == 0 {
+		s.FieldSets = []mapping.FieldSet{s.ModelStruct.Fields()}
+	}
+	fieldSet := mapping.FieldSet{}
+	for _, field := range s.FieldSets[0] {
+		if field != a.field {
+			fieldSet = append(fieldSet, field)
+		}
+	}
+	s.FieldSets[0] = fieldSet
+	return nil
+}
+
+func TestPrunedFieldSets(t *testing.T) {
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(testmodels.Neuron_Models...))
+	authorizer := &pruningAuthorizer{field: m.MustModelStruct(&testmodels.Post{}).MustFieldByName("Body")}
+	db, err := database.New(database.WithDefaultRepository(memrepo.New()), database.WithModelMap(m), database.WithQueryAuthorizer(authorizer))
+	require.NoError(t, err)
+	require.NoError(t, db.Dial(context.Background()))
+	s := New()
+	require.NoError(t, s.InitializeServer(db))
+
+	attributes := func(resp *testResponse) map[string]interface{} {
+		return resp.Body["data"].(map[string]interface{})["attributes"].(map[string]interface{})
+	}
+
+	resp := doRequest(t, s, http.MethodPost, "/posts", `{"data":{"type":"posts","attributes":{"title":"title","body":"secret"}}}`)
+	require.Equal(t, http.StatusCreated, resp.Status, resp.Body)
+	assert.Equal(t, "title", attributes(resp)["title"])
+	assert.NotContains(t, attributes(resp), "body")
+
+	resp = doRequest(t, s, http.MethodGet, "/posts/1", "")
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	assert.NotContains(t, attributes(resp), "body")
+	assert.Contains(t, resp.Body["data"].(map[string]interface{}), "relationships")
+
+	resp = doRequest(t, s, http.MethodGet, "/posts?fields[posts]=title,body", "")
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	for _, resource := range resp.Body["data"].([]interface{}) {
+		assert.Equal(t, map[string]interface{}{"title": "title"}, resource.(map[string]interface{})["attributes"])
+	}
+	assert.NotContains(t, string(resp.Raw), "secret")
+
+	blogs := m.MustModelStruct(&testmodels.Blog{})
+	require.NoError(t, db.Insert(context.Background(), blogs, &testmodels.Blog{Title: "blog", CurrentPostID: 1}))
+	_, err = db.QueryCtx(context.Background(), m.MustModelStruct(&testmodels.Post{}), &testmodels.Post{ID: 1, BlogID: 1}).
+		Select(m.MustModelStruct(&testmodels.Post{}).MustFieldByName("BlogID")).Update()
+	require.NoError(t, err)
+
+	resp = doRequest(t, s, http.MethodGet, "/blogs/1/current_post", "")
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	assert.Equal(t, "title", attributes(resp)["title"])
+	assert.NotContains(t, attributes(resp), "body")
+
+	resp = doRequest(t, s, http.MethodGet, "/blogs/1/posts", "")
+	require.Equal(t, http.StatusOK, resp.Status, resp.Body)
+	require.Len(t, resp.Body["data"], 1)
+	assert.Equal(t, map[string]interface{}{"title": "title"}, resp.Body["data"].([]interface{})[0].(map[string]interface{})["attributes"])
+}
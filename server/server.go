@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+
+	"github.com/neuronlabs/neuron/database"
 )
 
 // Server is the interface used
@@ -19,3 +21,9 @@ type EndpointsGetter interface {
 	// GetEndpoints is a method that gets server endpoints after initialization process.
 	GetEndpoints() []*Endpoint
 }
+
+// Initializer is the interface implemented by the servers that needs to be initialized with the service database.
+// The service calls it after the database is created.
+type Initializer interface {
+	InitializeServer(db database.DB) error
+}
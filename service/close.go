@@ -65,7 +65,7 @@ func (s *Service) closeJobsCreator(ctx context.Context, wg *sync.WaitGroup) <-ch
 		}
 
 		// Close all stores.
-		for _, s := range s.Stores {
+		for _, s := range s.stores() {
 			closer, isCloser := s.(Closer)
 			if !isCloser {
 				continue
@@ -79,7 +79,7 @@ func (s *Service) closeJobsCreator(ctx context.Context, wg *sync.WaitGroup) <-ch
 		}
 
 		// Close all file stores.
-		for _, s := range s.FileStores {
+		for _, s := range s.fileStores() {
 			closer, isCloser := s.(Closer)
 			if !isCloser {
 				continue
@@ -64,7 +64,7 @@ func (s *Service) dialJobsCreator(ctx context.Context, wg *sync.WaitGroup) <-cha
 			out <- dialer
 		}
 		// Iterate over stores and try to establish connection.
-		for _, s := range s.Stores {
+		for _, s := range s.stores() {
 			dialer, isDialer := s.(Dialer)
 			if !isDialer {
 				continue
@@ -78,7 +78,7 @@ func (s *Service) dialJobsCreator(ctx context.Context, wg *sync.WaitGroup) <-cha
 		}
 
 		// Iterate over file stores.
-		for _, s := range s.FileStores {
+		for _, s := range s.fileStores() {
 			dialer, isDialer := s.(Dialer)
 			if !isDialer {
 				continue
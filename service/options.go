@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
 	"github.com/neuronlabs/neuron/filestore"
 	"github.com/neuronlabs/neuron/mapping"
 	"github.com/neuronlabs/neuron/repository"
@@ -18,6 +19,7 @@ type Options struct {
 	DefaultRepository      repository.Repository
 	RepositoryModels       map[repository.Repository][]mapping.Model
 	SynchronousConnections bool
+	QueryAuthorizer        database.QueryAuthorizer
 
 	// Key-value stores.
 	DefaultStore store.Store
@@ -130,6 +132,13 @@ func WithNamingConvention(convention mapping.NamingConvention) Option {
 	}
 }
 
+// WithQueryAuthorizer sets the authorizer of all the service database queries.
+func WithQueryAuthorizer(authorizer database.QueryAuthorizer) Option {
+	return func(o *Options) {
+		o.QueryAuthorizer = authorizer
+	}
+}
+
 // WithRepositoryModels maps the repository 'r' to provided 'models'.
 func WithRepositoryModels(r repository.Repository, models ...mapping.Model) Option {
 	return func(o *Options) {
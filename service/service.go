@@ -87,6 +87,7 @@ func New(options ...Option) (*Service, error) {
 			database.WithModelMap(svc.ModelMap),
 			database.WithMigrateModels(o.MigrateModels...),
 			database.WithDefaultRepository(o.DefaultRepository),
+			database.WithQueryAuthorizer(o.QueryAuthorizer),
 		}
 		for repo, repoModels := range o.RepositoryModels {
 			databaseOptions = append(databaseOptions, database.WithRepositoryModels(repo, repoModels...))
@@ -97,6 +98,15 @@ func New(options ...Option) (*Service, error) {
 			return nil, err
 		}
 	}
+
+	if initializer, ok := svc.Server.(server.Initializer); ok {
+		if svc.DB == nil {
+			return nil, errors.WrapDet(server.ErrServerOptions, "server requires the database but no repository is defined")
+		}
+		if err := initializer.InitializeServer(svc.DB); err != nil {
+			return nil, err
+		}
+	}
 	return svc, nil
 }
 
@@ -139,3 +149,35 @@ func (s *Service) Run(ctx context.Context) error {
 	log.Info("Server had shutdown successfully.")
 	return nil
 }
+
+// stores gets all the service stores along with the default store.
+func (s *Service) stores() []store.Store {
+	stores := make([]store.Store, 0, len(s.Stores)+1)
+	isDefaultNamed := s.DefaultStore == nil
+	for _, st := range s.Stores {
+		if st == s.DefaultStore {
+			isDefaultNamed = true
+		}
+		stores = append(stores, st)
+	}
+	if !isDefaultNamed {
+		stores = append(stores, s.DefaultStore)
+	}
+	return stores
+}
+
+// fileStores gets all the service file stores along with the default file store.
+func (s *Service) fileStores() []filestore.Store {
+	fileStores := make([]filestore.Store, 0, len(s.FileStores)+1)
+	isDefaultNamed := s.DefaultFileStore == nil
+	for _, st := range s.FileStores {
+		if st == s.DefaultFileStore {
+			isDefaultNamed = true
+		}
+		fileStores = append(fileStores, st)
+	}
+	if !isDefaultNamed {
+		fileStores = append(fileStores, s.DefaultFileStore)
+	}
+	return fileStores
+}
@@ -10,6 +10,8 @@ var (
 	// ErrRecordNotFound is the error when the value stored with 'key' is not found.
 	// This should be implemented by all stores.
 	ErrRecordNotFound = errors.Wrap(ErrStore, "record not found")
+	// ErrPreconditionFailed is the error returned when the conditional set precondition is not satisfied.
+	ErrPreconditionFailed = errors.Wrap(ErrStore, "precondition failed")
 	// ErrInitialization is the error returned when the store have some issues with initialization.
 	ErrInitialization = errors.Wrap(ErrStore, "initialization")
 	// ErrInternal is an internal error for the stores.
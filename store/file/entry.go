@@ -0,0 +1,29 @@
+package file
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/store"
+)
+
+const (
+	operationSet    = "set"
+	operationDelete = "delete"
+)
+
+// entry is a single line of the store log file.
+type entry struct {
+	Operation string     `json:"op"`
+	Key       string     `json:"key"`
+	Value     []byte     `json:"value,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// expiresAt gets the 'record' expiration time for the log entry.
+func expiresAt(record *store.Record) *time.Time {
+	if record.ExpiresAt.IsZero() {
+		return nil
+	}
+	t := record.ExpiresAt
+	return &t
+}
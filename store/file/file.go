@@ -0,0 +1,484 @@
+// Package file implements the key value store persisted in the local file.
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+)
+
+// Compile time check for the store.Store and store.ConditionalSetter interfaces.
+var (
+	_ store.Store             = &File{}
+	_ store.ConditionalSetter = &File{}
+)
+
+// compactionMinimum is the minimum number of the obsolete log entries that triggers the compaction.
+const compactionMinimum = 1024
+
+// File is the store.Store implementation that keeps its records in memory and persists them in the append-only log
+// file defined in the options FileName. Each change is appended to the log and synced to the disk before the
+// function returns. The log is compacted on Dial, Close, each cleanup interval and when the number of obsolete
+// entries exceeds the number of stored records. The compaction writes the records into the temporary file which
+// atomically replaces the log. The expired records are dropped on load.
+// Before usage the store needs to be opened with the Dial method and closed with Close. The closed store could be
+// dialed again.
+type File struct {
+	Options *store.Options
+
+	lock    sync.Mutex
+	records map[string]*store.Record
+	file    *os.File
+	// obsolete is the number of the log entries that doesn't define the current records.
+	obsolete int
+
+	// closeOnce, done and stopped are created on each Dial and used to stop the cleanup goroutine.
+	closeOnce *sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+// New creates new file store. The options FileName is required.
+func New(options ...store.Option) *File {
+	o := store.DefaultOptions()
+	for _, option := range options {
+		option(o)
+	}
+	if o.TimeFunc == nil {
+		o.TimeFunc = time.Now
+	}
+	return &File{
+		Options: o,
+		records: map[string]*store.Record{},
+	}
+}
+
+// Dial loads the records from the log file, compacts it and opens it for appending. Implements service.Dialer interface.
+func (f *File) Dial(_ context.Context) error {
+	if f.Options.FileName == "" {
+		return errors.WrapDet(store.ErrInitialization, "no file name defined for the file store")
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file != nil {
+		return errors.WrapDetf(store.ErrInitialization, "file store: '%s' is already opened", f.Options.FileName)
+	}
+	// The records are loaded again from the log file, so that the store could be dialed after it was closed.
+	f.records = map[string]*store.Record{}
+	f.obsolete = 0
+	if err := f.load(); err != nil {
+		return err
+	}
+	if err := f.compact(); err != nil {
+		return err
+	}
+	f.closeOnce = &sync.Once{}
+	f.done = make(chan struct{})
+	f.stopped = make(chan struct{})
+	if f.Options.CleanupInterval > 0 {
+		go f.cleanup(f.done, f.stopped)
+	} else {
+		close(f.stopped)
+	}
+	return nil
+}
+
+// Close stops the cleanup goroutine, compacts the log and closes the file. Implements service.Closer interface.
+func (f *File) Close(ctx context.Context) error {
+	f.lock.Lock()
+	opened := f.file != nil
+	closeOnce, done, stopped := f.closeOnce, f.done, f.stopped
+	f.lock.Unlock()
+	if !opened {
+		return nil
+	}
+	closeOnce.Do(func() {
+		close(done)
+	})
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	f.deleteExpired()
+	if err := f.compact(); err != nil {
+		return err
+	}
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return errors.WrapDetf(store.ErrInternal, "closing file store: '%s' failed: %v", f.Options.FileName, err)
+	}
+	return nil
+}
+
+// Set implements store.Store interface. If the 'options' doesn't define the TTL the default expiration is used.
+// A negative TTL or default expiration means that the record never expires.
+func (f *File) Set(_ context.Context, record *store.Record, options ...store.SetOption) error {
+	return f.set(record, nil, options)
+}
+
+// SetIfNotExists implements store.ConditionalSetter interface. The record is set only if no non expired record is
+// stored with its key.
+func (f *File) SetIfNotExists(_ context.Context, record *store.Record, options ...store.SetOption) error {
+	return f.set(record, func(stored *store.Record) error {
+		if stored != nil {
+			return errors.WrapDetf(store.ErrPreconditionFailed, "record: '%s' already exists", record.Key)
+		}
+		return nil
+	}, options)
+}
+
+// SetIfValue implements store.ConditionalSetter interface. The record is set only if the non expired record
+// stored with its key has the 'value'.
+func (f *File) SetIfValue(_ context.Context, record *store.Record, value []byte, options ...store.SetOption) error {
+	return f.set(record, func(stored *store.Record) error {
+		if stored == nil || !bytes.Equal(stored.Value, value) {
+			return errors.WrapDetf(store.ErrPreconditionFailed, "record: '%s' value has changed", record.Key)
+		}
+		return nil
+	}, options)
+}
+
+// set appends the 'record' if the optional 'check' of the stored record passes. The stored record is nil if there
+// is no non expired record with the same key.
+func (f *File) set(record *store.Record, check func(stored *store.Record) error, options []store.SetOption) error {
+	if record == nil {
+		return errors.WrapDet(store.ErrStore, "provided nil record")
+	}
+	o := &store.SetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	cp := record.Copy()
+	cp.Key = f.key(record.Key)
+
+	ttl := o.TTL
+	if ttl == 0 && cp.ExpiresAt.IsZero() {
+		ttl = f.Options.DefaultExpiration
+	}
+	if ttl > 0 {
+		cp.ExpiresAt = f.Options.TimeFunc().Add(ttl)
+	} else if ttl < 0 {
+		cp.ExpiresAt = time.Time{}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	stored, ok := f.records[cp.Key]
+	if !ok || f.isExpired(stored) {
+		stored = nil
+	}
+	if check != nil {
+		if err := check(stored); err != nil {
+			return err
+		}
+	}
+	if err := f.append(&entry{Operation: operationSet, Key: cp.Key, Value: cp.Value, ExpiresAt: expiresAt(cp)}); err != nil {
+		return err
+	}
+	if _, ok := f.records[cp.Key]; ok {
+		f.obsolete++
+	}
+	f.records[cp.Key] = cp
+	return f.compactIfNeeded()
+}
+
+// Get implements store.Store interface.
+func (f *File) Get(_ context.Context, key string) (*store.Record, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return nil, errNotOpened()
+	}
+	record, ok := f.records[f.key(key)]
+	if !ok || f.isExpired(record) {
+		return nil, errors.WrapDetf(store.ErrRecordNotFound, "record: '%s' not found", key)
+	}
+	return f.recordCopy(record), nil
+}
+
+// Delete implements store.Store interface.
+func (f *File) Delete(_ context.Context, key string) error {
+	fullKey := f.key(key)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return errNotOpened()
+	}
+	record, ok := f.records[fullKey]
+	if !ok || f.isExpired(record) {
+		return errors.WrapDetf(store.ErrRecordNotFound, "record: '%s' not found", key)
+	}
+	if err := f.append(&entry{Operation: operationDelete, Key: fullKey}); err != nil {
+		return err
+	}
+	delete(f.records, fullKey)
+	// Both the set and the delete entries are obsolete now.
+	f.obsolete += 2
+	return f.compactIfNeeded()
+}
+
+// Find implements store.Store interface. The records are sorted by their keys. The pattern prefix and suffix are
+// matched with the record keys without the store prefix and suffix.
+func (f *File) Find(_ context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	pattern := &store.FindPattern{}
+	for _, option := range options {
+		option(pattern)
+	}
+	if pattern.Limit < 0 || pattern.Offset < 0 {
+		return nil, errors.WrapDetf(store.ErrStore, "invalid find pattern limit: %d or offset: %d", pattern.Limit, pattern.Offset)
+	}
+
+	f.lock.Lock()
+	if f.file == nil {
+		f.lock.Unlock()
+		return nil, errNotOpened()
+	}
+	var records []*store.Record
+	for _, record := range f.records {
+		if f.isExpired(record) {
+			continue
+		}
+		key := f.trimKey(record.Key)
+		if strings.HasPrefix(key, pattern.Prefix) && strings.HasSuffix(key, pattern.Suffix) {
+			records = append(records, f.recordCopy(record))
+		}
+	}
+	f.lock.Unlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	if pattern.Offset >= len(records) {
+		return []*store.Record{}, nil
+	}
+	records = records[pattern.Offset:]
+	if pattern.Limit > 0 && pattern.Limit < len(records) {
+		records = records[:pattern.Limit]
+	}
+	return records, nil
+}
+
+// DeleteExpired deletes all expired records from the store.
+func (f *File) DeleteExpired() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.deleteExpired()
+}
+
+func (f *File) deleteExpired() {
+	var deleted int
+	for key, record := range f.records {
+		if f.isExpired(record) {
+			delete(f.records, key)
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		// The expired records doesn't need the delete entries, they would be dropped on load or compaction.
+		f.obsolete += deleted
+		log.Debug2f("File store deleted: %d expired records", deleted)
+	}
+}
+
+func (f *File) cleanup(done <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(f.Options.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.lock.Lock()
+			f.deleteExpired()
+			if f.obsolete > 0 {
+				if err := f.compact(); err != nil {
+					log.Errorf("File store: '%s' compaction failed: %v", f.Options.FileName, err)
+				}
+			}
+			f.lock.Unlock()
+		case <-done:
+			return
+		}
+	}
+}
+
+// load reads the log file entries into the records. The entry that is not terminated with the new line is the
+// result of the interrupted write and is omitted.
+func (f *File) load() error {
+	file, err := os.Open(f.Options.FileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.WrapDetf(store.ErrInitialization, "opening file store: '%s' failed: %v", f.Options.FileName, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(data) > 0 {
+				log.Warningf("File store: '%s' omits incomplete entry at line: %d", f.Options.FileName, line)
+			}
+			return nil
+		}
+		if err != nil {
+			return errors.WrapDetf(store.ErrInitialization, "reading file store: '%s' failed: %v", f.Options.FileName, err)
+		}
+		e := &entry{}
+		if err = json.Unmarshal(data, e); err != nil {
+			return errors.WrapDetf(store.ErrInitialization, "file store: '%s' contains invalid entry at line: %d - %v", f.Options.FileName, line, err)
+		}
+		switch e.Operation {
+		case operationSet:
+			record := &store.Record{Key: e.Key, Value: e.Value}
+			if e.ExpiresAt != nil {
+				record.ExpiresAt = *e.ExpiresAt
+			}
+			if f.isExpired(record) {
+				delete(f.records, e.Key)
+				continue
+			}
+			f.records[e.Key] = record
+		case operationDelete:
+			delete(f.records, e.Key)
+		default:
+			return errors.WrapDetf(store.ErrInitialization, "file store: '%s' contains unknown operation: '%s' at line: %d", f.Options.FileName, e.Operation, line)
+		}
+	}
+}
+
+// compact writes all records to the temporary file that replaces the log file. The log file is then reopened for
+// appending.
+func (f *File) compact() error {
+	tempName := f.Options.FileName + ".tmp"
+	temp, err := os.OpenFile(tempName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.WrapDetf(store.ErrInternal, "creating file store: '%s' compaction file failed: %v", f.Options.FileName, err)
+	}
+	keys := make([]string, 0, len(f.records))
+	for key := range f.records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	writer := bufio.NewWriter(temp)
+	encoder := json.NewEncoder(writer)
+	for _, key := range keys {
+		record := f.records[key]
+		if err = encoder.Encode(&entry{Operation: operationSet, Key: record.Key, Value: record.Value, ExpiresAt: expiresAt(record)}); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = temp.Sync()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempName, f.Options.FileName)
+	}
+	if err != nil {
+		os.Remove(tempName)
+		return errors.WrapDetf(store.ErrInternal, "compacting file store: '%s' failed: %v", f.Options.FileName, err)
+	}
+	syncDir(filepath.Dir(f.Options.FileName))
+
+	if f.file != nil {
+		f.file.Close()
+	}
+	if f.file, err = os.OpenFile(f.Options.FileName, os.O_APPEND|os.O_WRONLY, 0600); err != nil {
+		return errors.WrapDetf(store.ErrInternal, "opening file store: '%s' failed: %v", f.Options.FileName, err)
+	}
+	f.obsolete = 0
+	return nil
+}
+
+func (f *File) compactIfNeeded() error {
+	if f.obsolete < compactionMinimum || f.obsolete < len(f.records) {
+		return nil
+	}
+	return f.compact()
+}
+
+// append writes the entry at the end of the log file and syncs it with the disk.
+func (f *File) append(e *entry) error {
+	if f.file == nil {
+		return errNotOpened()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return errors.WrapDetf(store.ErrInternal, "marshaling file store entry failed: %v", err)
+	}
+	data = append(data, '\n')
+	if _, err = f.file.Write(data); err == nil {
+		err = f.file.Sync()
+	}
+	if err != nil {
+		return errors.WrapDetf(store.ErrInternal, "writing file store: '%s' entry failed: %v", f.Options.FileName, err)
+	}
+	return nil
+}
+
+func (f *File) isExpired(record *store.Record) bool {
+	return !record.ExpiresAt.IsZero() && !f.Options.TimeFunc().Before(record.ExpiresAt)
+}
+
+// key creates the store key with the options prefix and suffix.
+func (f *File) key(key string) string {
+	return f.Options.Prefix + key + f.Options.Suffix
+}
+
+// trimKey trims the options prefix and suffix from the store 'key'.
+func (f *File) trimKey(key string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(key, f.Options.Prefix), f.Options.Suffix)
+}
+
+// recordCopy creates the 'record' copy with the key without the options prefix and suffix.
+func (f *File) recordCopy(record *store.Record) *store.Record {
+	cp := record.Copy()
+	cp.Key = f.trimKey(record.Key)
+	return cp
+}
+
+func errNotOpened() error {
+	return errors.WrapDet(store.ErrStore, "file store is not opened")
+}
+
+// syncDir syncs the directory so that the renamed file is persisted. Not all platforms supports syncing
+// directories thus the errors are only logged.
+func syncDir(name string) {
+	dir, err := os.Open(name)
+	if err != nil {
+		log.Debugf("Opening file store directory: '%s' failed: %v", name, err)
+		return
+	}
+	if err = dir.Sync(); err != nil {
+		log.Debugf("Syncing file store directory: '%s' failed: %v", name, err)
+	}
+	dir.Close()
+}
@@ -0,0 +1,209 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func testDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "neuron-store")
+	require.NoError(t, err)
+	return dir, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func openStore(t *testing.T, options ...store.Option) *File {
+	t.Helper()
+	f := New(options...)
+	require.NoError(t, f.Dial(context.Background()))
+	return f
+}
+
+func TestPersistence(t *testing.T) {
+	dir, cleanup := testDir(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	clock := &testClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	options := []store.Option{store.WithFileName(filepath.Join(dir, "store.log")), store.WithTimeFunc(clock.Now), store.WithPrefix("pre_")}
+
+	f := openStore(t, options...)
+	require.NoError(t, f.Set(ctx, &store.Record{Key: "persistent", Value: []byte("value")}))
+	require.NoError(t, f.Set(ctx, &store.Record{Key: "expiring", Value: []byte("value")}, store.SetWithTTL(time.Minute)))
+	require.NoError(t, f.Set(ctx, &store.Record{Key: "deleted", Value: []byte("value")}))
+	require.NoError(t, f.Set(ctx, &store.Record{Key: "persistent", Value: []byte("changed")}))
+	require.NoError(t, f.Delete(ctx, "deleted"))
+
+	// Reopen the store without closing - as after the crash.
+	f = openStore(t, options...)
+	record, err := f.Get(ctx, "persistent")
+	require.NoError(t, err)
+	assert.Equal(t, "persistent", record.Key)
+	assert.Equal(t, []byte("changed"), record.Value)
+
+	record, err = f.Get(ctx, "expiring")
+	require.NoError(t, err)
+	assert.Equal(t, clock.now.Add(time.Minute), record.ExpiresAt)
+
+	_, err = f.Get(ctx, "deleted")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+
+	records, err := f.Find(ctx, store.FindWithSuffix("ing"))
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	clock.now = clock.now.Add(time.Hour)
+	require.NoError(t, f.Close(ctx))
+
+	// The expired records are dropped.
+	f = openStore(t, options...)
+	_, err = f.Get(ctx, "expiring")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+	assert.Len(t, f.records, 1)
+	require.NoError(t, f.Close(ctx))
+
+	// The compacted log contains only a single record.
+	data, err := ioutil.ReadFile(f.Options.FileName)
+	require.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count(data, []byte("\n")))
+}
+
+func TestConditionalSet(t *testing.T) {
+	dir, cleanup := testDir(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	f := openStore(t, store.WithFileName(filepath.Join(dir, "store.log")))
+	require.NoError(t, f.SetIfNotExists(ctx, &store.Record{Key: "key", Value: []byte("1")}))
+	err := f.SetIfNotExists(ctx, &store.Record{Key: "key", Value: []byte("2")})
+	assert.True(t, errors.Is(err, store.ErrPreconditionFailed))
+
+	err = f.SetIfValue(ctx, &store.Record{Key: "key", Value: []byte("2")}, []byte("0"))
+	assert.True(t, errors.Is(err, store.ErrPreconditionFailed))
+	require.NoError(t, f.SetIfValue(ctx, &store.Record{Key: "key", Value: []byte("2")}, []byte("1")))
+	require.NoError(t, f.Close(ctx))
+
+	// The failed sets are not persisted.
+	require.NoError(t, f.Dial(ctx))
+	record, err := f.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), record.Value)
+	require.NoError(t, f.Close(ctx))
+}
+
+func TestIncompleteEntry(t *testing.T) {
+	dir, cleanup := testDir(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	fileName := filepath.Join(dir, "store.log")
+	f := openStore(t, store.WithFileName(fileName))
+	require.NoError(t, f.Set(ctx, &store.Record{Key: "key", Value: []byte("value")}))
+	require.NoError(t, f.file.Close())
+
+	// Simulate the interrupted write.
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"op":"set","key":"torn","val`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	f = openStore(t, store.WithFileName(fileName))
+	_, err = f.Get(ctx, "key")
+	assert.NoError(t, err)
+	_, err = f.Get(ctx, "torn")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+	require.NoError(t, f.Close(ctx))
+
+	// Invalid complete entry is an initialization error.
+	require.NoError(t, ioutil.WriteFile(fileName, []byte("invalid\n"), 0600))
+	err = New(store.WithFileName(fileName)).Dial(ctx)
+	assert.True(t, errors.Is(err, store.ErrInitialization))
+}
+
+func TestCompaction(t *testing.T) {
+	dir, cleanup := testDir(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	f := openStore(t, store.WithFileName(filepath.Join(dir, "store.log")))
+	defer f.Close(ctx)
+
+	for i := 0; i < compactionMinimum+1; i++ {
+		require.NoError(t, f.Set(ctx, &store.Record{Key: "key", Value: []byte("value")}))
+	}
+	assert.Equal(t, 0, f.obsolete)
+
+	info, err := os.Stat(f.Options.FileName)
+	require.NoError(t, err)
+	assert.True(t, info.Size() < 100)
+}
+
+func TestNotOpened(t *testing.T) {
+	ctx := context.Background()
+	f := New()
+	assert.True(t, errors.Is(f.Dial(ctx), store.ErrInitialization))
+	assert.Error(t, f.Set(ctx, &store.Record{Key: "key"}))
+	_, err := f.Get(ctx, "key")
+	assert.Error(t, err)
+	assert.NoError(t, f.Close(ctx))
+}
+
+func TestRedial(t *testing.T) {
+	dir, cleanup := testDir(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for _, interval := range []time.Duration{0, time.Hour} {
+		f := openStore(t, store.WithFileName(filepath.Join(dir, "store.log")), store.WithCleanupInterval(interval))
+		require.NoError(t, f.Set(ctx, &store.Record{Key: "key", Value: []byte("value")}))
+		assert.True(t, errors.Is(f.Dial(ctx), store.ErrInitialization))
+		require.NoError(t, f.Close(ctx))
+
+		require.NoError(t, f.Dial(ctx))
+		record, err := f.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), record.Value)
+		require.NoError(t, f.Close(ctx))
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	dir, cleanup := testDir(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	f := openStore(t, store.WithFileName(filepath.Join(dir, "store.log")), store.WithCleanupInterval(time.Millisecond))
+	require.NoError(t, f.Set(ctx, &store.Record{Key: "key"}, store.SetWithTTL(time.Millisecond)))
+	assert.Eventually(t, func() bool {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		return len(f.records) == 0 && f.obsolete == 0
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, f.Close(ctx))
+	require.NoError(t, f.Close(ctx))
+}
@@ -0,0 +1,254 @@
+// Package memory implements the in-memory key value store.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+)
+
+// Compile time check for the store.Store and store.ConditionalSetter interfaces.
+var (
+	_ store.Store             = &Memory{}
+	_ store.ConditionalSetter = &Memory{}
+)
+
+// Memory is the in-memory store.Store implementation. The records are stored with the options prefix and suffix and
+// expire after their TTL or the options default expiration. If the options cleanup interval is greater than zero,
+// the expired records are periodically deleted by the background goroutine, which is stopped on Close.
+type Memory struct {
+	Options *store.Options
+
+	lock    sync.RWMutex
+	records map[string]*store.Record
+
+	closeOnce sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+// New creates new in-memory store.
+func New(options ...store.Option) *Memory {
+	o := store.DefaultOptions()
+	for _, option := range options {
+		option(o)
+	}
+	if o.TimeFunc == nil {
+		o.TimeFunc = time.Now
+	}
+	m := &Memory{
+		Options: o,
+		records: map[string]*store.Record{},
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if o.CleanupInterval > 0 {
+		go m.cleanup()
+	} else {
+		close(m.stopped)
+	}
+	return m
+}
+
+// Set implements store.Store interface. If the 'options' doesn't define the TTL the default expiration is used.
+// A negative TTL or default expiration means that the record never expires.
+func (m *Memory) Set(_ context.Context, record *store.Record, options ...store.SetOption) error {
+	return m.set(record, nil, options)
+}
+
+// SetIfNotExists implements store.ConditionalSetter interface. The record is set only if no non expired record is
+// stored with its key.
+func (m *Memory) SetIfNotExists(_ context.Context, record *store.Record, options ...store.SetOption) error {
+	return m.set(record, func(stored *store.Record) error {
+		if stored != nil {
+			return errors.WrapDetf(store.ErrPreconditionFailed, "record: '%s' already exists", record.Key)
+		}
+		return nil
+	}, options)
+}
+
+// SetIfValue implements store.ConditionalSetter interface. The record is set only if the non expired record
+// stored with its key has the 'value'.
+func (m *Memory) SetIfValue(_ context.Context, record *store.Record, value []byte, options ...store.SetOption) error {
+	return m.set(record, func(stored *store.Record) error {
+		if stored == nil || !bytes.Equal(stored.Value, value) {
+			return errors.WrapDetf(store.ErrPreconditionFailed, "record: '%s' value has changed", record.Key)
+		}
+		return nil
+	}, options)
+}
+
+// set sets the 'record' if the optional 'check' of the stored record passes. The stored record is nil if there is
+// no non expired record with the same key.
+func (m *Memory) set(record *store.Record, check func(stored *store.Record) error, options []store.SetOption) error {
+	if record == nil {
+		return errors.WrapDet(store.ErrStore, "provided nil record")
+	}
+	o := &store.SetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	cp := record.Copy()
+	cp.Key = m.key(record.Key)
+
+	ttl := o.TTL
+	if ttl == 0 && cp.ExpiresAt.IsZero() {
+		ttl = m.Options.DefaultExpiration
+	}
+	if ttl > 0 {
+		cp.ExpiresAt = m.Options.TimeFunc().Add(ttl)
+	} else if ttl < 0 {
+		cp.ExpiresAt = time.Time{}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	stored, ok := m.records[cp.Key]
+	if !ok || m.isExpired(stored) {
+		stored = nil
+	}
+	if check != nil {
+		if err := check(stored); err != nil {
+			return err
+		}
+	}
+	m.records[cp.Key] = cp
+	return nil
+}
+
+// Get implements store.Store interface.
+func (m *Memory) Get(_ context.Context, key string) (*store.Record, error) {
+	m.lock.RLock()
+	record, ok := m.records[m.key(key)]
+	m.lock.RUnlock()
+	if !ok || m.isExpired(record) {
+		return nil, errors.WrapDetf(store.ErrRecordNotFound, "record: '%s' not found", key)
+	}
+	return m.recordCopy(record), nil
+}
+
+// Delete implements store.Store interface.
+func (m *Memory) Delete(_ context.Context, key string) error {
+	fullKey := m.key(key)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	record, ok := m.records[fullKey]
+	if !ok {
+		return errors.WrapDetf(store.ErrRecordNotFound, "record: '%s' not found", key)
+	}
+	delete(m.records, fullKey)
+	if m.isExpired(record) {
+		return errors.WrapDetf(store.ErrRecordNotFound, "record: '%s' not found", key)
+	}
+	return nil
+}
+
+// Find implements store.Store interface. The records are sorted by their keys. The pattern prefix and suffix are
+// matched with the record keys without the store prefix and suffix.
+func (m *Memory) Find(_ context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	pattern := &store.FindPattern{}
+	for _, option := range options {
+		option(pattern)
+	}
+	if pattern.Limit < 0 || pattern.Offset < 0 {
+		return nil, errors.WrapDetf(store.ErrStore, "invalid find pattern limit: %d or offset: %d", pattern.Limit, pattern.Offset)
+	}
+
+	m.lock.RLock()
+	var records []*store.Record
+	for _, record := range m.records {
+		if m.isExpired(record) {
+			continue
+		}
+		key := m.trimKey(record.Key)
+		if strings.HasPrefix(key, pattern.Prefix) && strings.HasSuffix(key, pattern.Suffix) {
+			records = append(records, m.recordCopy(record))
+		}
+	}
+	m.lock.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	if pattern.Offset >= len(records) {
+		return []*store.Record{}, nil
+	}
+	records = records[pattern.Offset:]
+	if pattern.Limit > 0 && pattern.Limit < len(records) {
+		records = records[:pattern.Limit]
+	}
+	return records, nil
+}
+
+// Close stops the cleanup goroutine. Implements service.Closer interface.
+func (m *Memory) Close(ctx context.Context) error {
+	m.closeOnce.Do(func() {
+		close(m.done)
+	})
+	select {
+	case <-m.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DeleteExpired deletes all expired records from the store.
+func (m *Memory) DeleteExpired() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var deleted int
+	for key, record := range m.records {
+		if m.isExpired(record) {
+			delete(m.records, key)
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		log.Debug2f("Memory store deleted: %d expired records", deleted)
+	}
+}
+
+func (m *Memory) cleanup() {
+	defer close(m.stopped)
+	ticker := time.NewTicker(m.Options.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.DeleteExpired()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+func (m *Memory) isExpired(record *store.Record) bool {
+	return !record.ExpiresAt.IsZero() && !m.Options.TimeFunc().Before(record.ExpiresAt)
+}
+
+// key creates the store key with the options prefix and suffix.
+func (m *Memory) key(key string) string {
+	return m.Options.Prefix + key + m.Options.Suffix
+}
+
+// trimKey trims the options prefix and suffix from the store 'key'.
+func (m *Memory) trimKey(key string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(key, m.Options.Prefix), m.Options.Suffix)
+}
+
+// recordCopy creates the 'record' copy with the key without the options prefix and suffix.
+func (m *Memory) recordCopy(record *store.Record) *store.Record {
+	cp := record.Copy()
+	cp.Key = m.trimKey(record.Key)
+	return cp
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func TestSetGet(t *testing.T) {
+	ctx := context.Background()
+	clock := &testClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := New(store.WithTimeFunc(clock.Now), store.WithDefaultExpiration(time.Minute), store.WithPrefix("pre_"), store.WithSuffix("_suf"))
+
+	require.NoError(t, m.Set(ctx, &store.Record{Key: "default", Value: []byte("value")}))
+	require.NoError(t, m.Set(ctx, &store.Record{Key: "ttl", Value: []byte("value")}, store.SetWithTTL(time.Hour)))
+	require.NoError(t, m.Set(ctx, &store.Record{Key: "persistent", Value: []byte("value")}, store.SetWithTTL(-1)))
+
+	record, err := m.Get(ctx, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "default", record.Key)
+	assert.Equal(t, []byte("value"), record.Value)
+	assert.Equal(t, clock.now.Add(time.Minute), record.ExpiresAt)
+
+	// The returned record should be a copy.
+	record.Value[0] = 'x'
+	record, err = m.Get(ctx, "default")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), record.Value)
+
+	_, ok := m.records["pre_default_suf"]
+	assert.True(t, ok)
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	_, err = m.Get(ctx, "default")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+
+	_, err = m.Get(ctx, "ttl")
+	assert.NoError(t, err)
+
+	clock.now = clock.now.Add(24 * time.Hour)
+	_, err = m.Get(ctx, "ttl")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+
+	record, err = m.Get(ctx, "persistent")
+	require.NoError(t, err)
+	assert.True(t, record.ExpiresAt.IsZero())
+
+	m.DeleteExpired()
+	assert.Len(t, m.records, 1)
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	require.NoError(t, m.Set(ctx, &store.Record{Key: "key"}))
+	require.NoError(t, m.Delete(ctx, "key"))
+
+	err := m.Delete(ctx, "key")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+	_, err = m.Get(ctx, "key")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+}
+
+func TestConditionalSet(t *testing.T) {
+	ctx := context.Background()
+	clock := &testClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := New(store.WithTimeFunc(clock.Now))
+
+	require.NoError(t, m.SetIfNotExists(ctx, &store.Record{Key: "key", Value: []byte("1")}, store.SetWithTTL(time.Minute)))
+	err := m.SetIfNotExists(ctx, &store.Record{Key: "key", Value: []byte("2")})
+	assert.True(t, errors.Is(err, store.ErrPreconditionFailed))
+
+	err = m.SetIfValue(ctx, &store.Record{Key: "key", Value: []byte("2")}, []byte("0"))
+	assert.True(t, errors.Is(err, store.ErrPreconditionFailed))
+	require.NoError(t, m.SetIfValue(ctx, &store.Record{Key: "key", Value: []byte("2")}, []byte("1"), store.SetWithTTL(time.Minute)))
+	record, err := m.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), record.Value)
+
+	// The expired record doesn't exist.
+	clock.now = clock.now.Add(time.Hour)
+	err = m.SetIfValue(ctx, &store.Record{Key: "key", Value: []byte("3")}, []byte("2"))
+	assert.True(t, errors.Is(err, store.ErrPreconditionFailed))
+	require.NoError(t, m.SetIfNotExists(ctx, &store.Record{Key: "key", Value: []byte("3")}))
+}
+
+func TestFind(t *testing.T) {
+	ctx := context.Background()
+	clock := &testClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := New(store.WithTimeFunc(clock.Now), store.WithPrefix("pre_"))
+
+	for _, key := range []string{"user_3_token", "user_1_token", "user_2_token", "user_2_session", "admin_1_token"} {
+		require.NoError(t, m.Set(ctx, &store.Record{Key: key}))
+	}
+	require.NoError(t, m.Set(ctx, &store.Record{Key: "user_4_token"}, store.SetWithTTL(time.Second)))
+	clock.now = clock.now.Add(time.Minute)
+
+	keys := func(records []*store.Record) []string {
+		var result []string
+		for _, record := range records {
+			result = append(result, record.Key)
+		}
+		return result
+	}
+
+	records, err := m.Find(ctx, store.FindWithPrefix("user_"), store.FindWithSuffix("_token"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user_1_token", "user_2_token", "user_3_token"}, keys(records))
+
+	records, err = m.Find(ctx, store.FindWithSuffix("_token"), store.FindWithOffset(1), store.FindWithLimit(2))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user_1_token", "user_2_token"}, keys(records))
+
+	records, err = m.Find(ctx, store.FindWithOffset(10))
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	_, err = m.Find(ctx, store.FindWithLimit(-1))
+	assert.Error(t, err)
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := context.Background()
+	m := New(store.WithCleanupInterval(time.Millisecond))
+
+	require.NoError(t, m.Set(ctx, &store.Record{Key: "key"}, store.SetWithTTL(time.Millisecond)))
+	assert.Eventually(t, func() bool {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+		return len(m.records) == 0
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, m.Close(ctx))
+	require.NoError(t, m.Close(ctx))
+
+	select {
+	case <-m.stopped:
+	default:
+		t.Error("cleanup goroutine not stopped")
+	}
+}
@@ -39,6 +39,13 @@ func WithDefaultExpiration(expiration time.Duration) Option {
 	}
 }
 
+// WithCleanupInterval sets the interval of the expired records cleanup.
+func WithCleanupInterval(interval time.Duration) Option {
+	return func(o *Options) {
+		o.CleanupInterval = interval
+	}
+}
+
 // WithPrefix sets the default prefix for the keys using this store.
 func WithPrefix(prefix string) Option {
 	return func(o *Options) {
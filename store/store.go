@@ -17,6 +17,17 @@ type Store interface {
 	Find(ctx context.Context, options ...FindOption) ([]*Record, error)
 }
 
+// ConditionalSetter is an interface for the stores that could atomically set the records only if the stored record
+// satisfies the condition. It allows to implement the atomic counters and the one-time markers on top of the store.
+type ConditionalSetter interface {
+	// SetIfNotExists sets the record only if no record is stored with its key. If the record already exists
+	// the function should return ErrPreconditionFailed.
+	SetIfNotExists(ctx context.Context, record *Record, options ...SetOption) error
+	// SetIfValue sets the record only if the value of the record stored with its key is equal to 'value'.
+	// If no record is stored or its value differs the function should return ErrPreconditionFailed.
+	SetIfValue(ctx context.Context, record *Record, value []byte, options ...SetOption) error
+}
+
 // Record is a single entry stored within a store.
 type Record struct {
 	// Key is the key at which the record would be stored